@@ -9,7 +9,9 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {},
+        "contact": {
+            "name": "James Cook"
+        },
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
@@ -17,18 +19,18 @@ const docTemplate = `{
     "paths": {
         "/movies": {
             "get": {
-                "description": "Retorna uma lista de filmes, com a possibilidade de usar limit e offset para paginação.",
+                "description": "Retorna uma lista de filmes, com a possibilidade de usar limit e offset para paginação. Os filtros são aplicados no banco, antes da paginação.\nEnviando o parâmetro cursor (vazio na primeira página), a paginação passa a ser por cursor e a resposta traz next_cursor.\nO header Link (RFC 8288) traz as páginas first/prev/next/last disponíveis no modo escolhido. Com include_total=true, o total de filmes vem no header X-Total-Count.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Movies"
                 ],
-                "summary": "Lista os filmes com paginação",
+                "summary": "Lista os filmes com paginação e filtros",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 20,
+                        "default": 10,
                         "description": "Número de resultados por página",
                         "name": "limit",
                         "in": "query"
@@ -39,6 +41,56 @@ const docTemplate = `{
                         "description": "Número de resultados a pular",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho do título (ignora maiúsculas e acentos)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano exato",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano inicial (inclusivo)",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano final (inclusivo)",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Década pelo ano inicial, ex.: 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-year,title",
+                        "description": "Ordenação: campos separados por vírgula, com - para decrescente (title, year, runtime_minutes, release_date)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor devolvido em next_cursor; vazio inicia a paginação por cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Conta o total de filmes que atendem aos filtros",
+                        "name": "include_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -47,12 +99,66 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_jamescookdev_projeto-sipub-tech_movies-service_gen_go.Movie"
+                                "$ref": "#/definitions/movies.Movie"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links de paginação (first, prev, next, last)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total de filmes, quando include_total=true"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Envia um evento para criação de filme. A operação é processada em background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Solicita a criação de um novo filme (assíncrono)",
+                "parameters": [
+                    {
+                        "description": "Dados para criar o filme",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateMovieRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -63,7 +169,7 @@ const docTemplate = `{
                                     {
                                         "type": "object",
                                         "properties": {
-                                            "error": {
+                                            "message": {
                                                 "type": "string"
                                             }
                                         }
@@ -71,11 +177,37 @@ const docTemplate = `{
                                 ]
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/movies/bulk": {
             "post": {
-                "description": "Adiciona um novo filme ao banco de dados.",
+                "description": "Valida cada filme e enfileira os válidos em um único evento movie.bulk_created, gravado em background com InsertMany. A resposta traz o resultado de cada filme pela posição: accepted ou rejected, com o erro.\nFilmes recusados não impedem os demais; quando nenhum é aceito, nada é enfileirado. Filmes que já existem são descartados pelo movies-service ao gravar o lote. Aceita até 500 filmes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,85 +217,73 @@ const docTemplate = `{
                 "tags": [
                     "Movies"
                 ],
-                "summary": "Cria um novo filme",
+                "summary": "Solicita a criação de vários filmes (assíncrono)",
                 "parameters": [
                     {
-                        "description": "Dados para criar o filme",
-                        "name": "movie",
+                        "description": "Filmes a criar",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api_handlers.CreateMovieRequest"
+                            "$ref": "#/definitions/handlers.BulkCreateMoviesRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/github_com_jamescookdev_projeto-sipub-tech_movies-service_gen_go.Movie"
+                            "$ref": "#/definitions/handlers.BulkCreateMoviesResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "error": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "error": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
                 }
             }
         },
-        "/movies/{id}": {
+        "/movies/by-external/{provider}/{id}": {
             "get": {
-                "description": "Retorna os detalhes de um filme específico baseado no seu ID.",
+                "description": "Retorna o filme que tem o id informado no provedor (imdb, tmdb ou movielens), para que outros sistemas encontrem o filme pelos ids que já têm. Um id do IMDb só com dígitos é completado com o prefixo \"tt\". O header ETag traz a versão do filme.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Movies"
                 ],
-                "summary": "Busca um filme por ID",
+                "summary": "Busca um filme pelo id em outro catálogo",
                 "parameters": [
                     {
+                        "enum": [
+                            "imdb",
+                            "tmdb",
+                            "movielens"
+                        ],
                         "type": "string",
-                        "format": "mongodb-id",
-                        "description": "ID do Filme",
+                        "description": "Provedor",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "tt0000010",
+                        "description": "Id do filme no provedor",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -173,148 +293,1429 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_jamescookdev_projeto-sipub-tech_movies-service_gen_go.Movie"
+                            "$ref": "#/definitions/movies.Movie"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do filme"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "error": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "error": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove um filme do banco de dados baseado no seu ID.",
+            }
+        },
+        "/movies/export": {
+            "get": {
+                "description": "Envia todos os filmes que atendem aos filtros, sem paginação, em uma resposta com Transfer-Encoding: chunked: cada filme é escrito assim que chega do movies-service, então nem o gateway nem o serviço guardam o catálogo em memória.\nAceita os mesmos filtros e a mesma ordenação de GET /movies. No CSV, as colunas de lista (genres, directors, cast) separam os itens com \"|\".",
                 "produces": [
-                    "application/json"
+                    "application/x-ndjson",
+                    "text/csv"
                 ],
                 "tags": [
                     "Movies"
                 ],
-                "summary": "Deleta um filme",
+                "summary": "Exporta o catálogo (NDJSON ou CSV)",
                 "parameters": [
                     {
+                        "enum": [
+                            "ndjson",
+                            "csv"
+                        ],
                         "type": "string",
-                        "format": "mongodb-id",
-                        "description": "ID do Filme",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "ndjson",
+                        "description": "Formato do arquivo",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho do título (ignora maiúsculas e acentos)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano exato",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano inicial (inclusivo)",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano final (inclusivo)",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Década pelo ano inicial, ex.: 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ordenação, como em GET /movies",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "message": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "type": "file"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "error": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "allOf": [
-                                    {
-                                        "type": "string"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "error": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
                 }
             }
-        }
-    },
+        },
+        "/movies/import": {
+            "post": {
+                "description": "Recebe o arquivo no campo file de um multipart/form-data e valida cada linha com as regras de criação. As linhas aceitas são gravadas à medida que o arquivo é lido (RPC ImportMovies, com streaming do cliente); uma linha recusada não impede as demais.\nModos: insert (padrão) só cria e recusa filmes que já existem; upsert cria ou substitui os campos do filme com o mesmo título e ano; dry-run faz as verificações do insert sem gravar nada.\nO formato vem do parâmetro format ou, na falta dele, da extensão do arquivo (.csv, .ndjson/.jsonl, .json). O CSV usa as colunas da exportação (title e year obrigatórias, listas separadas por \"|\", ids externos como imdb:tt0000010); o JSON é um array de filmes.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Importa filmes de um arquivo (CSV, NDJSON ou JSON)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo com os filmes",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "upsert",
+                            "dry-run"
+                        ],
+                        "type": "string",
+                        "default": "insert",
+                        "description": "Modo da importação",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Formato do arquivo, quando a extensão não o indica",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/search": {
+            "get": {
+                "description": "Busca os termos no título e na sinopse, ordenando por relevância. Cada resultado traz a pontuação e os trechos encontrados, destacados com \u003cem\u003e.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Busca textual de filmes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Termos da busca",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Máximo de resultados (até 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/movies.SearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/trash": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lista os filmes removidos que ainda não foram expurgados, com os mesmos filtros, ordenação e paginação de GET /movies. Cada filme traz deleted_at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Lista a lixeira (administradores)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Número de resultados por página",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Número de resultados a pular",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho do título (ignora maiúsculas e acentos)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano exato",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano inicial (inclusivo)",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano final (inclusivo)",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Década pelo ano inicial, ex.: 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ordenação, como em GET /movies",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor devolvido em next_cursor; vazio inicia a paginação por cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Conta o total de filmes que atendem aos filtros",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/movies.Movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}": {
+            "get": {
+                "description": "Retorna os detalhes de um filme específico baseado no seu ID. O header ETag traz a versão do filme, para uso em If-Match nas escritas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Busca um filme por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já conhecido; responde 304 se o filme não mudou",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/movies.Movie"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do filme"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Filme não mudou desde o ETag informado"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui todos os campos editáveis do filme. Campos omitidos ficam vazios.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Solicita a substituição completa de um filme (assíncrono)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag obtido no GET; a substituição só é aplicada se o filme continuar nessa versão",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Novos dados do filme",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateMovieRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "allOf": [
+                                    {
+                                        "type": "string"
+                                    },
+                                    {
+                                        "type": "object",
+                                        "properties": {
+                                            "message": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Envia um evento para deletar um filme. A operação é processada em background. O filme vai para a lixeira e pode ser restaurado até ser expurgado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Solicita a deleção de um filme (assíncrono)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag obtido no GET; a deleção só é aplicada se o filme continuar nessa versão",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "allOf": [
+                                    {
+                                        "type": "string"
+                                    },
+                                    {
+                                        "type": "object",
+                                        "properties": {
+                                            "message": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Altera apenas os campos enviados no corpo. A existência do filme é verificada antes de o evento ser publicado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Solicita a atualização parcial de um filme (assíncrono)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag obtido no GET; a atualização só é aplicada se o filme continuar nessa versão",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMovieRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "allOf": [
+                                    {
+                                        "type": "string"
+                                    },
+                                    {
+                                        "type": "object",
+                                        "properties": {
+                                            "message": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/history": {
+            "get": {
+                "description": "Lista quem alterou o filme, quando e os valores antigos e novos de cada campo, das alterações mais recentes para as mais antigas. Para a próxima página, envie em before_version a versão da última revisão recebida.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Histórico de alterações de um filme",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Máximo de revisões (padrão 20, máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lista só as revisões anteriores a essa versão",
+                        "name": "before_version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MovieHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/restore": {
+            "post": {
+                "description": "Desfaz a remoção de um filme que ainda não foi expurgado. Falha com 409 se outro filme com o mesmo título e ano foi criado depois da remoção.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Restaura um filme da lixeira",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/movies.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/revert": {
+            "post": {
+                "description": "Volta os campos editáveis do filme aos valores que tinham na versão informada. A reversão gera uma nova versão e aparece no histórico. Falha com 409 se o título e ano restaurados já pertencem a outro filme ou se o filme foi alterado durante a reversão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Reverte um filme para uma versão do histórico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "mongodb-id",
+                        "description": "ID do Filme",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Versão a restaurar",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RevertMovieRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/movies.Movie"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do filme"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies:batchDelete": {
+            "post": {
+                "description": "Move os filmes para a lixeira na hora (sem fila) e retorna um resultado por ID: o filme removido, com deleted_at, ou o erro daquele ID. Um ID que falha não impede os demais. Aceita até 500 IDs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Remove vários filmes",
+                "parameters": [
+                    {
+                        "description": "IDs dos filmes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchIDsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quem faz a alteração, registrado no histórico do filme",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchMoviesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies:batchGet": {
+            "post": {
+                "description": "Retorna um resultado por ID, na ordem pedida: o filme ou o erro daquele ID (ex.: MOVIE_NOT_FOUND). Um ID que falha não impede os demais. Aceita até 500 IDs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Busca vários filmes por ID",
+                "parameters": [
+                    {
+                        "description": "IDs dos filmes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchMoviesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
-        "api_handlers.CreateMovieRequest": {
+        "handlers.BatchIDsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "665f1c2a9b1e8a3d4c5b6a79",
+                        "665f1c2a9b1e8a3d4c5b6a7a"
+                    ]
+                }
+            }
+        },
+        "handlers.BatchMovieResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handlers.ItemError"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "665f1c2a9b1e8a3d4c5b6a79"
+                },
+                "movie": {
+                    "$ref": "#/definitions/movies.Movie"
+                }
+            }
+        },
+        "handlers.BatchMoviesResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BatchMovieResult"
+                    }
+                }
+            }
+        },
+        "handlers.BulkCreateMoviesRequest": {
+            "type": "object",
+            "required": [
+                "movies"
+            ],
+            "properties": {
+                "movies": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handlers.CreateMovieRequest"
+                    }
+                }
+            }
+        },
+        "handlers.BulkCreateMoviesResponse": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BulkCreateResult"
+                    }
+                }
+            }
+        },
+        "handlers.BulkCreateResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handlers.ItemError"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "accepted",
+                        "rejected"
+                    ],
+                    "example": "accepted"
+                }
+            }
+        },
+        "handlers.CreateMovieRequest": {
             "type": "object",
             "required": [
                 "title",
                 "year"
             ],
             "properties": {
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Matthew McConaughey",
+                        "Anne Hathaway"
+                    ]
+                },
+                "directors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Christopher Nolan"
+                    ]
+                },
+                "external_ids": {
+                    "description": "Ids do filme em outros catálogos, por provedor (imdb, tmdb, movielens).",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "imdb": "tt0816692",
+                        "tmdb": "157336"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Ficção científica",
+                        "Drama"
+                    ]
+                },
+                "original_language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2014-11-06"
+                },
+                "runtime_minutes": {
+                    "type": "integer",
+                    "example": 169
+                },
+                "synopsis": {
+                    "type": "string",
+                    "example": "Um grupo de astronautas viaja por um buraco de minhoca em busca de um novo lar para a humanidade."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Interestelar"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2014
+                }
+            }
+        },
+        "handlers.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "runtime_minutes"
+                },
+                "new": {
+                    "type": "object"
+                },
+                "old": {
+                    "type": "object"
+                }
+            }
+        },
+        "handlers.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "year"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "O ano deve estar entre 1888 e 2026"
+                }
+            }
+        },
+        "handlers.ImportReport": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "insert"
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportRowResult"
+                    }
+                }
+            }
+        },
+        "handlers.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "unchanged",
+                        "valid"
+                    ],
+                    "example": "created"
+                },
+                "error": {
+                    "$ref": "#/definitions/handlers.ItemError"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "665f1c2a9b1e8a3d4c5b6a79"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "accepted",
+                        "rejected"
+                    ],
+                    "example": "accepted"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bacurau"
+                }
+            }
+        },
+        "handlers.ItemError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "MOVIE_NOT_FOUND"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Filme não encontrado"
+                }
+            }
+        },
+        "handlers.MovieHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MovieRevisionResponse"
+                    }
+                }
+            }
+        },
+        "handlers.MovieRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "maria"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldChangeResponse"
+                    }
+                },
+                "reverted_to": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/movies.Movie"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2024-05-01T12:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "MOVIE_NOT_FOUND"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Filme não encontrado"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/movies/665f1c2a9b1e8a3d4c5b6a79"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Não encontrado"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:movies:error:MOVIE_NOT_FOUND"
+                }
+            }
+        },
+        "handlers.RevertMovieRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.UpdateMovieRequest": {
+            "type": "object",
+            "properties": {
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Matthew McConaughey",
+                        "Anne Hathaway"
+                    ]
+                },
+                "directors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Christopher Nolan"
+                    ]
+                },
+                "external_ids": {
+                    "description": "Ids do filme em outros catálogos, por provedor (imdb, tmdb, movielens). Substitui o mapa inteiro.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "imdb": "tt0816692",
+                        "tmdb": "157336"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Ficção científica",
+                        "Drama"
+                    ]
+                },
+                "original_language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2014-11-06"
+                },
+                "runtime_minutes": {
+                    "type": "integer",
+                    "example": 169
+                },
+                "synopsis": {
+                    "type": "string",
+                    "example": "Um grupo de astronautas viaja por um buraco de minhoca em busca de um novo lar para a humanidade."
+                },
                 "title": {
                     "type": "string",
                     "example": "Interestelar"
@@ -325,19 +1726,102 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_jamescookdev_projeto-sipub-tech_movies-service_gen_go.Movie": {
+        "movies.Movie": {
             "type": "object",
             "properties": {
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deleted_at": {
+                    "description": "Momento da remoção (RFC 3339), preenchido só nos filmes da lixeira.",
+                    "type": "string"
+                },
+                "directors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "external_ids": {
+                    "description": "Ids do filme em outros catálogos, por provedor: imdb (\"tt0000010\"), tmdb e movielens (números).",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
+                "original_language": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "description": "Data de lançamento no formato YYYY-MM-DD. Vazio quando desconhecida.",
+                    "type": "string"
+                },
+                "runtime_minutes": {
+                    "type": "integer"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 },
+                "version": {
+                    "description": "Versão do filme, incrementada a cada escrita. Em UpdateMovie, um valor diferente de zero\né a versão esperada: se o filme estiver em outra versão, a chamada falha com FAILED_PRECONDITION.",
+                    "type": "integer"
+                },
                 "year": {
                     "type": "integer"
                 }
             }
+        },
+        "movies.SearchHighlight": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "snippet": {
+                    "type": "string"
+                }
+            }
+        },
+        "movies.SearchResult": {
+            "type": "object",
+            "properties": {
+                "highlights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/movies.SearchHighlight"
+                    }
+                },
+                "movie": {
+                    "$ref": "#/definitions/movies.Movie"
+                },
+                "score": {
+                    "description": "Relevância do filme para a busca; maior é mais relevante.",
+                    "type": "number"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Token das rotas administrativas (ADMIN_TOKEN).",
+            "type": "apiKey",
+            "name": "X-Admin-Token",
+            "in": "header"
         }
     }
 }`
@@ -347,7 +1831,7 @@ var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
 	BasePath:         "/",
-	Schemes:          []string{},
+	Schemes:          []string{"http"},
 	Title:            "API de Filmes - Microsserviços com Go e gRPC",
 	Description:      "Esta é uma API REST para consulta e gerenciamento de filmes.",
 	InfoInstanceName: "swagger",
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// actorHeader identifica quem faz a escrita. O valor vai para o histórico de alterações do filme.
+const actorHeader = "X-Actor"
+
+func actorOf(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader(actorHeader))
+}
+
+// withActor repassa o X-Actor ao movies-service no metadado x-actor das chamadas gRPC síncronas.
+func withActor(c *gin.Context) context.Context {
+	if actor := actorOf(c); actor != "" {
+		return metadata.AppendToOutgoingContext(c.Request.Context(), "x-actor", actor)
+	}
+	return c.Request.Context()
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminTokenHeader é o header que autentica as rotas administrativas.
+const AdminTokenHeader = "X-Admin-Token"
+
+// RequireAdmin protege rotas administrativas com um token fixo (ADMIN_TOKEN). Sem token configurado,
+// as rotas ficam fechadas para todos.
+func RequireAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			respondProblem(c, http.StatusForbidden, codeAdminDisabled, "Rotas administrativas desabilitadas: ADMIN_TOKEN não configurado.")
+			return
+		}
+		provided := c.GetHeader(AdminTokenHeader)
+		if provided == "" {
+			respondProblem(c, http.StatusUnauthorized, codeMissingAdminToken, "Header "+AdminTokenHeader+" é obrigatório.")
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			respondProblem(c, http.StatusForbidden, codeInvalidAdminToken, "Token de administrador inválido.")
+			return
+		}
+		c.Next()
+	}
+}
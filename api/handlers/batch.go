@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
+)
+
+// BatchIDsRequest traz os IDs de uma operação em lote (até 500).
+type BatchIDsRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1" example:"665f1c2a9b1e8a3d4c5b6a79,665f1c2a9b1e8a3d4c5b6a7a"`
+}
+
+// BulkCreateMoviesRequest traz os filmes a criar (até 500).
+type BulkCreateMoviesRequest struct {
+	Movies []CreateMovieRequest `json:"movies" binding:"required,min=1"`
+}
+
+// ItemError é o erro de um item do lote. O code é o mesmo das respostas de erro das rotas de um filme só.
+type ItemError struct {
+	Code    string       `json:"code" example:"MOVIE_NOT_FOUND"`
+	Message string       `json:"message" example:"Filme não encontrado"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// BatchMovieResult é o resultado de um ID do lote: o filme, quando deu certo, ou o erro daquele item.
+type BatchMovieResult struct {
+	ID    string     `json:"id" example:"665f1c2a9b1e8a3d4c5b6a79"`
+	Movie *pb.Movie  `json:"movie,omitempty"`
+	Error *ItemError `json:"error,omitempty"`
+}
+
+// BatchMoviesResponse traz um resultado por ID pedido, na mesma ordem.
+type BatchMoviesResponse struct {
+	Results []BatchMovieResult `json:"results"`
+}
+
+// BulkCreateResult é o resultado de um filme do lote de criação, pela posição em que foi enviado.
+type BulkCreateResult struct {
+	Index  int        `json:"index" example:"0"`
+	Status string     `json:"status" enums:"accepted,rejected" example:"accepted"`
+	Error  *ItemError `json:"error,omitempty"`
+}
+
+// BulkCreateMoviesResponse resume o lote: quantos filmes foram enfileirados, quantos recusados e o resultado de cada um.
+type BulkCreateMoviesResponse struct {
+	Accepted int                `json:"accepted" example:"2"`
+	Rejected int                `json:"rejected" example:"1"`
+	Results  []BulkCreateResult `json:"results"`
+}
+
+// MovieCollectionAction atende os métodos customizados da coleção (POST /movies:batchGet e /movies:batchDelete).
+// O Gin trata o ":" como início de parâmetro, então o nome do método chega em c.Param("action") e é despachado aqui.
+func (h *MovieHandler) MovieCollectionAction(c *gin.Context) {
+	switch c.Param("action") {
+	case ":batchGet":
+		h.BatchGetMovies(c)
+	case ":batchDelete":
+		h.BatchDeleteMovies(c)
+	default:
+		NoRoute(c)
+	}
+}
+
+// BatchGetMovies
+// @Summary      Busca vários filmes por ID
+// @Description  Retorna um resultado por ID, na ordem pedida: o filme ou o erro daquele ID (ex.: MOVIE_NOT_FOUND). Um ID que falha não impede os demais. Aceita até 500 IDs.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        body  body      BatchIDsRequest  true  "IDs dos filmes"
+// @Success      200   {object}  BatchMoviesResponse
+// @Failure      400   {object}  Problem
+// @Failure      500   {object}  Problem
+// @Router       /movies:batchGet [post]
+func (h *MovieHandler) BatchGetMovies(c *gin.Context) {
+	var req BatchIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	res, err := h.MovieClient.BatchGetMovies(c.Request.Context(), &pb.BatchGetMoviesRequest{Ids: req.IDs})
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC BatchGetMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, batchMoviesResponse(req.IDs, res.Results))
+}
+
+// BatchDeleteMovies
+// @Summary      Remove vários filmes
+// @Description  Move os filmes para a lixeira na hora (sem fila) e retorna um resultado por ID: o filme removido, com deleted_at, ou o erro daquele ID. Um ID que falha não impede os demais. Aceita até 500 IDs.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        body     body      BatchIDsRequest  true   "IDs dos filmes"
+// @Param        X-Actor  header    string           false  "Quem faz a alteração, registrado no histórico do filme"
+// @Success      200      {object}  BatchMoviesResponse
+// @Failure      400      {object}  Problem
+// @Failure      500      {object}  Problem
+// @Router       /movies:batchDelete [post]
+func (h *MovieHandler) BatchDeleteMovies(c *gin.Context) {
+	var req BatchIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	res, err := h.MovieClient.BulkDeleteMovies(withActor(c), &pb.BulkDeleteMoviesRequest{Ids: req.IDs})
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC BulkDeleteMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, batchMoviesResponse(req.IDs, res.Results))
+}
+
+// BulkCreateMovies (ASSÍNCRONO)
+// @Summary      Solicita a criação de vários filmes (assíncrono)
+// @Description  Valida cada filme e enfileira os válidos em um único evento movie.bulk_created, gravado em background com InsertMany. A resposta traz o resultado de cada filme pela posição: accepted ou rejected, com o erro.
+// @Description  Filmes recusados não impedem os demais; quando nenhum é aceito, nada é enfileirado. Filmes que já existem são descartados pelo movies-service ao gravar o lote. Aceita até 500 filmes.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        body     body      BulkCreateMoviesRequest  true   "Filmes a criar"
+// @Param        X-Actor  header    string                   false  "Quem faz a alteração, registrado no histórico do filme"
+// @Success      202      {object}  BulkCreateMoviesResponse
+// @Failure      400      {object}  Problem
+// @Failure      500      {object}  Problem
+// @Router       /movies/bulk [post]
+func (h *MovieHandler) BulkCreateMovies(c *gin.Context) {
+	var req BulkCreateMoviesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	grpcRequest := &pb.BulkCreateMoviesRequest{ValidateOnly: true}
+	for _, movie := range req.Movies {
+		grpcRequest.Movies = append(grpcRequest.Movies, createRequestPB(movie))
+	}
+	res, err := h.MovieClient.BulkCreateMovies(c.Request.Context(), grpcRequest)
+	if err != nil {
+		log.Printf("Erro ao validar o lote via gRPC: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	response := BulkCreateMoviesResponse{Results: make([]BulkCreateResult, len(req.Movies))}
+	accepted := make([]CreateMovieRequest, 0, len(req.Movies))
+	for i, result := range res.Results {
+		if itemErr := result.GetError(); itemErr != nil {
+			response.Results[i] = BulkCreateResult{Index: i, Status: "rejected", Error: itemError(itemErr)}
+			response.Rejected++
+			continue
+		}
+		response.Results[i] = BulkCreateResult{Index: i, Status: "accepted"}
+		accepted = append(accepted, req.Movies[i])
+	}
+	response.Accepted = len(accepted)
+
+	if len(accepted) > 0 {
+		evt := MovieEvent{
+			Action:    "bulk_create",
+			Data:      gin.H{"movies": accepted},
+			Timestamp: time.Now().UTC(),
+			Actor:     actorOf(c),
+		}
+		body, _ := json.Marshal(evt)
+
+		if err := h.Publisher.Publish(c.Request.Context(), h.Publisher.RoutingKeyBulkCreated(), body); err != nil {
+			log.Printf("Erro ao publicar movie.bulk_created: %v", err)
+			respondProblem(c, http.StatusInternalServerError, codePublishFailed, "Falha ao enfileirar a criação do lote.")
+			return
+		}
+	}
+	c.JSON(http.StatusAccepted, response)
+}
+
+// createRequestPB monta o pedido de criação do gRPC com os valores do corpo.
+func createRequestPB(req CreateMovieRequest) *pb.CreateMovieRequest {
+	return &pb.CreateMovieRequest{
+		Title:            req.Title,
+		Year:             req.Year,
+		Genres:           req.Genres,
+		Directors:        req.Directors,
+		Cast:             req.Cast,
+		RuntimeMinutes:   req.RuntimeMinutes,
+		Synopsis:         req.Synopsis,
+		OriginalLanguage: req.OriginalLanguage,
+		ReleaseDate:      req.ReleaseDate,
+		ExternalIds:      req.ExternalIDs,
+	}
+}
+
+// batchMoviesResponse associa cada resultado do gRPC ao ID pedido na mesma posição.
+func batchMoviesResponse(ids []string, results []*pb.BatchResult) BatchMoviesResponse {
+	response := BatchMoviesResponse{Results: make([]BatchMovieResult, len(results))}
+	for i, result := range results {
+		response.Results[i] = BatchMovieResult{ID: ids[i], Movie: result.GetMovie()}
+		if itemErr := result.GetError(); itemErr != nil {
+			response.Results[i].Error = itemError(itemErr)
+		}
+	}
+	return response
+}
+
+// itemError traduz o erro de um item do gRPC, com as violações de campos quando houver.
+func itemError(err *pb.ItemError) *ItemError {
+	item := &ItemError{Code: err.GetCode(), Message: err.GetMessage()}
+	for _, violation := range err.GetFieldViolations() {
+		item.Errors = append(item.Errors, FieldError{Field: violation.GetField(), Message: violation.GetDescription()})
+	}
+	return item
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// errMovieChanged é a resposta 412 quando o If-Match não corresponde à versão atual do filme.
+const errMovieChanged = "O filme foi alterado desde a última leitura. Busque-o de novo e repita a operação."
+
+// movieETag é o ETag forte de um filme, derivado da versão: toda escrita incrementa a versão e muda o ETag.
+func movieETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// freshRead pede ao movies-service, no metadado cache-control, a versão atual do filme e não a do cache dele,
+// para conferir o If-Match.
+func freshRead(c *gin.Context) context.Context {
+	return metadata.AppendToOutgoingContext(c.Request.Context(), "cache-control", "no-cache")
+}
+
+// matchesIfMatch indica se a versão atende ao If-Match da requisição. Sem o header, qualquer versão serve.
+// Só ETags fortes são comparados, como manda a RFC 9110; "*" aceita qualquer filme existente.
+func matchesIfMatch(c *gin.Context, version int64) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return true
+	}
+	return matchesETagList(header, movieETag(version))
+}
+
+// matchesIfNoneMatch indica se a versão atende ao If-None-Match, caso em que um GET pode responder 304.
+func matchesIfNoneMatch(c *gin.Context, version int64) bool {
+	header := strings.TrimSpace(c.GetHeader("If-None-Match"))
+	if header == "" {
+		return false
+	}
+	// If-None-Match usa comparação fraca: W/"3" equivale a "3".
+	etag := movieETag(version)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesETagList(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
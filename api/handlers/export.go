@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
+)
+
+// exportFlushEvery é de quantos em quantos filmes a exportação envia ao cliente o que já foi escrito.
+const exportFlushEvery = 100
+
+// csvColumns são as colunas do CSV exportado, na ordem em que aparecem.
+var csvColumns = []string{
+	"id", "title", "year", "genres", "directors", "cast",
+	"runtime_minutes", "synopsis", "original_language", "release_date", "version", "external_ids",
+}
+
+// csvListSeparator separa os itens das colunas de lista (genres, directors, cast, external_ids) dentro da célula.
+const csvListSeparator = "|"
+
+// csvExternalIDSeparator separa o provedor do id em cada item da coluna external_ids, como em "imdb:tt0000010".
+const csvExternalIDSeparator = ":"
+
+// movieEncoder escreve os filmes exportados em um formato. Flush envia o que estiver em buffer.
+type movieEncoder interface {
+	Encode(movie *pb.Movie) error
+	Flush() error
+}
+
+// exportFormat é um formato aceito no parâmetro format da exportação.
+type exportFormat struct {
+	contentType string
+	extension   string
+	newEncoder  func(w io.Writer) (movieEncoder, error)
+}
+
+var exportFormats = map[string]exportFormat{
+	"ndjson": {"application/x-ndjson", "ndjson", newNDJSONEncoder},
+	"csv":    {"text/csv; charset=utf-8", "csv", newCSVEncoder},
+}
+
+// ExportMovies
+// @Summary      Exporta o catálogo (NDJSON ou CSV)
+// @Description  Envia todos os filmes que atendem aos filtros, sem paginação, em uma resposta com Transfer-Encoding: chunked: cada filme é escrito assim que chega do movies-service, então nem o gateway nem o serviço guardam o catálogo em memória.
+// @Description  Aceita os mesmos filtros e a mesma ordenação de GET /movies. No CSV, as colunas de lista (genres, directors, cast) separam os itens com "|".
+// @Tags         Movies
+// @Produce      application/x-ndjson
+// @Produce      text/csv
+// @Param        format     query     string  false  "Formato do arquivo"  Enums(ndjson, csv)  default(ndjson)
+// @Param        title      query     string  false  "Trecho do título (ignora maiúsculas e acentos)"
+// @Param        year       query     int     false  "Ano exato"
+// @Param        year_from  query     int     false  "Ano inicial (inclusivo)"
+// @Param        year_to    query     int     false  "Ano final (inclusivo)"
+// @Param        decade     query     int     false  "Década pelo ano inicial, ex.: 1990"
+// @Param        sort       query     string  false  "Ordenação, como em GET /movies"
+// @Success      200        {file}    file
+// @Failure      400        {object}  Problem
+// @Failure      500        {object}  Problem
+// @Router       /movies/export [get]
+func (h *MovieHandler) ExportMovies(c *gin.Context) {
+	name := strings.ToLower(c.DefaultQuery("format", "ndjson"))
+	format, ok := exportFormats[name]
+	if !ok {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Parâmetro format deve ser ndjson ou csv.")
+		return
+	}
+	req := &pb.ListMoviesRequest{}
+	if !bindListFilters(c, req) {
+		return
+	}
+
+	stream, err := h.MovieClient.ExportMovies(c.Request.Context(), req)
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC ExportMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	// Erros de filtro ou ordenação chegam no primeiro Recv, ainda a tempo de responder com o status certo.
+	movie, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		log.Printf("Erro ao chamar gRPC ExportMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", format.contentType)
+	c.Header("Content-Disposition", `attachment; filename="movies.`+format.extension+`"`)
+	c.Status(http.StatusOK)
+	encoder, encErr := format.newEncoder(c.Writer)
+
+	// A partir daqui o status já foi enviado: uma falha só pode interromper o arquivo.
+	for count := 1; err == nil && encErr == nil; count++ {
+		if encErr = encoder.Encode(movie); encErr != nil {
+			break
+		}
+		if count%exportFlushEvery == 0 {
+			encErr = encoder.Flush()
+			c.Writer.Flush()
+		}
+		movie, err = stream.Recv()
+	}
+	if encErr == nil {
+		encErr = encoder.Flush()
+	}
+	c.Writer.Flush()
+	if err != nil && err != io.EOF {
+		log.Printf("Exportação interrompida pelo movies-service: %v", err)
+	}
+	if encErr != nil {
+		log.Printf("Exportação interrompida ao escrever a resposta: %v", encErr)
+	}
+}
+
+// ndjsonEncoder escreve um filme por linha, no mesmo JSON das demais rotas.
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) (movieEncoder, error) {
+	return ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+}
+
+func (e ndjsonEncoder) Encode(movie *pb.Movie) error { return e.enc.Encode(movie) }
+func (e ndjsonEncoder) Flush() error                 { return nil }
+
+// csvEncoder escreve o cabeçalho com csvColumns e uma linha por filme.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) (movieEncoder, error) {
+	writer := csv.NewWriter(w)
+	return csvEncoder{w: writer}, writer.Write(csvColumns)
+}
+
+func (e csvEncoder) Encode(movie *pb.Movie) error {
+	return e.w.Write([]string{
+		movie.Id,
+		movie.Title,
+		strconv.Itoa(int(movie.Year)),
+		strings.Join(movie.Genres, csvListSeparator),
+		strings.Join(movie.Directors, csvListSeparator),
+		strings.Join(movie.Cast, csvListSeparator),
+		optionalInt(movie.RuntimeMinutes),
+		movie.Synopsis,
+		movie.OriginalLanguage,
+		movie.ReleaseDate,
+		strconv.FormatInt(movie.Version, 10),
+		joinExternalIDs(movie.ExternalIds),
+	})
+}
+
+// joinExternalIDs escreve os ids externos como itens "provedor:id", em ordem de provedor.
+func joinExternalIDs(ids map[string]string) string {
+	items := make([]string, 0, len(ids))
+	for provider, id := range ids {
+		items = append(items, provider+csvExternalIDSeparator+id)
+	}
+	sort.Strings(items)
+	return strings.Join(items, csvListSeparator)
+}
+
+func (e csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// optionalInt deixa a célula vazia quando o campo não foi informado.
+func optionalInt(value int32) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(value))
+}
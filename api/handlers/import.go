@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportLine é o tamanho máximo de uma linha de um arquivo NDJSON importado.
+const maxImportLine = 1 << 20
+
+// ImportRowResult é o resultado de uma linha do arquivo importado. Action diz o que foi feito com a linha aceita:
+// created, updated, unchanged ou, no dry-run, valid.
+type ImportRowResult struct {
+	Line   int64      `json:"line" example:"2"`
+	Status string     `json:"status" enums:"accepted,rejected" example:"accepted"`
+	Action string     `json:"action,omitempty" enums:"created,updated,unchanged,valid" example:"created"`
+	ID     string     `json:"id,omitempty" example:"665f1c2a9b1e8a3d4c5b6a79"`
+	Title  string     `json:"title,omitempty" example:"Bacurau"`
+	Error  *ItemError `json:"error,omitempty"`
+}
+
+// ImportReport é o relatório da importação, com o resultado de cada linha em ordem.
+type ImportReport struct {
+	Mode     string            `json:"mode" example:"insert"`
+	Accepted int64             `json:"accepted" example:"120"`
+	Rejected int64             `json:"rejected" example:"3"`
+	Rows     []ImportRowResult `json:"rows"`
+}
+
+// importRow é uma linha lida do arquivo: o filme ou, quando a linha não pôde ser lida, o problema dela.
+type importRow struct {
+	line    int64
+	movie   *CreateMovieRequest
+	problem *ItemError
+}
+
+// importFileError é uma falha que impede a leitura do restante do arquivo (cabeçalho inválido, JSON quebrado).
+type importFileError struct {
+	line    int64
+	message string
+}
+
+func (e *importFileError) Error() string {
+	return fmt.Sprintf("linha %d: %s", e.line, e.message)
+}
+
+// importParser lê o arquivo e chama emit para cada linha, parando no primeiro erro de emit.
+type importParser func(r io.Reader, emit func(importRow) error) error
+
+var importParsers = map[string]importParser{
+	"csv":    parseCSVImport,
+	"ndjson": parseNDJSONImport,
+	"json":   parseJSONImport,
+}
+
+// ImportMovies
+// @Summary      Importa filmes de um arquivo (CSV, NDJSON ou JSON)
+// @Description  Recebe o arquivo no campo file de um multipart/form-data e valida cada linha com as regras de criação. As linhas aceitas são gravadas à medida que o arquivo é lido (RPC ImportMovies, com streaming do cliente); uma linha recusada não impede as demais.
+// @Description  Modos: insert (padrão) só cria e recusa filmes que já existem; upsert cria ou substitui os campos do filme com o mesmo título e ano; dry-run faz as verificações do insert sem gravar nada.
+// @Description  O formato vem do parâmetro format ou, na falta dele, da extensão do arquivo (.csv, .ndjson/.jsonl, .json). O CSV usa as colunas da exportação (title e year obrigatórias, listas separadas por "|", ids externos como imdb:tt0000010); o JSON é um array de filmes.
+// @Tags         Movies
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    true   "Arquivo com os filmes"
+// @Param        mode     query     string  false  "Modo da importação"  Enums(insert, upsert, dry-run)  default(insert)
+// @Param        format   query     string  false  "Formato do arquivo, quando a extensão não o indica"  Enums(csv, ndjson, json)
+// @Param        X-Actor  header    string  false  "Quem faz a alteração, registrado no histórico do filme"
+// @Success      200      {object}  ImportReport
+// @Failure      400      {object}  Problem
+// @Failure      500      {object}  Problem
+// @Router       /movies/import [post]
+func (h *MovieHandler) ImportMovies(c *gin.Context) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Envie o arquivo em multipart/form-data, no campo file.")
+		return
+	}
+	// As partes são lidas em sequência, sem guardar o arquivo em memória nem em disco.
+	part, err := reader.NextPart()
+	for err == nil && part.FormName() != "file" {
+		part, err = reader.NextPart()
+	}
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Envie o arquivo em multipart/form-data, no campo file.")
+		return
+	}
+	format := importFormat(c.Query("format"), part.FileName(), part.Header.Get("Content-Type"))
+	parse, ok := importParsers[format]
+	if !ok {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Não foi possível identificar o formato do arquivo; informe format=csv, ndjson ou json.")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(withActor(c))
+	defer cancel()
+	stream, err := h.MovieClient.ImportMovies(ctx)
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC ImportMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	// Um erro no envio (io.EOF) significa que o movies-service encerrou o stream; o motivo vem em CloseAndRecv.
+	sendErr := stream.Send(&pb.ImportMoviesRequest{Mode: c.Query("mode")})
+	var local []ImportRowResult
+	sent := 0
+	if sendErr == nil {
+		sendErr = parse(skipBOM(part), func(row importRow) error {
+			if row.problem != nil {
+				local = append(local, ImportRowResult{Line: row.line, Status: "rejected", Error: row.problem})
+				return nil
+			}
+			sent++
+			return stream.Send(&pb.ImportMoviesRequest{Line: row.line, Movie: createRequestPB(*row.movie)})
+		})
+	}
+
+	var fileErr *importFileError
+	switch {
+	case errors.As(sendErr, &fileErr) && sent == 0 && len(local) == 0:
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Arquivo inválido: "+fileErr.Error()+".")
+		return
+	case errors.As(sendErr, &fileErr):
+		local = append(local, ImportRowResult{Line: fileErr.line, Status: "rejected", Error: &ItemError{
+			Code:    codeInvalidRow,
+			Message: fileErr.message + ". O restante do arquivo foi ignorado.",
+		}})
+	case isGRPCStatus(sendErr):
+		log.Printf("Erro ao chamar gRPC ImportMovies: %v", sendErr)
+		respondGRPCError(c, sendErr)
+		return
+	case sendErr != nil && sendErr != io.EOF:
+		log.Printf("Erro ao ler o arquivo importado: %v", sendErr)
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Falha ao ler o arquivo enviado.")
+		return
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC ImportMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, importReport(res, local))
+}
+
+// isGRPCStatus indica se err veio do gRPC, e não da leitura do arquivo.
+func isGRPCStatus(err error) bool {
+	_, ok := status.FromError(err)
+	return err != nil && err != io.EOF && ok
+}
+
+// importReport junta o relatório do movies-service às linhas que nem chegaram a ser enviadas, em ordem de linha.
+func importReport(res *pb.ImportMoviesResponse, local []ImportRowResult) ImportReport {
+	report := ImportReport{
+		Mode:     res.Mode,
+		Accepted: res.Accepted,
+		Rejected: res.Rejected + int64(len(local)),
+		Rows:     make([]ImportRowResult, 0, len(res.Rows)+len(local)),
+	}
+	for _, row := range res.Rows {
+		result := ImportRowResult{Line: row.Line, Status: "accepted", Action: row.Action}
+		if row.Error != nil {
+			result.Status = "rejected"
+			result.Error = itemError(row.Error)
+		} else {
+			result.ID, result.Title = row.Movie.GetId(), row.Movie.GetTitle()
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	report.Rows = append(report.Rows, local...)
+	sort.SliceStable(report.Rows, func(i, j int) bool { return report.Rows[i].Line < report.Rows[j].Line })
+	return report
+}
+
+// importFormat escolhe o formato pelo parâmetro format, pela extensão do arquivo ou pelo Content-Type da parte.
+func importFormat(param, filename, contentType string) string {
+	if param != "" {
+		return strings.ToLower(param)
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".json":
+		return "json"
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return "csv"
+	case "application/x-ndjson", "application/jsonl":
+		return "ndjson"
+	case "application/json":
+		return "json"
+	}
+	return ""
+}
+
+// skipBOM descarta a marca de ordem de bytes que alguns editores (como o Excel) gravam no início do arquivo.
+func skipBOM(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = buffered.Discard(3)
+	}
+	return buffered
+}
+
+// parseCSVImport lê um CSV com cabeçalho. As colunas são as da exportação; title e year são obrigatórias
+// e as demais (inclusive id e version) são opcionais ou ignoradas.
+func parseCSVImport(r io.Reader, emit func(importRow) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return &importFileError{line: 1, message: "cabeçalho do CSV ilegível"}
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"title", "year"} {
+		if _, ok := columns[required]; !ok {
+			return &importFileError{line: 1, message: "o cabeçalho do CSV precisa das colunas title e year"}
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := emit(importRow{line: int64(parseErr.StartLine), problem: &ItemError{Code: codeInvalidRow, Message: "Linha do CSV malformada: " + parseErr.Err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		row := importRow{line: int64(line)}
+		row.movie, row.problem = csvMovie(record, columns)
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+}
+
+// csvMovie monta o filme de uma linha do CSV.
+func csvMovie(record []string, columns map[string]int) (*CreateMovieRequest, *ItemError) {
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var fields []FieldError
+	number := func(name string) int32 {
+		value := get(name)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			fields = append(fields, FieldError{Field: name, Message: "Deve ser um número inteiro"})
+		}
+		return int32(n)
+	}
+
+	movie := &CreateMovieRequest{
+		Title:            get("title"),
+		Year:             number("year"),
+		Genres:           splitList(get("genres")),
+		Directors:        splitList(get("directors")),
+		Cast:             splitList(get("cast")),
+		RuntimeMinutes:   number("runtime_minutes"),
+		Synopsis:         get("synopsis"),
+		OriginalLanguage: get("original_language"),
+		ReleaseDate:      get("release_date"),
+	}
+	for _, item := range splitList(get("external_ids")) {
+		provider, id, ok := strings.Cut(item, csvExternalIDSeparator)
+		if !ok {
+			fields = append(fields, FieldError{Field: "external_ids", Message: "Use itens provedor:id separados por |"})
+			break
+		}
+		if movie.ExternalIDs == nil {
+			movie.ExternalIDs = make(map[string]string)
+		}
+		movie.ExternalIDs[strings.TrimSpace(provider)] = strings.TrimSpace(id)
+	}
+	if len(fields) > 0 {
+		return nil, &ItemError{Code: codeInvalidRow, Message: "Há campos ilegíveis na linha.", Errors: fields}
+	}
+	return movie, nil
+}
+
+// splitList separa os itens de uma coluna de lista, descartando os vazios.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, csvListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseNDJSONImport lê um filme por linha; linhas em branco são ignoradas.
+func parseNDJSONImport(r io.Reader, emit func(importRow) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLine)
+	var line int64
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		row := importRow{line: line}
+		row.movie, row.problem = jsonMovie(raw)
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return &importFileError{line: line + 1, message: "linha maior que 1 MiB"}
+	}
+	return scanner.Err()
+}
+
+// parseJSONImport lê um array de filmes, um elemento por vez. A linha de cada filme é a linha em que ele começa.
+func parseJSONImport(r io.Reader, emit func(importRow) error) error {
+	counter := &lineCounter{r: r}
+	decoder := json.NewDecoder(counter)
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil || token != json.Delim('[') {
+		return &importFileError{line: 1, message: "o arquivo JSON deve ser um array de filmes"}
+	}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			// O decodificador não avança depois do erro: a linha vem da posição do erro ou, se o arquivo acabou
+			// no meio do filme, do fim do arquivo.
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return &importFileError{line: counter.lineAt(syntaxErr.Offset), message: "JSON malformado"}
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return &importFileError{line: counter.lineAt(counter.read), message: "JSON malformado"}
+			}
+			return err
+		}
+		row := importRow{line: counter.lineAt(decoder.InputOffset() - int64(len(raw)))}
+		row.movie, row.problem = jsonMovie(raw)
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonMovie lê um filme em JSON, com os mesmos campos do corpo de POST /movies. Campos desconhecidos
+// (como id e version, presentes na exportação) são ignorados.
+func jsonMovie(raw []byte) (*CreateMovieRequest, *ItemError) {
+	var movie CreateMovieRequest
+	if err := json.Unmarshal(raw, &movie); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return nil, &ItemError{Code: codeInvalidRow, Message: "Há campos ilegíveis na linha.",
+				Errors: []FieldError{{Field: typeErr.Field, Message: "Tipo de valor inválido para o campo"}}}
+		}
+		return nil, &ItemError{Code: codeInvalidRow, Message: "A linha não é um objeto JSON válido."}
+	}
+	return &movie, nil
+}
+
+// lineCounter conta as quebras de linha lidas, para traduzir posições do decodificador JSON em linhas.
+// Só guarda as quebras ainda não consultadas, então a memória não cresce com o tamanho do arquivo.
+type lineCounter struct {
+	r        io.Reader
+	read     int64
+	newlines []int64
+	line     int64
+}
+
+func (l *lineCounter) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			l.newlines = append(l.newlines, l.read+int64(i))
+		}
+	}
+	l.read += int64(n)
+	return n, err
+}
+
+// lineAt devolve a linha (a partir de 1) da posição offset. As consultas devem vir em ordem crescente.
+func (l *lineCounter) lineAt(offset int64) int64 {
+	for len(l.newlines) > 0 && l.newlines[0] < offset {
+		l.newlines = l.newlines[1:]
+		l.line++
+	}
+	return l.line + 1
+}
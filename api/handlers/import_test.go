@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// parseAll lê o arquivo com o parser e devolve todas as linhas emitidas e o erro final.
+func parseAll(parse importParser, content string) ([]importRow, error) {
+	var rows []importRow
+	err := parse(skipBOM(strings.NewReader(content)), func(row importRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+func invalidRow(message string, fields ...FieldError) *ItemError {
+	return &ItemError{Code: codeInvalidRow, Message: message, Errors: fields}
+}
+
+func TestParseCSVImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		rows    []importRow
+		fileErr *importFileError
+	}{
+		{
+			name:    "arquivo vazio",
+			content: "",
+		},
+		{
+			name:    "só o cabeçalho",
+			content: "title,year\n",
+		},
+		{
+			name:    "cabeçalho sem year",
+			content: "title,genres\nBacurau,Drama\n",
+			fileErr: &importFileError{line: 1, message: "o cabeçalho do CSV precisa das colunas title e year"},
+		},
+		{
+			name:    "cabeçalho com BOM, maiúsculas, espaços e colunas extras",
+			content: "\xef\xbb\xbfid, Title ,YEAR,version,genres,external_ids\n665f,Bacurau,2019,3,Drama| Western |,imdb:tt2762506|tmdb:473074\n",
+			rows: []importRow{{line: 2, movie: &CreateMovieRequest{
+				Title:       "Bacurau",
+				Year:        2019,
+				Genres:      []string{"Drama", "Western"},
+				ExternalIDs: map[string]string{"imdb": "tt2762506", "tmdb": "473074"},
+			}}},
+		},
+		{
+			name:    "colunas a menos na linha",
+			content: "title,year,synopsis\nAquarius\n",
+			rows:    []importRow{{line: 2, movie: &CreateMovieRequest{Title: "Aquarius"}}},
+		},
+		{
+			name:    "números e ids externos ilegíveis",
+			content: "title,year,runtime_minutes,external_ids\nBacurau,dois mil,131min,tt2762506\n",
+			rows: []importRow{{line: 2, problem: invalidRow("Há campos ilegíveis na linha.",
+				FieldError{Field: "year", Message: "Deve ser um número inteiro"},
+				FieldError{Field: "runtime_minutes", Message: "Deve ser um número inteiro"},
+				FieldError{Field: "external_ids", Message: "Use itens provedor:id separados por |"},
+			)}},
+		},
+		{
+			name:    "linha malformada não impede as seguintes",
+			content: "title,year\nBa\"curau,2019\nAquarius,2016\n",
+			rows: []importRow{
+				{line: 2, problem: invalidRow(`Linha do CSV malformada: bare " in non-quoted-field`)},
+				{line: 3, movie: &CreateMovieRequest{Title: "Aquarius", Year: 2016}},
+			},
+		},
+		{
+			name:    "campo entre aspas com quebra de linha",
+			content: "title,year,synopsis\n\"Bacurau\",2019,\"Um povoado\nsome do mapa.\"\nAquarius,2016,\n",
+			rows: []importRow{
+				{line: 2, movie: &CreateMovieRequest{Title: "Bacurau", Year: 2019, Synopsis: "Um povoado\nsome do mapa."}},
+				{line: 4, movie: &CreateMovieRequest{Title: "Aquarius", Year: 2016}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseAll(parseCSVImport, tt.content)
+
+			if tt.fileErr != nil {
+				assert.Equal(t, tt.fileErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.rows, rows)
+		})
+	}
+}
+
+func TestParseNDJSONImport(t *testing.T) {
+	content := "{\"title\":\"Bacurau\",\"year\":2019}\n\n   \n{\"title\":\"Aquarius\",\"year\":\"2016\"}\n{\"title\":\n[1,2]\n{\"title\":\"Tatuagem\",\"year\":2013,\"id\":\"665f\",\"version\":2}\n"
+
+	rows, err := parseAll(parseNDJSONImport, content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []importRow{
+		{line: 1, movie: &CreateMovieRequest{Title: "Bacurau", Year: 2019}},
+		{line: 4, problem: invalidRow("Há campos ilegíveis na linha.", FieldError{Field: "year", Message: "Tipo de valor inválido para o campo"})},
+		{line: 5, problem: invalidRow("A linha não é um objeto JSON válido.")},
+		{line: 6, problem: invalidRow("A linha não é um objeto JSON válido.")},
+		{line: 7, movie: &CreateMovieRequest{Title: "Tatuagem", Year: 2013}},
+	}, rows)
+}
+
+func TestParseNDJSONImport_LineTooLong(t *testing.T) {
+	content := "{\"title\":\"Bacurau\",\"year\":2019}\n{\"synopsis\":\"" + strings.Repeat("a", maxImportLine) + "\"}\n"
+
+	rows, err := parseAll(parseNDJSONImport, content)
+
+	assert.Equal(t, &importFileError{line: 2, message: "linha maior que 1 MiB"}, err)
+	assert.Len(t, rows, 1)
+}
+
+func TestParseJSONImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		rows    []importRow
+		fileErr *importFileError
+	}{
+		{
+			name:    "arquivo vazio",
+			content: "",
+		},
+		{
+			name:    "array vazio",
+			content: "[]",
+		},
+		{
+			name:    "objeto em vez de array",
+			content: `{"title":"Bacurau","year":2019}`,
+			fileErr: &importFileError{line: 1, message: "o arquivo JSON deve ser um array de filmes"},
+		},
+		{
+			name:    "linha de cada filme e erros por filme",
+			content: "[\n  {\"title\": \"Bacurau\", \"year\": 2019},\n  {\"title\": \"Aquarius\",\n   \"year\": \"2016\"},\n  42,\n  {\"title\": \"Tatuagem\", \"year\": 2013}\n]\n",
+			rows: []importRow{
+				{line: 2, movie: &CreateMovieRequest{Title: "Bacurau", Year: 2019}},
+				{line: 3, problem: invalidRow("Há campos ilegíveis na linha.", FieldError{Field: "year", Message: "Tipo de valor inválido para o campo"})},
+				{line: 5, problem: invalidRow("A linha não é um objeto JSON válido.")},
+				{line: 6, movie: &CreateMovieRequest{Title: "Tatuagem", Year: 2013}},
+			},
+		},
+		{
+			name:    "JSON quebrado para a leitura no meio",
+			content: "[\n  {\"title\": \"Bacurau\", \"year\": 2019},\n  {\"title\": \"Aquarius\" \"year\": 2016}\n]\n",
+			rows:    []importRow{{line: 2, movie: &CreateMovieRequest{Title: "Bacurau", Year: 2019}}},
+			fileErr: &importFileError{line: 3, message: "JSON malformado"},
+		},
+		{
+			name:    "array sem fim",
+			content: "[\n  {\"title\": \"Bacurau\", \"year\": 2019},\n  {\"title\": \"Aqua",
+			rows:    []importRow{{line: 2, movie: &CreateMovieRequest{Title: "Bacurau", Year: 2019}}},
+			fileErr: &importFileError{line: 3, message: "JSON malformado"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseAll(parseJSONImport, tt.content)
+
+			if tt.fileErr != nil {
+				assert.Equal(t, tt.fileErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.rows, rows)
+		})
+	}
+}
+
+func TestImportFormat(t *testing.T) {
+	tests := []struct {
+		param, filename, contentType string
+		format                       string
+	}{
+		{"NDJSON", "filmes.csv", "text/csv", "ndjson"},
+		{"", "filmes.CSV", "", "csv"},
+		{"", "filmes.jsonl", "", "ndjson"},
+		{"", "filmes.json", "text/plain", "json"},
+		{"", "filmes", "application/x-ndjson; charset=utf-8", "ndjson"},
+		{"", "filmes.txt", "text/plain", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.format, importFormat(tt.param, tt.filename, tt.contentType), "%q %q %q", tt.param, tt.filename, tt.contentType)
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -9,27 +10,99 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/jamescookdev/projeto-sipub-tech/api/messaging"
 	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// CreateMovieRequest traz os dados de um filme novo. As regras de cada campo (título, ano etc.) são do
+// movies-service, validadas por uma chamada gRPC com validate_only; o tag validate só documenta o Swagger.
 type CreateMovieRequest struct {
-	Title string `json:"title" binding:"required" example:"Interestelar"`
-	Year  int32  `json:"year"  binding:"required" example:"2014"`
+	Title            string   `json:"title" validate:"required" example:"Interestelar"`
+	Year             int32    `json:"year" validate:"required" example:"2014"`
+	Genres           []string `json:"genres,omitempty" example:"Ficção científica,Drama"`
+	Directors        []string `json:"directors,omitempty" example:"Christopher Nolan"`
+	Cast             []string `json:"cast,omitempty" example:"Matthew McConaughey,Anne Hathaway"`
+	RuntimeMinutes   int32    `json:"runtime_minutes,omitempty" example:"169"`
+	Synopsis         string   `json:"synopsis,omitempty" example:"Um grupo de astronautas viaja por um buraco de minhoca em busca de um novo lar para a humanidade."`
+	OriginalLanguage string   `json:"original_language,omitempty" example:"en"`
+	ReleaseDate      string   `json:"release_date,omitempty" binding:"omitempty,datetime=2006-01-02" example:"2014-11-06"`
+	// Ids do filme em outros catálogos, por provedor (imdb, tmdb, movielens).
+	ExternalIDs map[string]string `json:"external_ids,omitempty" example:"imdb:tt0816692,tmdb:157336"`
 }
 
+// UpdateMovieRequest traz os novos valores de um filme. No PATCH, só as chaves presentes no corpo são alteradas.
+type UpdateMovieRequest struct {
+	Title            string   `json:"title,omitempty" example:"Interestelar"`
+	Year             int32    `json:"year,omitempty" example:"2014"`
+	Genres           []string `json:"genres,omitempty" example:"Ficção científica,Drama"`
+	Directors        []string `json:"directors,omitempty" example:"Christopher Nolan"`
+	Cast             []string `json:"cast,omitempty" example:"Matthew McConaughey,Anne Hathaway"`
+	RuntimeMinutes   int32    `json:"runtime_minutes,omitempty" example:"169"`
+	Synopsis         string   `json:"synopsis,omitempty" example:"Um grupo de astronautas viaja por um buraco de minhoca em busca de um novo lar para a humanidade."`
+	OriginalLanguage string   `json:"original_language,omitempty" example:"en"`
+	ReleaseDate      string   `json:"release_date,omitempty" binding:"omitempty,datetime=2006-01-02" example:"2014-11-06"`
+	// Ids do filme em outros catálogos, por provedor (imdb, tmdb, movielens). Substitui o mapa inteiro.
+	ExternalIDs map[string]string `json:"external_ids,omitempty" example:"imdb:tt0816692,tmdb:157336"`
+}
+
+// toPB monta o filme do gRPC com os valores do corpo.
+func (r UpdateMovieRequest) toPB(id string) *pb.Movie {
+	return &pb.Movie{
+		Id:               id,
+		Title:            r.Title,
+		Year:             r.Year,
+		Genres:           r.Genres,
+		Directors:        r.Directors,
+		Cast:             r.Cast,
+		RuntimeMinutes:   r.RuntimeMinutes,
+		Synopsis:         r.Synopsis,
+		OriginalLanguage: r.OriginalLanguage,
+		ReleaseDate:      r.ReleaseDate,
+		ExternalIds:      r.ExternalIDs,
+	}
+}
+
+// updatableFields são as chaves aceitas no PATCH; correspondem aos caminhos da máscara de atualização do gRPC.
+var updatableFields = []string{
+	"title", "year", "genres", "directors", "cast",
+	"runtime_minutes", "synopsis", "original_language", "release_date", "external_ids",
+}
+
+// movieUpdatedEvent é o payload de "movie.updated": o filme, seu ID e os campos a alterar.
+type movieUpdatedEvent struct {
+	ID string `json:"id"`
+	UpdateMovieRequest
+	UpdateMask []string `json:"update_mask"`
+	// ExpectedVersion vem do If-Match; o movies-service descarta a atualização se o filme mudar antes de processá-la.
+	ExpectedVersion int64 `json:"expected_version,omitempty"`
+}
+
+// MovieListResponse é o corpo da listagem no modo cursor (quando o parâmetro cursor é enviado).
+type MovieListResponse struct {
+	Movies     []*pb.Movie `json:"movies"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	TotalCount *int64      `json:"total_count,omitempty"`
+}
+
+// defaultPageSize é o limit usado quando o cliente não informa um valor válido.
+const defaultPageSize = 10
+
 // Evento padronizado publicado no RabbitMQ
 type MovieEvent struct {
-	Action    string      `json:"action"`   // "create" | "delete"
+	Action    string      `json:"action"`   // "create" | "update" | "delete"
 	Data      interface{} `json:"data"`     // payload do evento
 	Timestamp time.Time   `json:"timestamp"`
+	Actor     string      `json:"actor,omitempty"` // X-Actor da requisição, para o histórico do filme
 }
 
 type MovieHandler struct {
 	MovieClient pb.MovieServiceClient  // Leituras (GET) continuam síncronas via gRPC
-	Publisher   *messaging.Publisher   // Escritas (POST/DELETE) publicam eventos
+	Publisher   *messaging.Publisher   // Escritas (POST/PATCH/PUT/DELETE) publicam eventos
 }
 
 func NewMovieHandler(client pb.MovieServiceClient, pub *messaging.Publisher) *MovieHandler {
@@ -37,75 +110,213 @@ func NewMovieHandler(client pb.MovieServiceClient, pub *messaging.Publisher) *Mo
 }
 
 // ListMovies
-// @Summary      Lista os filmes com paginação
-// @Description  Retorna uma lista de filmes, com a possibilidade de usar limit e offset para paginação.
+// @Summary      Lista os filmes com paginação e filtros
+// @Description  Retorna uma lista de filmes, com a possibilidade de usar limit e offset para paginação. Os filtros são aplicados no banco, antes da paginação.
+// @Description  Enviando o parâmetro cursor (vazio na primeira página), a paginação passa a ser por cursor e a resposta traz next_cursor.
+// @Description  O header Link (RFC 8288) traz as páginas first/prev/next/last disponíveis no modo escolhido. Com include_total=true, o total de filmes vem no header X-Total-Count.
 // @Tags         Movies
 // @Produce      json
-// @Param        limit  query     int    false  "Número de resultados por página" default(20)
-// @Param        offset query     int    false  "Número de resultados a pular"    default(0)
-// @Success      200    {array}   pb.Movie
-// @Failure      500    {object}  map[string]string{error=string}
+// @Param        limit      query     int     false  "Número de resultados por página" default(10)
+// @Param        offset     query     int     false  "Número de resultados a pular"    default(0)
+// @Param        title      query     string  false  "Trecho do título (ignora maiúsculas e acentos)"
+// @Param        year       query     int     false  "Ano exato"
+// @Param        year_from  query     int     false  "Ano inicial (inclusivo)"
+// @Param        year_to    query     int     false  "Ano final (inclusivo)"
+// @Param        decade     query     int     false  "Década pelo ano inicial, ex.: 1990"
+// @Param        sort           query     string  false  "Ordenação: campos separados por vírgula, com - para decrescente (title, year, runtime_minutes, release_date)"  example(-year,title)
+// @Param        cursor         query     string  false  "Cursor devolvido em next_cursor; vazio inicia a paginação por cursor"
+// @Param        include_total  query     bool    false  "Conta o total de filmes que atendem aos filtros"  default(false)
+// @Success      200            {array}   pb.Movie
+// @Header       200            {string}  Link           "Links de paginação (first, prev, next, last)"
+// @Header       200            {integer} X-Total-Count  "Total de filmes, quando include_total=true"
+// @Failure      400        {object}  Problem
+// @Failure      500        {object}  Problem
 // @Router       /movies [get]
 func (h *MovieHandler) ListMovies(c *gin.Context) {
-    titleQ := strings.TrimSpace(c.Query("title"))
-    yearQ  := strings.TrimSpace(c.Query("year"))
-
-    limitStr := c.DefaultQuery("limit", "10")
-    offsetStr := c.DefaultQuery("offset", "0")
-    limit, _ := strconv.ParseInt(limitStr, 10, 32)
-    offset, _ := strconv.ParseInt(offsetStr, 10, 32)
-
-    grpcRequest := &pb.ListMoviesRequest{
-        Limit:  int32(limit),
-        Offset: int32(offset),
-    }
-
-    res, err := h.MovieClient.ListMovies(c.Request.Context(), grpcRequest)
-    if err != nil {
-        log.Printf("Erro ao ListMovies: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar filmes"})
-        return
-    }
-
-    if titleQ == "" && yearQ == "" {
-        if res.Movies == nil {
-            c.JSON(http.StatusOK, []any{})
-            return
-        }
-        c.JSON(http.StatusOK, res.Movies)
-        return
-    }
-
-    out := make([]*pb.Movie, 0) 
-    for _, m := range res.Movies {
-        if m == nil {
-            continue
-        }
-        ok := true
-        if titleQ != "" && !strings.Contains(strings.ToLower(m.Title), strings.ToLower(titleQ)) {
-            ok = false
-        }
-        if yearQ != "" {
-            if y, err := strconv.Atoi(yearQ); err == nil && int32(y) != m.Year {
-                ok = false
-            }
-        }
-        if ok {
-            out = append(out, m)
-        }
-    }
-    c.JSON(http.StatusOK, out)
+	h.listMovies(c, h.MovieClient.ListMovies)
+}
+
+// ListDeletedMovies
+// @Summary      Lista a lixeira (administradores)
+// @Description  Lista os filmes removidos que ainda não foram expurgados, com os mesmos filtros, ordenação e paginação de GET /movies. Cada filme traz deleted_at.
+// @Tags         Movies
+// @Produce      json
+// @Security     AdminToken
+// @Param        limit          query     int     false  "Número de resultados por página" default(10)
+// @Param        offset         query     int     false  "Número de resultados a pular"    default(0)
+// @Param        title          query     string  false  "Trecho do título (ignora maiúsculas e acentos)"
+// @Param        year           query     int     false  "Ano exato"
+// @Param        year_from      query     int     false  "Ano inicial (inclusivo)"
+// @Param        year_to        query     int     false  "Ano final (inclusivo)"
+// @Param        decade         query     int     false  "Década pelo ano inicial, ex.: 1990"
+// @Param        sort           query     string  false  "Ordenação, como em GET /movies"
+// @Param        cursor         query     string  false  "Cursor devolvido em next_cursor; vazio inicia a paginação por cursor"
+// @Param        include_total  query     bool    false  "Conta o total de filmes que atendem aos filtros"  default(false)
+// @Success      200            {array}   pb.Movie
+// @Failure      400            {object}  Problem
+// @Failure      401            {object}  Problem
+// @Failure      403            {object}  Problem
+// @Failure      500            {object}  Problem
+// @Router       /movies/trash [get]
+func (h *MovieHandler) ListDeletedMovies(c *gin.Context) {
+	h.listMovies(c, h.MovieClient.ListDeletedMovies)
+}
+
+// listMovies atende as listagens do catálogo e da lixeira, que têm os mesmos parâmetros e a mesma resposta.
+func (h *MovieHandler) listMovies(c *gin.Context, list func(ctx context.Context, in *pb.ListMoviesRequest, opts ...grpc.CallOption) (*pb.MovieList, error)) {
+	limitStr := c.DefaultQuery("limit", "10")
+	offsetStr := c.DefaultQuery("offset", "0")
+	limit, _ := strconv.ParseInt(limitStr, 10, 32)
+	offset, _ := strconv.ParseInt(offsetStr, 10, 32)
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	includeTotal, _ := strconv.ParseBool(c.DefaultQuery("include_total", "false"))
+
+	cursor, cursorMode := c.GetQuery("cursor")
+
+	grpcRequest := &pb.ListMoviesRequest{
+		Limit:        int32(limit),
+		Offset:       int32(offset),
+		PageToken:    cursor,
+		IncludeTotal: includeTotal,
+	}
+	if !bindListFilters(c, grpcRequest) {
+		return
+	}
+
+	res, err := list(c.Request.Context(), grpcRequest)
+	if err != nil {
+		log.Printf("Erro ao ListMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	movies := res.Movies
+	if movies == nil {
+		movies = []*pb.Movie{}
+	}
+
+	if res.TotalCount != nil {
+		c.Header("X-Total-Count", strconv.FormatInt(res.GetTotalCount(), 10))
+	}
+
+	if cursorMode {
+		links := []string{pageLink(c, "first", map[string]string{"cursor": ""}, "offset")}
+		if res.NextPageToken != "" {
+			links = append(links, pageLink(c, "next", map[string]string{"cursor": res.NextPageToken}, "offset"))
+		}
+		c.Header("Link", strings.Join(links, ", "))
+		c.JSON(http.StatusOK, MovieListResponse{Movies: movies, NextCursor: res.NextPageToken, TotalCount: res.TotalCount})
+		return
+	}
+
+	offsetLink := func(rel string, value int64) string {
+		return pageLink(c, rel, map[string]string{"offset": strconv.FormatInt(value, 10)}, "cursor")
+	}
+	links := []string{offsetLink("first", 0)}
+	if offset > 0 {
+		links = append(links, offsetLink("prev", max(offset-limit, 0)))
+	}
+	if res.NextPageToken != "" {
+		links = append(links, offsetLink("next", offset+int64(len(movies))))
+	}
+	if total := res.GetTotalCount(); res.TotalCount != nil && total > 0 {
+		links = append(links, offsetLink("last", (total-1)/limit*limit))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+	c.JSON(http.StatusOK, movies)
+}
+
+// bindListFilters lê os filtros e a ordenação aceitos pela listagem e pela exportação.
+// Quando um parâmetro de ano não é número, responde 400 e devolve false.
+func bindListFilters(c *gin.Context, req *pb.ListMoviesRequest) bool {
+	req.Title = strings.TrimSpace(c.Query("title"))
+	req.OrderBy = strings.TrimSpace(c.Query("sort"))
+
+	yearParams := map[string]*int32{
+		"year":      &req.Year,
+		"year_from": &req.YearFrom,
+		"year_to":   &req.YearTo,
+		"decade":    &req.Decade,
+	}
+	for name, target := range yearParams {
+		value := strings.TrimSpace(c.Query(name))
+		if value == "" {
+			continue
+		}
+		year, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Parâmetro "+name+" deve ser um número inteiro.")
+			return false
+		}
+		*target = int32(year)
+	}
+	return true
+}
+
+// pageLink monta um valor do header Link (RFC 8288) a partir da URL atual,
+// preservando filtros e limit, trocando os parâmetros em set e removendo os de drop.
+func pageLink(c *gin.Context, rel string, set map[string]string, drop ...string) string {
+	query := c.Request.URL.Query()
+	for _, key := range drop {
+		query.Del(key)
+	}
+	for key, value := range set {
+		query.Set(key, value)
+	}
+	return "<" + c.Request.URL.Path + "?" + query.Encode() + `>; rel="` + rel + `"`
+}
+
+// SearchMovies
+// @Summary      Busca textual de filmes
+// @Description  Busca os termos no título e na sinopse, ordenando por relevância. Cada resultado traz a pontuação e os trechos encontrados, destacados com <em>.
+// @Tags         Movies
+// @Produce      json
+// @Param        q      query     string  true   "Termos da busca"
+// @Param        limit  query     int     false  "Máximo de resultados (até 100)" default(20)
+// @Success      200    {array}   pb.SearchResult
+// @Failure      400    {object}  Problem
+// @Failure      500    {object}  Problem
+// @Router       /movies/search [get]
+func (h *MovieHandler) SearchMovies(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Parâmetro q é obrigatório.")
+		return
+	}
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 32)
+
+	res, err := h.MovieClient.SearchMovies(c.Request.Context(), &pb.SearchMoviesRequest{Query: query, Limit: int32(limit)})
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC SearchMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	if res.Results == nil {
+		c.JSON(http.StatusOK, []any{})
+		return
+	}
+	c.JSON(http.StatusOK, res.Results)
 }
 
 // GetMovieByID
 // @Summary      Busca um filme por ID
-// @Description  Retorna os detalhes de um filme específico baseado no seu ID.
+// @Description  Retorna os detalhes de um filme específico baseado no seu ID. O header ETag traz a versão do filme, para uso em If-Match nas escritas.
 // @Tags         Movies
 // @Produce      json
-// @Param        id   path      string  true  "ID do Filme" Format(mongodb-id)
+// @Param        id             path      string  true   "ID do Filme" Format(mongodb-id)
+// @Param        If-None-Match  header    string  false  "ETag já conhecido; responde 304 se o filme não mudou"
 // @Success      200  {object}  pb.Movie
-// @Failure      404  {object}  map[string]string{error=string}
-// @Failure      500  {object}  map[string]string{error=string}
+// @Header       200  {string}  ETag  "Versão do filme"
+// @Success      304  "Filme não mudou desde o ETag informado"
+// @Failure      400  {object}  Problem
+// @Failure      404  {object}  Problem
+// @Failure      500  {object}  Problem
 // @Router       /movies/{id} [get]
 func (h *MovieHandler) GetMovieByID(c *gin.Context) {
 	movieID := c.Param("id")
@@ -114,13 +325,42 @@ func (h *MovieHandler) GetMovieByID(c *gin.Context) {
 	res, err := h.MovieClient.GetMovie(c.Request.Context(), grpcRequest)
 	if err != nil {
 		log.Printf("Erro ao chamar gRPC GetMovie: %v", err)
-		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Filme não encontrado."})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar o filme."})
+		respondGRPCError(c, err)
 		return
 	}
+
+	c.Header("ETag", movieETag(res.Version))
+	if matchesIfNoneMatch(c, res.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetMovieByExternalID
+// @Summary      Busca um filme pelo id em outro catálogo
+// @Description  Retorna o filme que tem o id informado no provedor (imdb, tmdb ou movielens), para que outros sistemas encontrem o filme pelos ids que já têm. Um id do IMDb só com dígitos é completado com o prefixo "tt". O header ETag traz a versão do filme.
+// @Tags         Movies
+// @Produce      json
+// @Param        provider  path      string  true  "Provedor"  Enums(imdb, tmdb, movielens)
+// @Param        id        path      string  true  "Id do filme no provedor"  example(tt0000010)
+// @Success      200  {object}  pb.Movie
+// @Header       200  {string}  ETag  "Versão do filme"
+// @Failure      400  {object}  Problem
+// @Failure      404  {object}  Problem
+// @Failure      500  {object}  Problem
+// @Router       /movies/by-external/{provider}/{id} [get]
+func (h *MovieHandler) GetMovieByExternalID(c *gin.Context) {
+	grpcRequest := &pb.GetMovieByExternalIDRequest{Provider: c.Param("provider"), Id: c.Param("id")}
+
+	res, err := h.MovieClient.GetMovieByExternalID(c.Request.Context(), grpcRequest)
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC GetMovieByExternalID: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	c.Header("ETag", movieETag(res.Version))
 	c.JSON(http.StatusOK, res)
 }
 
@@ -131,14 +371,48 @@ func (h *MovieHandler) GetMovieByID(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        movie  body      CreateMovieRequest  true  "Dados para criar o filme"
+// @Param        X-Actor   header    string  false  "Quem faz a alteração, registrado no histórico do filme"
 // @Success      202    {object}  map[string]string{message=string}
-// @Failure      400    {object}  map[string]string{error=string}
-// @Failure      500    {object}  map[string]string{error=string}
+// @Failure      400    {object}  Problem
+// @Failure      409    {object}  Problem
+// @Failure      422    {object}  Problem
+// @Failure      500    {object}  Problem
 // @Router       /movies [post]
 func (h *MovieHandler) CreateMovie(c *gin.Context) {
 	var req CreateMovieRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+
+	// A escrita é assíncrona: as regras do filme são conferidas antes, para que o erro chegue ao cliente.
+	grpcRequest := createRequestPB(req)
+	grpcRequest.ValidateOnly = true
+	validated, err := h.MovieClient.CreateMovie(c.Request.Context(), grpcRequest)
+	if err != nil {
+		log.Printf("Erro ao validar o filme via gRPC: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	exists, err := h.movieExists(c, validated.Title, validated.Year)
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC ListMovies: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	if exists {
+		respondProblem(c, http.StatusConflict, codeMovieAlreadyExists, "Já existe um filme com esse título e ano.")
+		return
+	}
+	provider, taken, err := h.externalIDTaken(c, validated.ExternalIds)
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC GetMovieByExternalID: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	if taken {
+		respondProblem(c, http.StatusConflict, codeMovieAlreadyExists, "Já existe um filme com esse id no "+provider+".")
 		return
 	}
 
@@ -146,39 +420,365 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 		Action:    "create",
 		Data:      req,                // payload simples; o consumer mapeia para o modelo/domínio
 		Timestamp: time.Now().UTC(),
+		Actor:     actorOf(c),
 	}
 	body, _ := json.Marshal(evt)
 
 	if err := h.Publisher.Publish(c.Request.Context(), h.Publisher.RoutingKeyCreated(), body); err != nil {
 		log.Printf("Erro ao publicar movie.created: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao enfileirar criação"})
+		respondProblem(c, http.StatusInternalServerError, codePublishFailed, "Falha ao enfileirar a criação.")
 		return
 	}
 	c.JSON(http.StatusAccepted, gin.H{"message": "Solicitação de criação recebida e sendo processada."})
 }
 
+// movieExists procura um filme com o mesmo título e ano antes de enfileirar a criação. É uma verificação
+// antecipada: o movies-service continua sendo quem garante a unicidade (também ignorando acentos) e descarta
+// a mensagem duplicada que passar por aqui.
+func (h *MovieHandler) movieExists(c *gin.Context, title string, year int32) (bool, error) {
+	title = strings.Join(strings.Fields(title), " ")
+	res, err := h.MovieClient.ListMovies(c.Request.Context(), &pb.ListMoviesRequest{Title: title, Year: year, Limit: 100})
+	if err != nil {
+		return false, err
+	}
+	for _, movie := range res.Movies {
+		if strings.EqualFold(strings.Join(strings.Fields(movie.Title), " "), title) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// externalIDTaken procura, antes de enfileirar a criação, um filme que já tenha um dos ids externos (já normalizados
+// pelo movies-service). Como movieExists, é só uma verificação antecipada; devolve o provedor do id repetido.
+func (h *MovieHandler) externalIDTaken(c *gin.Context, externalIDs map[string]string) (string, bool, error) {
+	for provider, id := range externalIDs {
+		_, err := h.MovieClient.GetMovieByExternalID(c.Request.Context(), &pb.GetMovieByExternalIDRequest{Provider: provider, Id: id})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return provider, true, nil
+	}
+	return "", false, nil
+}
+
+// UpdateMovie (ASSÍNCRONO)
+// @Summary      Solicita a atualização parcial de um filme (assíncrono)
+// @Description  Altera apenas os campos enviados no corpo. A existência do filme é verificada antes de o evento ser publicado.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string              true   "ID do Filme" Format(mongodb-id)
+// @Param        If-Match  header    string              false  "ETag obtido no GET; a atualização só é aplicada se o filme continuar nessa versão"
+// @Param        movie     body      UpdateMovieRequest  true   "Campos a alterar"
+// @Param        X-Actor   header    string  false  "Quem faz a alteração, registrado no histórico do filme"
+// @Success      202    {object}  map[string]string{message=string}
+// @Failure      400    {object}  Problem
+// @Failure      404    {object}  Problem
+// @Failure      412    {object}  Problem
+// @Failure      422    {object}  Problem
+// @Failure      500    {object}  Problem
+// @Router       /movies/{id} [patch]
+func (h *MovieHandler) UpdateMovie(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(body, &present); err != nil {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "O corpo da requisição deve ser um objeto JSON.")
+		return
+	}
+	if len(present) == 0 {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Informe ao menos um campo para atualizar.")
+		return
+	}
+
+	mask := make([]string, 0, len(present))
+	for _, field := range updatableFields {
+		if _, ok := present[field]; ok {
+			mask = append(mask, field)
+		}
+	}
+	if len(mask) != len(present) {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Campos aceitos: "+strings.Join(updatableFields, ", ")+".")
+		return
+	}
+
+	var req UpdateMovieRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	h.publishUpdate(c, c.Param("id"), req, mask)
+}
+
+// ReplaceMovie (ASSÍNCRONO)
+// @Summary      Solicita a substituição completa de um filme (assíncrono)
+// @Description  Substitui todos os campos editáveis do filme. Campos omitidos ficam vazios.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string              true   "ID do Filme" Format(mongodb-id)
+// @Param        If-Match  header    string              false  "ETag obtido no GET; a substituição só é aplicada se o filme continuar nessa versão"
+// @Param        movie     body      CreateMovieRequest  true   "Novos dados do filme"
+// @Param        X-Actor   header    string  false  "Quem faz a alteração, registrado no histórico do filme"
+// @Success      202    {object}  map[string]string{message=string}
+// @Failure      400    {object}  Problem
+// @Failure      404    {object}  Problem
+// @Failure      412    {object}  Problem
+// @Failure      422    {object}  Problem
+// @Failure      500    {object}  Problem
+// @Router       /movies/{id} [put]
+func (h *MovieHandler) ReplaceMovie(c *gin.Context) {
+	var req CreateMovieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	h.publishUpdate(c, c.Param("id"), UpdateMovieRequest(req), updatableFields)
+}
+
+// publishUpdate valida a alteração via gRPC (o que também confirma que o filme existe) e publica o evento "movie.updated".
+func (h *MovieHandler) publishUpdate(c *gin.Context, movieID string, req UpdateMovieRequest, mask []string) {
+	current, err := h.MovieClient.UpdateMovie(c.Request.Context(), &pb.UpdateMovieRequest{
+		Movie:        req.toPB(movieID),
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: mask},
+		ValidateOnly: true,
+	})
+	if err != nil {
+		log.Printf("Erro ao validar a atualização via gRPC: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	if !matchesIfMatch(c, current.Version) {
+		respondProblem(c, http.StatusPreconditionFailed, codeVersionMismatch, errMovieChanged)
+		return
+	}
+
+	data := movieUpdatedEvent{ID: movieID, UpdateMovieRequest: req, UpdateMask: mask}
+	if c.GetHeader("If-Match") != "" {
+		data.ExpectedVersion = current.Version
+	}
+	evt := MovieEvent{
+		Action:    "update",
+		Data:      data,
+		Timestamp: time.Now().UTC(),
+		Actor:     actorOf(c),
+	}
+	body, _ := json.Marshal(evt)
+
+	if err := h.Publisher.Publish(c.Request.Context(), h.Publisher.RoutingKeyUpdated(), body); err != nil {
+		log.Printf("Erro ao publicar movie.updated: %v", err)
+		respondProblem(c, http.StatusInternalServerError, codePublishFailed, "Falha ao enfileirar a atualização.")
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Solicitação de atualização recebida e sendo processada."})
+}
+
+// RestoreMovie
+// @Summary      Restaura um filme da lixeira
+// @Description  Desfaz a remoção de um filme que ainda não foi expurgado. Falha com 409 se outro filme com o mesmo título e ano foi criado depois da remoção.
+// @Tags         Movies
+// @Produce      json
+// @Param        id   path      string  true  "ID do Filme" Format(mongodb-id)
+// @Param        X-Actor   header    string  false  "Quem faz a alteração, registrado no histórico do filme"
+// @Success      200  {object}  pb.Movie
+// @Failure      400  {object}  Problem
+// @Failure      404  {object}  Problem
+// @Failure      409  {object}  Problem
+// @Failure      500  {object}  Problem
+// @Router       /movies/{id}/restore [post]
+func (h *MovieHandler) RestoreMovie(c *gin.Context) {
+	res, err := h.MovieClient.RestoreMovie(withActor(c), &pb.RestoreMovieRequest{Id: c.Param("id")})
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC RestoreMovie: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	c.Header("ETag", movieETag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// FieldChangeResponse é a alteração de um campo; old e new são null quando o campo estava ou ficou vazio.
+type FieldChangeResponse struct {
+	Field string          `json:"field" example:"runtime_minutes"`
+	Old   json.RawMessage `json:"old" swaggertype:"object"`
+	New   json.RawMessage `json:"new" swaggertype:"object"`
+}
+
+// MovieRevisionResponse é uma escrita no histórico do filme. Version é a versão que a escrita produziu.
+type MovieRevisionResponse struct {
+	Version    int64                 `json:"version" example:"3"`
+	Action     string                `json:"action" example:"update"`
+	Actor      string                `json:"actor" example:"maria"`
+	Timestamp  string                `json:"timestamp" example:"2024-05-01T12:00:00Z"`
+	Changes    []FieldChangeResponse `json:"changes"`
+	Snapshot   *pb.Movie             `json:"snapshot"`
+	RevertedTo int64                 `json:"reverted_to,omitempty"`
+}
+
+// MovieHistoryResponse é o corpo do histórico, das revisões mais recentes para as mais antigas.
+type MovieHistoryResponse struct {
+	Revisions []MovieRevisionResponse `json:"revisions"`
+}
+
+// RevertMovieRequest indica a versão, entre as do histórico, cujos valores o filme deve voltar a ter.
+type RevertMovieRequest struct {
+	Version int64 `json:"version" binding:"required,gt=0" example:"2"`
+}
+
+// GetMovieHistory
+// @Summary      Histórico de alterações de um filme
+// @Description  Lista quem alterou o filme, quando e os valores antigos e novos de cada campo, das alterações mais recentes para as mais antigas. Para a próxima página, envie em before_version a versão da última revisão recebida.
+// @Tags         Movies
+// @Produce      json
+// @Param        id              path      string  true   "ID do Filme" Format(mongodb-id)
+// @Param        limit           query     int     false  "Máximo de revisões (padrão 20, máximo 100)"
+// @Param        before_version  query     int     false  "Lista só as revisões anteriores a essa versão"
+// @Success      200  {object}  MovieHistoryResponse
+// @Failure      400  {object}  Problem
+// @Failure      500  {object}  Problem
+// @Failure      501  {object}  Problem
+// @Router       /movies/{id}/history [get]
+func (h *MovieHandler) GetMovieHistory(c *gin.Context) {
+	req := &pb.ListMovieRevisionsRequest{MovieId: c.Param("id")}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		req.Limit = int32(limit)
+	}
+	if raw := c.Query("before_version"); raw != "" {
+		beforeVersion, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || beforeVersion <= 0 {
+			respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "before_version deve ser um número maior que zero.")
+			return
+		}
+		req.BeforeVersion = beforeVersion
+	}
+
+	res, err := h.MovieClient.ListMovieRevisions(c.Request.Context(), req)
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC ListMovieRevisions: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+
+	revisions := make([]MovieRevisionResponse, len(res.Revisions))
+	for i, revision := range res.Revisions {
+		changes := make([]FieldChangeResponse, len(revision.Changes))
+		for j, change := range revision.Changes {
+			changes[j] = FieldChangeResponse{Field: change.Field, Old: rawFieldValue(change.OldValue), New: rawFieldValue(change.NewValue)}
+		}
+		revisions[i] = MovieRevisionResponse{
+			Version:    revision.Version,
+			Action:     revision.Action,
+			Actor:      revision.Actor,
+			Timestamp:  revision.Timestamp,
+			Changes:    changes,
+			Snapshot:   revision.Snapshot,
+			RevertedTo: revision.RevertedTo,
+		}
+	}
+	c.JSON(http.StatusOK, MovieHistoryResponse{Revisions: revisions})
+}
+
+// rawFieldValue devolve o valor já codificado em JSON pelo movies-service; vazio vira null.
+func rawFieldValue(value string) json.RawMessage {
+	if value == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(value)
+}
+
+// RevertMovie
+// @Summary      Reverte um filme para uma versão do histórico
+// @Description  Volta os campos editáveis do filme aos valores que tinham na versão informada. A reversão gera uma nova versão e aparece no histórico. Falha com 409 se o título e ano restaurados já pertencem a outro filme ou se o filme foi alterado durante a reversão.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string              true   "ID do Filme" Format(mongodb-id)
+// @Param        X-Actor  header    string              false  "Quem faz a alteração, registrado no histórico do filme"
+// @Param        body     body      RevertMovieRequest  true   "Versão a restaurar"
+// @Success      200  {object}  pb.Movie
+// @Header       200  {string}  ETag  "Nova versão do filme"
+// @Failure      400  {object}  Problem
+// @Failure      404  {object}  Problem
+// @Failure      409  {object}  Problem
+// @Failure      500  {object}  Problem
+// @Failure      501  {object}  Problem
+// @Router       /movies/{id}/revert [post]
+func (h *MovieHandler) RevertMovie(c *gin.Context) {
+	var req RevertMovieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	res, err := h.MovieClient.RevertMovie(withActor(c), &pb.RevertMovieRequest{Id: c.Param("id"), Version: req.Version})
+	if err != nil {
+		log.Printf("Erro ao chamar gRPC RevertMovie: %v", err)
+		respondGRPCError(c, err)
+		return
+	}
+	c.Header("ETag", movieETag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
 // DeleteMovie (ASSÍNCRONO)
 // @Summary      Solicita a deleção de um filme (assíncrono)
-// @Description  Envia um evento para deletar um filme. A operação é processada em background.
+// @Description  Envia um evento para deletar um filme. A operação é processada em background. O filme vai para a lixeira e pode ser restaurado até ser expurgado.
 // @Tags         Movies
 // @Produce      json
-// @Param        id   path      string  true  "ID do Filme" Format(mongodb-id)
+// @Param        id        path      string  true   "ID do Filme" Format(mongodb-id)
+// @Param        If-Match  header    string  false  "ETag obtido no GET; a deleção só é aplicada se o filme continuar nessa versão"
+// @Param        X-Actor   header    string  false  "Quem faz a alteração, registrado no histórico do filme"
 // @Success      202  {object}  map[string]string{message=string}
-// @Failure      500  {object}  map[string]string{error=string}
+// @Failure      400  {object}  Problem
+// @Failure      404  {object}  Problem
+// @Failure      412  {object}  Problem
+// @Failure      500  {object}  Problem
 // @Router       /movies/{id} [delete]
 func (h *MovieHandler) DeleteMovie(c *gin.Context) {
 	movieID := c.Param("id")
+	data := gin.H{"id": movieID}
+
+	// Sem If-Match a deleção segue direto para a fila; com ele, a versão atual é conferida antes, para responder 412
+	// na hora, e segue no evento: o movies-service só remove o filme se ele ainda estiver nessa versão.
+	if c.GetHeader("If-Match") != "" {
+		current, err := h.MovieClient.GetMovie(freshRead(c), &pb.GetMovieRequest{Id: movieID})
+		if err != nil {
+			log.Printf("Erro ao chamar gRPC GetMovie: %v", err)
+			respondGRPCError(c, err)
+			return
+		}
+		if !matchesIfMatch(c, current.Version) {
+			respondProblem(c, http.StatusPreconditionFailed, codeVersionMismatch, errMovieChanged)
+			return
+		}
+		data["expected_version"] = current.Version
+	}
 
 	evt := MovieEvent{
 		Action:    "delete",
-		Data:      gin.H{"id": movieID},
+		Data:      data,
 		Timestamp: time.Now().UTC(),
+		Actor:     actorOf(c),
 	}
 	body, _ := json.Marshal(evt)
 
 	if err := h.Publisher.Publish(c.Request.Context(), h.Publisher.RoutingKeyDeleted(), body); err != nil {
 		log.Printf("Erro ao publicar movie.deleted: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao enfileirar deleção"})
+		respondProblem(c, http.StatusInternalServerError, codePublishFailed, "Falha ao enfileirar a deleção.")
 		return
 	}
 	c.JSON(http.StatusAccepted, gin.H{"message": "Solicitação de deleção recebida e sendo processada."})
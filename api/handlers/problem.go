@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// problemContentType é o media type das respostas de erro (RFC 9457).
+const problemContentType = "application/problem+json"
+
+// problemTypePrefix forma o campo type de cada problema a partir do código estável do erro.
+const problemTypePrefix = "urn:movies:error:"
+
+// Códigos dos erros detectados no próprio gateway. Os demais vêm do catálogo do movies-service (ErrorInfo.reason).
+const (
+	codeInvalidRequest     = "INVALID_REQUEST"
+	codeInvalidRow         = "INVALID_ROW"
+	codeVersionMismatch    = "VERSION_MISMATCH"
+	codeMovieAlreadyExists = "MOVIE_ALREADY_EXISTS"
+	codeInvalidMovie       = "INVALID_MOVIE"
+	codePublishFailed      = "PUBLISH_FAILED"
+	codeAdminDisabled      = "ADMIN_DISABLED"
+	codeMissingAdminToken  = "MISSING_ADMIN_TOKEN"
+	codeInvalidAdminToken  = "INVALID_ADMIN_TOKEN"
+	codeRouteNotFound      = "ROUTE_NOT_FOUND"
+	codeInternal           = "INTERNAL"
+)
+
+// Problem é o corpo de toda resposta de erro da API (RFC 9457). Code é estável e serve a máquinas;
+// Detail é o texto em português para pessoas e pode mudar. Errors só aparece quando o filme viola regras de campos.
+type Problem struct {
+	Type     string       `json:"type" example:"urn:movies:error:MOVIE_NOT_FOUND"`
+	Title    string       `json:"title" example:"Não encontrado"`
+	Status   int          `json:"status" example:"404"`
+	Detail   string       `json:"detail,omitempty" example:"Filme não encontrado"`
+	Instance string       `json:"instance,omitempty" example:"/movies/665f1c2a9b1e8a3d4c5b6a79"`
+	Code     string       `json:"code" example:"MOVIE_NOT_FOUND"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError é a violação de uma regra em um campo do filme.
+type FieldError struct {
+	Field   string `json:"field" example:"year"`
+	Message string `json:"message" example:"O ano deve estar entre 1888 e 2026"`
+}
+
+// statusClientClosedRequest é o status (não padronizado, mas usual) para a requisição cancelada pelo cliente.
+const statusClientClosedRequest = 499
+
+// problemTitles são os títulos dos problemas por status HTTP.
+var problemTitles = map[int]string{
+	http.StatusBadRequest:          "Requisição inválida",
+	http.StatusUnauthorized:        "Não autenticado",
+	http.StatusForbidden:           "Acesso negado",
+	http.StatusNotFound:            "Não encontrado",
+	http.StatusConflict:            "Conflito",
+	http.StatusPreconditionFailed:  "Pré-condição não atendida",
+	http.StatusUnprocessableEntity: "Filme inválido",
+	http.StatusTooManyRequests:     "Requisições em excesso",
+	http.StatusInternalServerError: "Erro interno",
+	http.StatusNotImplemented:      "Não disponível",
+	http.StatusServiceUnavailable:  "Serviço indisponível",
+	http.StatusGatewayTimeout:      "Tempo esgotado",
+	statusClientClosedRequest:      "Requisição cancelada",
+}
+
+// grpcProblem diz como responder a um código gRPC. Code e detail só são usados quando o erro não traz
+// ErrorInfo (falhas de transporte, como o movies-service fora do ar), e então não expõem a mensagem original.
+type grpcProblem struct {
+	status int
+	code   string
+	detail string
+}
+
+var grpcProblems = map[codes.Code]grpcProblem{
+	codes.InvalidArgument:    {http.StatusBadRequest, codeInvalidRequest, "Requisição inválida."},
+	codes.NotFound:           {http.StatusNotFound, "NOT_FOUND", "Recurso não encontrado."},
+	codes.AlreadyExists:      {http.StatusConflict, "ALREADY_EXISTS", "O recurso já existe."},
+	codes.Aborted:            {http.StatusConflict, "CONFLICT", "A operação conflitou com outra alteração."},
+	codes.FailedPrecondition: {http.StatusBadRequest, "FAILED_PRECONDITION", "A operação não pode ser feita no estado atual."},
+	codes.OutOfRange:         {http.StatusBadRequest, codeInvalidRequest, "Requisição inválida."},
+	codes.PermissionDenied:   {http.StatusForbidden, "PERMISSION_DENIED", "Acesso negado."},
+	codes.Unauthenticated:    {http.StatusUnauthorized, "UNAUTHENTICATED", "Autenticação necessária."},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, "RESOURCE_EXHAUSTED", "Limite de requisições atingido."},
+	codes.Unimplemented:      {http.StatusNotImplemented, "NOT_IMPLEMENTED", "Operação não disponível."},
+	codes.Unavailable:        {http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "O serviço de filmes está indisponível."},
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, "TIMEOUT", "O serviço de filmes não respondeu a tempo."},
+	codes.Canceled:           {statusClientClosedRequest, "CANCELED", "A requisição foi cancelada."},
+}
+
+// reasonStatus sobrepõe o status HTTP de alguns códigos do catálogo, mais específicos que o código gRPC.
+var reasonStatus = map[string]int{
+	codeVersionMismatch: http.StatusPreconditionFailed,
+	codeInvalidMovie:    http.StatusUnprocessableEntity,
+}
+
+// respondProblem encerra a requisição com um problem+json.
+func respondProblem(c *gin.Context, status int, code, detail string, fields ...FieldError) {
+	title, ok := problemTitles[status]
+	if !ok {
+		title = http.StatusText(status)
+	}
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(status, Problem{
+		Type:     problemTypePrefix + code,
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Errors:   fields,
+	})
+}
+
+// respondGRPCError traduz um erro do movies-service para o status HTTP e o problem+json correspondentes.
+// O código estável vem do detalhe ErrorInfo; as violações de campos, do detalhe BadRequest.
+func respondGRPCError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	problem, ok := grpcProblems[st.Code()]
+	if !ok {
+		problem = grpcProblem{http.StatusInternalServerError, codeInternal, "Um erro interno ocorreu."}
+	}
+
+	var fields []FieldError
+	var reason string
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			reason = detail.GetReason()
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				fields = append(fields, FieldError{Field: violation.GetField(), Message: violation.GetDescription()})
+			}
+		}
+	}
+
+	if reason == "" {
+		respondProblem(c, problem.status, problem.code, problem.detail)
+		return
+	}
+	httpStatus := problem.status
+	if override, ok := reasonStatus[reason]; ok {
+		httpStatus = override
+	}
+	respondProblem(c, httpStatus, reason, st.Message(), fields...)
+}
+
+// respondBindError responde 400 quando o corpo não pôde ser lido: JSON malformado, tipo errado em um campo
+// ou formato rejeitado pelos tags binding (ex.: release_date fora de AAAA-MM-DD).
+func respondBindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, len(validationErrs))
+		for i, fieldErr := range validationErrs {
+			fields[i] = FieldError{Field: snakeCase(fieldErr.Field()), Message: bindMessage(fieldErr)}
+		}
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Há campos inválidos na requisição.", fields...)
+		return
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "Há campos inválidos na requisição.",
+			FieldError{Field: typeErr.Field, Message: "Tipo de valor inválido para o campo"})
+		return
+	}
+	respondProblem(c, http.StatusBadRequest, codeInvalidRequest, "O corpo da requisição não é um JSON válido.")
+}
+
+// bindMessage descreve em português a regra binding violada.
+func bindMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "Campo obrigatório"
+	case "datetime":
+		return "Deve estar no formato AAAA-MM-DD"
+	case "gt":
+		return "Deve ser maior que " + fieldErr.Param()
+	case "min":
+		return "Deve ter ao menos " + fieldErr.Param() + " item(ns)"
+	default:
+		return "Valor inválido"
+	}
+}
+
+// snakeCase converte o nome do campo Go para o nome usado no JSON (ReleaseDate -> release_date, IDs -> ids).
+func snakeCase(name string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			if prevLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+			prevLower = false
+		} else {
+			prevLower = true
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// NoRoute responde às rotas inexistentes no mesmo formato dos demais erros.
+func NoRoute(c *gin.Context) {
+	respondProblem(c, http.StatusNotFound, codeRouteNotFound, "Rota não encontrada.")
+}
@@ -24,6 +24,11 @@ import (
 // @schemes         http
 // @contact.name    James Cook
 
+// @securityDefinitions.apikey  AdminToken
+// @in                          header
+// @name                        X-Admin-Token
+// @description                 Token das rotas administrativas (ADMIN_TOKEN).
+
 func main() {
 	moviesServiceAddress := getEnv("MOVIES_SERVICE_ADDRESS", "movies_service:50051")
 
@@ -36,7 +41,7 @@ func main() {
 	movieClient := pb.NewMovieServiceClient(conn)
 	log.Println("Conexao com o movies-service estabelecida com sucesso!")
 
-	// Publisher RabbitMQ para escritas assíncronas (POST/DELETE)
+	// Publisher RabbitMQ para escritas assíncronas (POST/PATCH/PUT/DELETE)
 	pub, err := messaging.NewPublisher()
 	if err != nil {
 		log.Fatalf("Nao foi possivel conectar ao RabbitMQ: %v", err)
@@ -44,17 +49,33 @@ func main() {
 	defer pub.Close()
 
 	h := handlers.NewMovieHandler(movieClient, pub)
+	requireAdmin := handlers.RequireAdmin(getEnv("ADMIN_TOKEN", ""))
 	router := gin.Default()
+	router.NoRoute(handlers.NoRoute)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Rotas
+	// Métodos customizados da coleção (/movies:batchGet, /movies:batchDelete)
+	router.POST("/movies:action", h.MovieCollectionAction)
+
 	movieRoutes := router.Group("/movies")
 	{
 		movieRoutes.GET("", h.ListMovies)         
+		movieRoutes.GET("/search", h.SearchMovies)
+		movieRoutes.GET("/export", h.ExportMovies)
+		movieRoutes.GET("/trash", requireAdmin, h.ListDeletedMovies)
+		movieRoutes.GET("/by-external/:provider/:id", h.GetMovieByExternalID)
 		movieRoutes.GET("/:id", h.GetMovieByID)
 		movieRoutes.POST("", h.CreateMovie)       
+		movieRoutes.POST("/bulk", h.BulkCreateMovies)
+		movieRoutes.POST("/import", h.ImportMovies)
+		movieRoutes.PATCH("/:id", h.UpdateMovie)
+		movieRoutes.PUT("/:id", h.ReplaceMovie)
 		movieRoutes.DELETE("/:id", h.DeleteMovie)  
+		movieRoutes.POST("/:id/restore", h.RestoreMovie)
+		movieRoutes.GET("/:id/history", h.GetMovieHistory)
+		movieRoutes.POST("/:id/revert", h.RevertMovie)
 	}
 
 	// Inicialização do Servidor HTTP
@@ -10,11 +10,13 @@ import (
 )
 
 type Publisher struct {
-	conn     *amqp.Connection
-	ch       *amqp.Channel
-	exchange string
-	created  string
-	deleted  string
+	conn        *amqp.Connection
+	ch          *amqp.Channel
+	exchange    string
+	created     string
+	updated     string
+	deleted     string
+	bulkCreated string
 }
 
 func NewPublisher() (*Publisher, error) {
@@ -22,7 +24,9 @@ func NewPublisher() (*Publisher, error) {
     ex := env("RABBITMQ_EXCHANGE", "movies")
     exType := env("RABBITMQ_EXCHANGE_TYPE", "topic")
     rkCreated := env("RABBITMQ_ROUTING_KEY_CREATED", "movie.created")
+    rkUpdated := env("RABBITMQ_ROUTING_KEY_UPDATED", "movie.updated")
     rkDeleted := env("RABBITMQ_ROUTING_KEY_DELETED", "movie.deleted")
+    rkBulkCreated := env("RABBITMQ_ROUTING_KEY_BULK_CREATED", "movie.bulk_created")
 
     var conn *amqp.Connection
     var err error
@@ -52,7 +56,8 @@ func NewPublisher() (*Publisher, error) {
 
     return &Publisher{
         conn: conn, ch: ch, exchange: ex,
-        created: rkCreated, deleted: rkDeleted,
+        created: rkCreated, updated: rkUpdated, deleted: rkDeleted,
+        bulkCreated: rkBulkCreated,
     }, nil
 }
 
@@ -70,7 +75,9 @@ func (p *Publisher) Publish(ctx context.Context, routingKey string, body []byte)
 }
 
 func (p *Publisher) RoutingKeyCreated() string { return p.created }
+func (p *Publisher) RoutingKeyUpdated() string { return p.updated }
 func (p *Publisher) RoutingKeyDeleted() string { return p.deleted }
+func (p *Publisher) RoutingKeyBulkCreated() string { return p.bulkCreated }
 
 func (p *Publisher) Close() {
 	if p.ch != nil { _ = p.ch.Close() }
@@ -2,27 +2,22 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
 	grpcAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/grpc"
-	mongoAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/mongodb"
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/services"
-	domain "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 
-	rabbitConsumer "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/rabbitmq"
+	rabbitAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/rabbitmq"
 )
 
 func getEnv(key, fallback string) string {
@@ -32,34 +27,81 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getDurationEnv lê uma duração no formato do time.ParseDuration (ex.: "720h").
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("%s inválido %q: %v", key, value, err)
+	}
+	return duration
+}
+
+// getIntEnv lê um número inteiro, como o tamanho do cache.
+func getIntEnv(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("%s inválido %q: %v", key, value, err)
+	}
+	return n
+}
+
 func main() {
-	mongodbURI := getEnv("MONGODB_URI", "mongodb://mongodb:27017")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(os.Args[2:]); err != nil {
+				log.Fatalf("migrate: %v", err)
+			}
+			return
+		case "seed":
+			if err := runSeed(os.Args[2:]); err != nil {
+				log.Fatalf("seed: %v", err)
+			}
+			return
+		}
+	}
+
+	driver := getEnv("REPOSITORY_DRIVER", driverMongo)
 	port := getEnv("MOVIES_SERVICE_PORT", ":50051")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongodbURI))
+	store, err := openStorage(ctx, driver)
 	if err != nil {
-		log.Fatalf("failed to connect to mongo: %v", err)
+		log.Fatalf("failed to open %s storage: %v", driver, err)
 	}
-	if err := client.Ping(ctx, nil); err != nil {
-		log.Fatalf("failed to ping mongo: %v", err)
-	}
-
-	db := client.Database("moviedb")
-	log.Println("Conectado ao MongoDB")
-	seedDatabase(ctx, db)
-
-	movieRepository, err := mongoAdapter.NewMongoRepository(db)
-	if err != nil {
-		log.Fatalf("failed to create mongo repository: %v", err)
+	publisher := rabbitAdapter.NewPublisher()
+	opts := append([]services.Option{
+		services.WithSearcher(store.searcher),
+		services.WithRevisionStore(store.revisions),
+	}, store.eventOptions(publisher)...)
+	movieService := services.NewMovieService(store.repository, opts...)
+	// MOVIE_CACHE_SIZE=0 desliga o cache de GetMovie.
+	var movieCache *services.CachedMovieService
+	if size := getIntEnv("MOVIE_CACHE_SIZE", 1000); size > 0 {
+		movieCache = services.NewCachedMovieService(movieService, size, getDurationEnv("MOVIE_CACHE_TTL", time.Minute))
+		movieService = movieCache
 	}
-	movieService := services.NewMovieService(movieRepository)
-
 	workerCtx, workerCancel := context.WithCancel(context.Background())
 	defer workerCancel()
-	consumer := rabbitConsumer.NewConsumer(movieService)
+	go runTrashPurge(workerCtx, movieService,
+		getDurationEnv("TRASH_RETENTION", 30*24*time.Hour),
+		getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour))
+	if store.outbox != nil {
+		go runOutboxRelay(workerCtx, services.NewOutboxRelay(store.outbox, publisher),
+			getDurationEnv("OUTBOX_POLL_INTERVAL", time.Second))
+	}
+
+	consumer := rabbitAdapter.NewConsumer(movieService)
 	go func() {
 		if err := consumer.Start(workerCtx); err != nil {
 			log.Fatalf("rabbit consumer error: %v", err)
@@ -71,7 +113,10 @@ func main() {
 		log.Fatalf("failed to listen on port %s: %v", port, err)
 	}
 	grpcServerAdapter := grpcAdapter.NewGRPCServerAdapter(movieService)
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcAdapter.ActorInterceptor),
+		grpc.ChainStreamInterceptor(grpcAdapter.ActorStreamInterceptor),
+	)
 	pb.RegisterMovieServiceServer(grpcServer, grpcServerAdapter)
 	reflection.Register(grpcServer)
 	log.Printf("gRPC server listening on %s", port)
@@ -81,6 +126,11 @@ func main() {
 			log.Fatalf("failed to serve gRPC server: %v", err)
 		}
 	}()
+	// O seed roda depois que o servidor já atende, para não atrasar a subida. Só o driver memory, que sobe
+	// vazio, carrega o seed por padrão.
+	if getEnv("SEED_ON_STARTUP", strconv.FormatBool(driver == driverMemory)) == "true" {
+		go seedOnStartup(workerCtx, store.locker, movieService)
+	}
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
@@ -89,55 +139,11 @@ func main() {
 
 	workerCancel()     
 	grpcServer.GracefulStop()
-	_ = client.Disconnect(context.Background())
-	log.Println("Bye!")
-}
-
-type MovieSeed struct {
-	ID    interface{} `json:"_id"`
-	Title string      `json:"title"`
-	Year  int         `json:"year,string"`
-}
-
-func seedDatabase(ctx context.Context, db *mongo.Database) {
-	log.Println("Iniciando a verificação do banco de dados para popular...")
-	collection := db.Collection("movies")
-	count, err := collection.CountDocuments(ctx, bson.M{})
-	if err != nil {
-		log.Fatalf("Erro ao verificar documentos na coleção: %v", err)
-	}
-	if count > 0 {
-		log.Println("O banco de dados já contém dados. População não necessária.")
-		return
-	}
-
-	log.Println("Banco de dados vazio. Tentando ler o arquivo /app/data/movies.json...")
-	file, err := os.ReadFile("/app/data/movies.json")
-	if err != nil {
-		log.Fatalf("Erro ao ler o arquivo de seed /app/data/movies.json: %v", err)
-	}
-
-	var movieSeeds []MovieSeed
-	log.Println("Tentando decodificar o JSON...")
-	if err := json.Unmarshal(file, &movieSeeds); err != nil {
-		log.Fatalf("Erro ao decodificar o JSON: %v", err)
-	}
-	log.Printf("JSON decodificado com sucesso. Encontrados %d filmes no arquivo.", len(movieSeeds))
-
-	var docs []interface{}
-	for _, seed := range movieSeeds {
-		doc := domain.Movie{
-			Title: seed.Title,
-			Year:  seed.Year,
-		}
-		docs = append(docs, doc)
+	publisher.Close()
+	_ = store.close(context.Background())
+	if movieCache != nil {
+		stats := movieCache.Stats()
+		log.Printf("Cache de filmes: %d acertos, %d falhas, %d filmes guardados", stats.Hits, stats.Misses, stats.Entries)
 	}
-
-	if len(docs) > 0 {
-		log.Printf("Tentando inserir %d documentos no MongoDB...", len(docs))
-		if _, err = collection.InsertMany(ctx, docs); err != nil {
-			log.Fatalf("Erro ao inserir dados no banco: %v", err)
-		}
-	}
-	log.Printf("Banco de dados populado com sucesso com %d filmes.", len(movieSeeds))
+	log.Println("Bye!")
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	mongoAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/mongodb"
+)
+
+const migrateUsage = "uso: movies-service migrate up|down|status"
+
+// runMigrate executa o subcomando `migrate` sobre o MongoDB do MONGODB_URI:
+//
+//	up     aplica as migrações pendentes
+//	down   reverte a última migração aplicada
+//	status lista as migrações e quando cada uma foi aplicada
+func runMigrate(args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client, db, err := connectMongo(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+	migrator := mongoAdapter.NewMigrator(db)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Nenhuma migração pendente.")
+		}
+		return nil
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			fmt.Println("Nenhuma migração aplicada.")
+		}
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSÃO\tNOME\tAPLICADA EM")
+		for _, status := range statuses {
+			appliedAt := "pendente"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/services"
+)
+
+// runOutboxRelay publica os eventos da caixa de saída até o contexto ser cancelado. Enquanto há eventos
+// pendentes, os lotes seguem um atrás do outro; sem nenhum, a caixa é lida de novo a cada interval.
+func runOutboxRelay(ctx context.Context, relay *services.OutboxRelay, interval time.Duration) {
+	log.Printf("[outbox] eventos pendentes publicados a cada %s", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sent, err := relay.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[outbox] erro ao publicar eventos: %v", err)
+		}
+		if err == nil && sent > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
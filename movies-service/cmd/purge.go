@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+)
+
+// purgeActor é o autor dos eventos de expurgo.
+const purgeActor = "purge"
+
+// runTrashPurge expurga, a cada interval, os filmes que estão na lixeira há mais tempo que retention,
+// até o contexto ser cancelado. Uma retenção zero ou negativa desliga o expurgo.
+func runTrashPurge(ctx context.Context, service ports.MovieService, retention, interval time.Duration) {
+	if retention <= 0 || interval <= 0 {
+		log.Println("[purge] expurgo da lixeira desligado")
+		return
+	}
+	log.Printf("[purge] lixeira expurgada a cada %s; retenção de %s", interval, retention)
+
+	ctx = domain.WithActor(ctx, purgeActor)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := service.PurgeDeletedMovies(ctx, retention)
+		if purged > 0 {
+			log.Printf("[purge] %d filmes removidos definitivamente", purged)
+		}
+		if err != nil {
+			log.Printf("[purge] erro ao expurgar a lixeira: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/services"
+)
+
+const seedUsage = "uso: movies-service seed [--file movies.json] [--mode insert|upsert]"
+
+// seedLockName é a trava que impede duas instâncias de carregarem o seed ao mesmo tempo.
+const seedLockName = "seed"
+
+// seedActor é o autor registrado no histórico dos filmes alterados pelo seed.
+const seedActor = "seed"
+
+// MovieSeed é um filme do arquivo de seed. O id identifica o filme no arquivo e vira o SourceID; quando é
+// um número, é também o id do filme no IMDb sem o prefixo "tt", como em data/movies.json.
+type MovieSeed struct {
+	ID               seedID            `json:"id"`
+	Title            string            `json:"title"`
+	Year             seedYear          `json:"year"`
+	Genres           []string          `json:"genres"`
+	Directors        []string          `json:"directors"`
+	Cast             []string          `json:"cast"`
+	RuntimeMinutes   int               `json:"runtime_minutes"`
+	Synopsis         string            `json:"synopsis"`
+	OriginalLanguage string            `json:"original_language"`
+	ReleaseDate      string            `json:"release_date"`
+	ExternalIDs      map[string]string `json:"external_ids"`
+}
+
+// externalIDs devolve os ids externos do filme, com o id do IMDb tirado do id do arquivo se ele não vier em external_ids.
+func (seed MovieSeed) externalIDs() map[string]string {
+	ids := maps.Clone(seed.ExternalIDs)
+	if _, err := strconv.ParseUint(string(seed.ID), 10, 64); err != nil {
+		return ids
+	}
+	if _, ok := ids[domain.ProviderIMDb]; !ok {
+		if ids == nil {
+			ids = make(map[string]string, 1)
+		}
+		ids[domain.ProviderIMDb] = string(seed.ID)
+	}
+	return ids
+}
+
+// seedID aceita o id do arquivo como número (como em data/movies.json) ou como texto.
+type seedID string
+
+func (id *seedID) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*id = seedID(text)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("id deve ser número ou texto, não %s", data)
+	}
+	*id = seedID(number.String())
+	return nil
+}
+
+// seedYear aceita o ano como número ou como texto (como em data/movies.json). Um ano vazio fica zero, e o
+// serviço o completa com o ano do fim do título (ver domain.Movie.NormalizeTitle).
+type seedYear int
+
+func (year *seedYear) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		data = []byte(strings.TrimSpace(text))
+		if len(data) == 0 {
+			*year = 0
+			return nil
+		}
+	}
+	var number int
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("year deve ser um número inteiro, não %s", data)
+	}
+	*year = seedYear(number)
+	return nil
+}
+
+// runSeed executa o subcomando `seed`, que carrega um arquivo no catálogo do REPOSITORY_DRIVER:
+//
+//	--file  arquivo JSON com os filmes (padrão: SEED_FILE)
+//	--mode  insert cria só os filmes novos; upsert também atualiza os que já foram carregados
+//
+// Os filmes são identificados pelo id do arquivo, então o comando pode ser repetido com segurança.
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := flags.String("file", seedFilePath(), "arquivo JSON com os filmes")
+	modeName := flags.String("mode", string(domain.ImportInsert), "insert ou upsert")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errors.New(seedUsage)
+	}
+	mode, err := domain.ParseImportMode(*modeName)
+	if err != nil {
+		return err
+	}
+	if mode == domain.ImportDryRun {
+		return errors.New(seedUsage)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), seedTimeout())
+	defer cancel()
+
+	store, err := openStorage(ctx, getEnv("REPOSITORY_DRIVER", driverMongo))
+	if err != nil {
+		return err
+	}
+	defer store.close(context.Background())
+	// Com a caixa de saída, os eventos das alterações ficam gravados para o relay do serviço publicar.
+	opts := append([]services.Option{services.WithRevisionStore(store.revisions)}, store.eventOptions(nil)...)
+	movieService := services.NewMovieService(store.repository, opts...)
+
+	report, err := seedCatalog(ctx, store.locker, movieService, *file, mode)
+	if errors.Is(err, domain.ErrLockHeld) {
+		return errors.New("outra instância está carregando o seed; tente de novo quando ela terminar")
+	}
+	if err != nil {
+		return err
+	}
+	logSeedReport(*file, report)
+	for _, rejected := range report.Rejected {
+		log.Printf("Filme %d (%q) recusado: %v", rejected.Line, rejected.Movie.Title, rejected.Err)
+	}
+	return nil
+}
+
+// seedOnStartup carrega o SEED_FILE no modo insert com o serviço já no ar, criando só os filmes que ainda não
+// estão no catálogo. Nenhuma falha aqui derruba o serviço, que segue com o catálogo que já tem; o encerramento
+// do serviço (ctx) interrompe a carga.
+func seedOnStartup(ctx context.Context, locker ports.Locker, service ports.MovieService) {
+	ctx, cancel := context.WithTimeout(ctx, seedTimeout())
+	defer cancel()
+
+	path := seedFilePath()
+	report, err := seedCatalog(ctx, locker, service, path, domain.ImportInsert)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Printf("Arquivo de seed %s não encontrado. Seguindo com o catálogo atual.", path)
+	case errors.Is(err, domain.ErrLockHeld):
+		log.Println("Outra instância está carregando o seed. Seguindo com o catálogo atual.")
+	case err != nil:
+		log.Printf("Erro ao carregar o seed %s: %v. Seguindo com o catálogo atual.", path, err)
+	default:
+		logSeedReport(path, report)
+	}
+}
+
+// seedCatalog carrega o arquivo no catálogo com a trava do seed. A trava vale pelo mesmo tempo que a carga
+// pode levar (SEED_TIMEOUT). Se ainda assim duas cargas se sobrepuserem, o índice único de SourceID impede
+// filmes duplicados.
+func seedCatalog(ctx context.Context, locker ports.Locker, service ports.MovieService, path string, mode domain.ImportMode) (*domain.SeedReport, error) {
+	movies, err := readSeedFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := locker.TryLock(ctx, seedLockName, seedTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := unlock(context.Background()); err != nil {
+			log.Printf("Erro ao liberar a trava do seed: %v", err)
+		}
+	}()
+
+	log.Printf("Carregando %d filmes de %s (modo %s)...", len(movies), path, mode)
+	return service.SeedMovies(domain.WithActor(ctx, seedActor), mode, movies)
+}
+
+func logSeedReport(path string, report *domain.SeedReport) {
+	log.Printf("Seed %s carregado: %d criados, %d atualizados, %d ligados a filmes existentes, %d sem alteração, %d ignorados, %d recusados.",
+		path, report.Created, report.Updated, report.Linked, report.Unchanged, report.Skipped, len(report.Rejected))
+}
+
+// seedFilePath é o arquivo padrão do seed. SEED_FILE permite apontar para ../data/movies.json ao rodar o
+// serviço fora do contêiner.
+func seedFilePath() string {
+	return getEnv("SEED_FILE", "/app/data/movies.json")
+}
+
+// seedTimeout é quanto tempo uma carga do seed pode levar.
+func seedTimeout() time.Duration {
+	return getDurationEnv("SEED_TIMEOUT", 10*time.Minute)
+}
+
+// readSeedFile lê e converte o arquivo de seed para filmes do domínio.
+func readSeedFile(path string) ([]domain.Movie, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var movieSeeds []MovieSeed
+	if err := json.Unmarshal(file, &movieSeeds); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar o JSON de %s: %w", path, err)
+	}
+
+	movies := make([]domain.Movie, 0, len(movieSeeds))
+	for _, seed := range movieSeeds {
+		movie := domain.Movie{
+			SourceID:         string(seed.ID),
+			Title:            seed.Title,
+			Year:             int(seed.Year),
+			Genres:           seed.Genres,
+			Directors:        seed.Directors,
+			Cast:             seed.Cast,
+			RuntimeMinutes:   seed.RuntimeMinutes,
+			Synopsis:         seed.Synopsis,
+			OriginalLanguage: seed.OriginalLanguage,
+			ExternalIDs:      seed.externalIDs(),
+		}
+		if seed.ReleaseDate != "" {
+			releaseDate, err := time.Parse(domain.ReleaseDateLayout, seed.ReleaseDate)
+			if err != nil {
+				log.Printf("Data de lançamento inválida para %q: %v", seed.Title, err)
+			} else {
+				movie.ReleaseDate = &releaseDate
+			}
+		}
+		movies = append(movies, movie)
+	}
+	return movies, nil
+}
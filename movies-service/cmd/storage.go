@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	memoryAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/memory"
+	mongoAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/mongodb"
+	sqlAdapter "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/sqldb"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/services"
+)
+
+// Valores aceitos em REPOSITORY_DRIVER.
+const (
+	driverMongo  = "mongo"
+	driverMemory = "memory"
+	driverSQLite = "sqlite"
+)
+
+// storage agrupa os adaptadores de persistência do driver escolhido e a forma de encerrá-los.
+// outbox e transactor só existem no driver mongo.
+type storage struct {
+	repository ports.MovieRepository
+	searcher   ports.MovieSearcher
+	revisions  ports.MovieRevisionStore
+	locker     ports.Locker
+	outbox     ports.EventOutbox
+	transactor ports.Transactor
+	close      func(ctx context.Context) error
+}
+
+// eventOptions liga os eventos de fato ao serviço. Com a caixa de saída, o evento é gravado na transação da escrita
+// e publicado depois pelo relay; sem ela, é publicado direto em publisher, se houver.
+func (s *storage) eventOptions(publisher ports.EventPublisher) []services.Option {
+	if s.outbox != nil {
+		return []services.Option{services.WithTransactor(s.transactor), services.WithEventPublisher(s.outbox)}
+	}
+	if publisher != nil {
+		return []services.Option{services.WithEventPublisher(publisher)}
+	}
+	return nil
+}
+
+// openStorage cria os adaptadores do driver informado. Só o driver mongo exige um banco acessível.
+func openStorage(ctx context.Context, driver string) (*storage, error) {
+	switch driver {
+	case driverMongo:
+		return openMongoStorage(ctx)
+	case driverMemory:
+		return openMemoryStorage(), nil
+	case driverSQLite:
+		return openSQLStorage(ctx, sqlAdapter.SQLite, getEnv("SQL_DSN", "file:movies.db?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"))
+	default:
+		return nil, fmt.Errorf("REPOSITORY_DRIVER desconhecido %q (use %s, %s ou %s)", driver, driverMongo, driverMemory, driverSQLite)
+	}
+}
+
+// openMongoStorage conecta ao MongoDB e aplica as migrações pendentes. Se algo falhar depois da conexão,
+// o cliente é desconectado antes de devolver o erro.
+func openMongoStorage(ctx context.Context) (_ *storage, err error) {
+	client, db, err := connectMongo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = client.Disconnect(context.WithoutCancel(ctx))
+		}
+	}()
+
+	if _, err := mongoAdapter.NewMigrator(db).Up(ctx); err != nil {
+		return nil, fmt.Errorf("failed to migrate mongo: %w", err)
+	}
+
+	movieRepository, err := mongoAdapter.NewMongoRepository(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mongo repository: %w", err)
+	}
+
+	transactor, err := mongoAdapter.NewMongoTransactor(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage{
+		repository: movieRepository,
+		searcher:   mongoAdapter.NewMongoSearcher(db),
+		revisions:  mongoAdapter.NewMongoRevisionStore(db),
+		locker:     mongoAdapter.NewMongoLocker(db),
+		outbox:     mongoAdapter.NewMongoOutbox(db),
+		transactor: transactor,
+		close:      client.Disconnect,
+	}, nil
+}
+
+// connectMongo conecta ao MONGODB_URI e devolve o banco do catálogo.
+func connectMongo(ctx context.Context) (*mongo.Client, *mongo.Database, error) {
+	mongodbURI := getEnv("MONGODB_URI", "mongodb://mongodb:27017")
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongodbURI))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to mongo: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.WithoutCancel(ctx))
+		return nil, nil, fmt.Errorf("failed to ping mongo: %w", err)
+	}
+
+	log.Println("Conectado ao MongoDB")
+	return client, client.Database("moviedb"), nil
+}
+
+// openMemoryStorage sobe um catálogo em memória. Os dados se perdem ao encerrar o processo.
+func openMemoryStorage() *storage {
+	repository := memoryAdapter.NewMemoryRepository()
+	log.Println("Usando repositório em memória; os dados não serão persistidos")
+
+	return &storage{
+		repository: repository,
+		searcher:   repository,
+		revisions:  memoryAdapter.NewMemoryRevisionStore(),
+		locker:     memoryAdapter.NewMemoryLocker(),
+		close:      func(context.Context) error { return nil },
+	}
+}
+
+// openSQLStorage abre o banco SQL e aplica as migrações pendentes.
+// O driver SQL não tem busca textual; SearchMovies responde como indisponível.
+func openSQLStorage(ctx context.Context, dialect sqlAdapter.Dialect, dsn string) (*storage, error) {
+	db, err := sqlAdapter.Open(ctx, dialect, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", dialect.Name(), err)
+	}
+	log.Printf("Conectado ao banco %s", dialect.Name())
+
+	return &storage{
+		repository: sqlAdapter.NewSQLRepository(db, dialect),
+		revisions:  sqlAdapter.NewSQLRevisionStore(db, dialect),
+		locker:     sqlAdapter.NewSQLLocker(db, dialect),
+		close:      func(context.Context) error { return db.Close() },
+	}, nil
+}
@@ -9,6 +9,7 @@ package movies
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,29 +23,1330 @@ const (
 )
 
 type Movie struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Year             int32                  `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`
+	Genres           []string               `protobuf:"bytes,5,rep,name=genres,proto3" json:"genres,omitempty"`
+	Directors        []string               `protobuf:"bytes,6,rep,name=directors,proto3" json:"directors,omitempty"`
+	Cast             []string               `protobuf:"bytes,7,rep,name=cast,proto3" json:"cast,omitempty"`
+	RuntimeMinutes   int32                  `protobuf:"varint,8,opt,name=runtime_minutes,json=runtimeMinutes,proto3" json:"runtime_minutes,omitempty"`
+	Synopsis         string                 `protobuf:"bytes,9,opt,name=synopsis,proto3" json:"synopsis,omitempty"`
+	OriginalLanguage string                 `protobuf:"bytes,10,opt,name=original_language,json=originalLanguage,proto3" json:"original_language,omitempty"`
+	// Data de lançamento no formato YYYY-MM-DD. Vazio quando desconhecida.
+	ReleaseDate string `protobuf:"bytes,11,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	// Momento da remoção (RFC 3339), preenchido só nos filmes da lixeira.
+	DeletedAt string `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Versão do filme, incrementada a cada escrita. Em UpdateMovie, um valor diferente de zero
+	// é a versão esperada: se o filme estiver em outra versão, a chamada falha com FAILED_PRECONDITION.
+	Version int64 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	// Ids do filme em outros catálogos, por provedor: imdb ("tt0000010"), tmdb e movielens (números).
+	ExternalIds   map[string]string `protobuf:"bytes,14,rep,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Movie) Reset() {
+	*x = Movie{}
+	mi := &file_movies_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Movie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Movie) ProtoMessage() {}
+
+func (x *Movie) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Movie.ProtoReflect.Descriptor instead.
+func (*Movie) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Movie) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Movie) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Movie) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Movie) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Movie) GetDirectors() []string {
+	if x != nil {
+		return x.Directors
+	}
+	return nil
+}
+
+func (x *Movie) GetCast() []string {
+	if x != nil {
+		return x.Cast
+	}
+	return nil
+}
+
+func (x *Movie) GetRuntimeMinutes() int32 {
+	if x != nil {
+		return x.RuntimeMinutes
+	}
+	return 0
+}
+
+func (x *Movie) GetSynopsis() string {
+	if x != nil {
+		return x.Synopsis
+	}
+	return ""
+}
+
+func (x *Movie) GetOriginalLanguage() string {
+	if x != nil {
+		return x.OriginalLanguage
+	}
+	return ""
+}
+
+func (x *Movie) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Movie) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *Movie) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Movie) GetExternalIds() map[string]string {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+type GetMovieRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Year          int32                  `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Movie) Reset() {
-	*x = Movie{}
-	mi := &file_movies_proto_msgTypes[0]
+func (x *GetMovieRequest) Reset() {
+	*x = GetMovieRequest{}
+	mi := &file_movies_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMovieRequest) ProtoMessage() {}
+
+func (x *GetMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMovieRequest.ProtoReflect.Descriptor instead.
+func (*GetMovieRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetMovieRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetMovieByExternalIDRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// imdb, tmdb ou movielens.
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Id do filme no provedor. Um id do IMDb só com dígitos é aceito e completado com o prefixo "tt".
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMovieByExternalIDRequest) Reset() {
+	*x = GetMovieByExternalIDRequest{}
+	mi := &file_movies_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMovieByExternalIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMovieByExternalIDRequest) ProtoMessage() {}
+
+func (x *GetMovieByExternalIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMovieByExternalIDRequest.ProtoReflect.Descriptor instead.
+func (*GetMovieByExternalIDRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetMovieByExternalIDRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetMovieByExternalIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateMovieRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Title            string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Year             int32                  `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	Genres           []string               `protobuf:"bytes,4,rep,name=genres,proto3" json:"genres,omitempty"`
+	Directors        []string               `protobuf:"bytes,5,rep,name=directors,proto3" json:"directors,omitempty"`
+	Cast             []string               `protobuf:"bytes,6,rep,name=cast,proto3" json:"cast,omitempty"`
+	RuntimeMinutes   int32                  `protobuf:"varint,7,opt,name=runtime_minutes,json=runtimeMinutes,proto3" json:"runtime_minutes,omitempty"`
+	Synopsis         string                 `protobuf:"bytes,8,opt,name=synopsis,proto3" json:"synopsis,omitempty"`
+	OriginalLanguage string                 `protobuf:"bytes,9,opt,name=original_language,json=originalLanguage,proto3" json:"original_language,omitempty"`
+	ReleaseDate      string                 `protobuf:"bytes,10,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	// Quando verdadeiro, só valida o filme e devolve como ele seria gravado, sem gravar nada.
+	ValidateOnly  bool              `protobuf:"varint,11,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	ExternalIds   map[string]string `protobuf:"bytes,12,rep,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMovieRequest) Reset() {
+	*x = CreateMovieRequest{}
+	mi := &file_movies_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMovieRequest) ProtoMessage() {}
+
+func (x *CreateMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMovieRequest.ProtoReflect.Descriptor instead.
+func (*CreateMovieRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateMovieRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateMovieRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CreateMovieRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *CreateMovieRequest) GetDirectors() []string {
+	if x != nil {
+		return x.Directors
+	}
+	return nil
+}
+
+func (x *CreateMovieRequest) GetCast() []string {
+	if x != nil {
+		return x.Cast
+	}
+	return nil
+}
+
+func (x *CreateMovieRequest) GetRuntimeMinutes() int32 {
+	if x != nil {
+		return x.RuntimeMinutes
+	}
+	return 0
+}
+
+func (x *CreateMovieRequest) GetSynopsis() string {
+	if x != nil {
+		return x.Synopsis
+	}
+	return ""
+}
+
+func (x *CreateMovieRequest) GetOriginalLanguage() string {
+	if x != nil {
+		return x.OriginalLanguage
+	}
+	return ""
+}
+
+func (x *CreateMovieRequest) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *CreateMovieRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+func (x *CreateMovieRequest) GetExternalIds() map[string]string {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+type UpdateMovieRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filme a ser alterado: o id identifica o registro e os demais campos trazem os novos valores.
+	Movie *Movie `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	// Campos de `movie` que devem ser alterados. Uma máscara vazia substitui todos os campos editáveis.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Quando verdadeiro, só valida a alteração e devolve o filme como ficaria, sem gravar nada.
+	ValidateOnly  bool `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMovieRequest) Reset() {
+	*x = UpdateMovieRequest{}
+	mi := &file_movies_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMovieRequest) ProtoMessage() {}
+
+func (x *UpdateMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMovieRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMovieRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateMovieRequest) GetMovie() *Movie {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+func (x *UpdateMovieRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateMovieRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type DeleteMovieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMovieRequest) Reset() {
+	*x = DeleteMovieRequest{}
+	mi := &file_movies_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMovieRequest) ProtoMessage() {}
+
+func (x *DeleteMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMovieRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMovieRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteMovieRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreMovieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreMovieRequest) Reset() {
+	*x = RestoreMovieRequest{}
+	mi := &file_movies_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreMovieRequest) ProtoMessage() {}
+
+func (x *RestoreMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreMovieRequest.ProtoReflect.Descriptor instead.
+func (*RestoreMovieRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RestoreMovieRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevertMovieRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Versão do filme a restaurar, ou seja, a version de uma das revisões do histórico.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertMovieRequest) Reset() {
+	*x = RevertMovieRequest{}
+	mi := &file_movies_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertMovieRequest) ProtoMessage() {}
+
+func (x *RevertMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertMovieRequest.ProtoReflect.Descriptor instead.
+func (*RevertMovieRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RevertMovieRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RevertMovieRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListMovieRevisionsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	MovieId string                 `protobuf:"bytes,1,opt,name=movie_id,json=movieId,proto3" json:"movie_id,omitempty"`
+	// Máximo de revisões (padrão 20, máximo 100).
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Quando informado, lista só as revisões anteriores a essa versão. Para a próxima página,
+	// use a version da última revisão recebida.
+	BeforeVersion int64 `protobuf:"varint,3,opt,name=before_version,json=beforeVersion,proto3" json:"before_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovieRevisionsRequest) Reset() {
+	*x = ListMovieRevisionsRequest{}
+	mi := &file_movies_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovieRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovieRevisionsRequest) ProtoMessage() {}
+
+func (x *ListMovieRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovieRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovieRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMovieRevisionsRequest) GetMovieId() string {
+	if x != nil {
+		return x.MovieId
+	}
+	return ""
+}
+
+func (x *ListMovieRevisionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMovieRevisionsRequest) GetBeforeVersion() int64 {
+	if x != nil {
+		return x.BeforeVersion
+	}
+	return 0
+}
+
+// FieldChange é a alteração de um campo. Os valores vêm codificados em JSON; vazio quando o campo estava ou ficou vazio.
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_movies_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// MovieRevision é uma escrita no filme. A version é a versão que a escrita produziu.
+type MovieRevision struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	MovieId string                 `protobuf:"bytes,1,opt,name=movie_id,json=movieId,proto3" json:"movie_id,omitempty"`
+	Version int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// create, update, delete, restore ou revert.
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Quem fez a escrita, informado no metadado x-actor; "anonymous" quando ausente.
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Momento da escrita (RFC 3339).
+	Timestamp string         `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Changes   []*FieldChange `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	// O filme como ficou depois da escrita.
+	Snapshot *Movie `protobuf:"bytes,7,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Em reversões, a versão restaurada.
+	RevertedTo    int64 `protobuf:"varint,8,opt,name=reverted_to,json=revertedTo,proto3" json:"reverted_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieRevision) Reset() {
+	*x = MovieRevision{}
+	mi := &file_movies_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieRevision) ProtoMessage() {}
+
+func (x *MovieRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieRevision.ProtoReflect.Descriptor instead.
+func (*MovieRevision) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MovieRevision) GetMovieId() string {
+	if x != nil {
+		return x.MovieId
+	}
+	return ""
+}
+
+func (x *MovieRevision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MovieRevision) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *MovieRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *MovieRevision) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *MovieRevision) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *MovieRevision) GetSnapshot() *Movie {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *MovieRevision) GetRevertedTo() int64 {
+	if x != nil {
+		return x.RevertedTo
+	}
+	return 0
+}
+
+type MovieRevisionList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Revisões da mais recente para a mais antiga.
+	Revisions     []*MovieRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieRevisionList) Reset() {
+	*x = MovieRevisionList{}
+	mi := &file_movies_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieRevisionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieRevisionList) ProtoMessage() {}
+
+func (x *MovieRevisionList) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieRevisionList.ProtoReflect.Descriptor instead.
+func (*MovieRevisionList) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MovieRevisionList) GetRevisions() []*MovieRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type BatchGetMoviesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Até 500 IDs.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMoviesRequest) Reset() {
+	*x = BatchGetMoviesRequest{}
+	mi := &file_movies_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMoviesRequest) ProtoMessage() {}
+
+func (x *BatchGetMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMoviesRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchGetMoviesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BulkCreateMoviesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Até 500 filmes. O validate_only de cada filme é ignorado; vale o do lote.
+	Movies []*CreateMovieRequest `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	// Quando verdadeiro, só valida cada filme, sem gravar nada.
+	ValidateOnly  bool `protobuf:"varint,2,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCreateMoviesRequest) Reset() {
+	*x = BulkCreateMoviesRequest{}
+	mi := &file_movies_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateMoviesRequest) ProtoMessage() {}
+
+func (x *BulkCreateMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateMoviesRequest.ProtoReflect.Descriptor instead.
+func (*BulkCreateMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BulkCreateMoviesRequest) GetMovies() []*CreateMovieRequest {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+func (x *BulkCreateMoviesRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type BulkDeleteMoviesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Até 500 IDs.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteMoviesRequest) Reset() {
+	*x = BulkDeleteMoviesRequest{}
+	mi := &file_movies_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteMoviesRequest) ProtoMessage() {}
+
+func (x *BulkDeleteMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteMoviesRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BulkDeleteMoviesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type FieldViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
+	mi := &file_movies_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldViolation) ProtoMessage() {}
+
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ItemError é o erro de um item de um lote. O code é o mesmo código estável do ErrorInfo.reason das chamadas unitárias.
+type ItemError struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Code    string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Violação de cada campo, quando code é INVALID_MOVIE.
+	FieldViolations []*FieldViolation `protobuf:"bytes,3,rep,name=field_violations,json=fieldViolations,proto3" json:"field_violations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ItemError) Reset() {
+	*x = ItemError{}
+	mi := &file_movies_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemError) ProtoMessage() {}
+
+func (x *ItemError) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemError.ProtoReflect.Descriptor instead.
+func (*ItemError) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ItemError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ItemError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ItemError) GetFieldViolations() []*FieldViolation {
+	if x != nil {
+		return x.FieldViolations
+	}
+	return nil
+}
+
+// BatchResult é o resultado de um item do lote: o filme, quando deu certo, ou o erro daquele item.
+type BatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*BatchResult_Movie
+	//	*BatchResult_Error
+	Result        isBatchResult_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_movies_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchResult) GetResult() isBatchResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchResult) GetMovie() *Movie {
+	if x != nil {
+		if x, ok := x.Result.(*BatchResult_Movie); ok {
+			return x.Movie
+		}
+	}
+	return nil
+}
+
+func (x *BatchResult) GetError() *ItemError {
+	if x != nil {
+		if x, ok := x.Result.(*BatchResult_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBatchResult_Result interface {
+	isBatchResult_Result()
+}
+
+type BatchResult_Movie struct {
+	Movie *Movie `protobuf:"bytes,1,opt,name=movie,proto3,oneof"`
+}
+
+type BatchResult_Error struct {
+	Error *ItemError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchResult_Movie) isBatchResult_Result() {}
+
+func (*BatchResult_Error) isBatchResult_Result() {}
+
+type BatchMoviesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Um resultado por item pedido, na mesma ordem.
+	Results       []*BatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchMoviesResponse) Reset() {
+	*x = BatchMoviesResponse{}
+	mi := &file_movies_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchMoviesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchMoviesResponse) ProtoMessage() {}
+
+func (x *BatchMoviesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchMoviesResponse.ProtoReflect.Descriptor instead.
+func (*BatchMoviesResponse) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchMoviesResponse) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ImportMoviesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Modo da importação: insert (padrão) só cria; upsert cria ou substitui o filme com o mesmo título e ano;
+	// dry-run faz as verificações do insert sem gravar nada. Só é lido na primeira mensagem.
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Linha do filme no arquivo de origem, repetida no relatório. Zero usa a posição da mensagem no stream.
+	Line int64 `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	// O filme da linha; o validate_only dele é ignorado. Uma mensagem sem filme só informa o modo.
+	Movie         *CreateMovieRequest `protobuf:"bytes,3,opt,name=movie,proto3" json:"movie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMoviesRequest) Reset() {
+	*x = ImportMoviesRequest{}
+	mi := &file_movies_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMoviesRequest) ProtoMessage() {}
+
+func (x *ImportMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMoviesRequest.ProtoReflect.Descriptor instead.
+func (*ImportMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportMoviesRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ImportMoviesRequest) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportMoviesRequest) GetMovie() *CreateMovieRequest {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+type ImportRowResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Line  int64                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// created, updated, unchanged ou valid (dry-run). Vazio quando a linha foi recusada.
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// O filme como ficou (no dry-run, como ficaria), quando a linha foi aceita.
+	Movie *Movie `protobuf:"bytes,3,opt,name=movie,proto3" json:"movie,omitempty"`
+	// O motivo da recusa.
+	Error         *ItemError `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_movies_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Movie) String() string {
+func (x *ImportRowResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Movie) ProtoMessage() {}
+func (*ImportRowResult) ProtoMessage() {}
 
-func (x *Movie) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[0]
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,54 +1357,65 @@ func (x *Movie) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Movie.ProtoReflect.Descriptor instead.
-func (*Movie) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Movie) GetId() string {
+func (x *ImportRowResult) GetLine() int64 {
 	if x != nil {
-		return x.Id
+		return x.Line
 	}
-	return ""
+	return 0
 }
 
-func (x *Movie) GetTitle() string {
+func (x *ImportRowResult) GetAction() string {
 	if x != nil {
-		return x.Title
+		return x.Action
 	}
 	return ""
 }
 
-func (x *Movie) GetYear() int32 {
+func (x *ImportRowResult) GetMovie() *Movie {
 	if x != nil {
-		return x.Year
+		return x.Movie
 	}
-	return 0
+	return nil
 }
 
-type GetMovieRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ImportRowResult) GetError() *ItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ImportMoviesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Mode     string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Accepted int64                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected int64                  `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// Um resultado por linha recebida, na mesma ordem.
+	Rows          []*ImportRowResult `protobuf:"bytes,4,rep,name=rows,proto3" json:"rows,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMovieRequest) Reset() {
-	*x = GetMovieRequest{}
-	mi := &file_movies_proto_msgTypes[1]
+func (x *ImportMoviesResponse) Reset() {
+	*x = ImportMoviesResponse{}
+	mi := &file_movies_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMovieRequest) String() string {
+func (x *ImportMoviesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMovieRequest) ProtoMessage() {}
+func (*ImportMoviesResponse) ProtoMessage() {}
 
-func (x *GetMovieRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[1]
+func (x *ImportMoviesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,41 +1426,79 @@ func (x *GetMovieRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMovieRequest.ProtoReflect.Descriptor instead.
-func (*GetMovieRequest) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ImportMoviesResponse.ProtoReflect.Descriptor instead.
+func (*ImportMoviesResponse) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *GetMovieRequest) GetId() string {
+func (x *ImportMoviesResponse) GetMode() string {
 	if x != nil {
-		return x.Id
+		return x.Mode
 	}
 	return ""
 }
 
-type CreateMovieRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Year          int32                  `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+func (x *ImportMoviesResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *ImportMoviesResponse) GetRejected() int64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *ImportMoviesResponse) GetRows() []*ImportRowResult {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type ListMoviesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Trecho do título, sem diferenciar maiúsculas nem acentos.
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// Filtros de ano. Quando combinados, vale a interseção entre eles.
+	Year     int32 `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`
+	YearFrom int32 `protobuf:"varint,5,opt,name=year_from,json=yearFrom,proto3" json:"year_from,omitempty"`
+	YearTo   int32 `protobuf:"varint,6,opt,name=year_to,json=yearTo,proto3" json:"year_to,omitempty"`
+	// Década pelo ano inicial, ex.: 1990 para 1990-1999.
+	Decade int32 `protobuf:"varint,7,opt,name=decade,proto3" json:"decade,omitempty"`
+	// Token devolvido em MovieList.next_page_token. Quando informado, a página continua
+	// depois do último filme da página anterior e offset é ignorado.
+	PageToken string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Quando verdadeiro, MovieList.total_count traz quantos filmes atendem aos filtros.
+	// Contar tem custo, por isso é opcional.
+	IncludeTotal bool `protobuf:"varint,9,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	// Campos separados por vírgula, com "-" na frente para ordem decrescente (ex.: "-year,title").
+	// Aceita title, year, runtime_minutes e release_date; o id é sempre o último critério de desempate.
+	OrderBy       string `protobuf:"bytes,10,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMovieRequest) Reset() {
-	*x = CreateMovieRequest{}
-	mi := &file_movies_proto_msgTypes[2]
+func (x *ListMoviesRequest) Reset() {
+	*x = ListMoviesRequest{}
+	mi := &file_movies_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMovieRequest) String() string {
+func (x *ListMoviesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMovieRequest) ProtoMessage() {}
+func (*ListMoviesRequest) ProtoMessage() {}
 
-func (x *CreateMovieRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[2]
+func (x *ListMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,47 +1509,106 @@ func (x *CreateMovieRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMovieRequest.ProtoReflect.Descriptor instead.
-func (*CreateMovieRequest) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListMoviesRequest.ProtoReflect.Descriptor instead.
+func (*ListMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *CreateMovieRequest) GetTitle() string {
+func (x *ListMoviesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetTitle() string {
 	if x != nil {
 		return x.Title
 	}
 	return ""
 }
 
-func (x *CreateMovieRequest) GetYear() int32 {
+func (x *ListMoviesRequest) GetYear() int32 {
 	if x != nil {
 		return x.Year
 	}
 	return 0
 }
 
-type DeleteMovieRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ListMoviesRequest) GetYearFrom() int32 {
+	if x != nil {
+		return x.YearFrom
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetYearTo() int32 {
+	if x != nil {
+		return x.YearTo
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetDecade() int32 {
+	if x != nil {
+		return x.Decade
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+func (x *ListMoviesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type SearchMoviesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Termos da busca. Pontuação é ignorada.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Máximo de resultados (padrão 20, máximo 100).
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMovieRequest) Reset() {
-	*x = DeleteMovieRequest{}
-	mi := &file_movies_proto_msgTypes[3]
+func (x *SearchMoviesRequest) Reset() {
+	*x = SearchMoviesRequest{}
+	mi := &file_movies_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMovieRequest) String() string {
+func (x *SearchMoviesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMovieRequest) ProtoMessage() {}
+func (*SearchMoviesRequest) ProtoMessage() {}
 
-func (x *DeleteMovieRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[3]
+func (x *SearchMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -209,41 +1619,49 @@ func (x *DeleteMovieRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMovieRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMovieRequest) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use SearchMoviesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *DeleteMovieRequest) GetId() string {
+func (x *SearchMoviesRequest) GetQuery() string {
 	if x != nil {
-		return x.Id
+		return x.Query
 	}
 	return ""
 }
 
-type ListMoviesRequest struct {
+func (x *SearchMoviesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SearchHighlight é um trecho de um campo do filme com os termos encontrados entre <em> e </em>.
+type SearchHighlight struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMoviesRequest) Reset() {
-	*x = ListMoviesRequest{}
-	mi := &file_movies_proto_msgTypes[4]
+func (x *SearchHighlight) Reset() {
+	*x = SearchHighlight{}
+	mi := &file_movies_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMoviesRequest) String() string {
+func (x *SearchHighlight) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMoviesRequest) ProtoMessage() {}
+func (*SearchHighlight) ProtoMessage() {}
 
-func (x *ListMoviesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[4]
+func (x *SearchHighlight) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,25 +1672,130 @@ func (x *ListMoviesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMoviesRequest.ProtoReflect.Descriptor instead.
-func (*ListMoviesRequest) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SearchHighlight.ProtoReflect.Descriptor instead.
+func (*SearchHighlight) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ListMoviesRequest) GetLimit() int32 {
+func (x *SearchHighlight) GetField() string {
 	if x != nil {
-		return x.Limit
+		return x.Field
 	}
-	return 0
+	return ""
 }
 
-func (x *ListMoviesRequest) GetOffset() int32 {
+func (x *SearchHighlight) GetSnippet() string {
 	if x != nil {
-		return x.Offset
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Movie *Movie                 `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	// Relevância do filme para a busca; maior é mais relevante.
+	Score         float64            `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights    []*SearchHighlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_movies_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchResult) GetMovie() *Movie {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
 	}
 	return 0
 }
 
+func (x *SearchResult) GetHighlights() []*SearchHighlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type SearchMoviesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMoviesResponse) Reset() {
+	*x = SearchMoviesResponse{}
+	mi := &file_movies_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMoviesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMoviesResponse) ProtoMessage() {}
+
+func (x *SearchMoviesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_movies_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMoviesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMoviesResponse) Descriptor() ([]byte, []int) {
+	return file_movies_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchMoviesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -281,7 +1804,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_movies_proto_msgTypes[5]
+	mi := &file_movies_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -293,7 +1816,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[5]
+	mi := &file_movies_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,19 +1829,23 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{5}
+	return file_movies_proto_rawDescGZIP(), []int{27}
 }
 
 type MovieList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Movies        []*Movie               `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Movies []*Movie               `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	// Token opaco para buscar a próxima página. Vazio na última página.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total de filmes que atendem aos filtros, presente só quando include_total foi pedido.
+	TotalCount    *int64 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3,oneof" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MovieList) Reset() {
 	*x = MovieList{}
-	mi := &file_movies_proto_msgTypes[6]
+	mi := &file_movies_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -330,7 +1857,7 @@ func (x *MovieList) String() string {
 func (*MovieList) ProtoMessage() {}
 
 func (x *MovieList) ProtoReflect() protoreflect.Message {
-	mi := &file_movies_proto_msgTypes[6]
+	mi := &file_movies_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,7 +1870,7 @@ func (x *MovieList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MovieList.ProtoReflect.Descriptor instead.
 func (*MovieList) Descriptor() ([]byte, []int) {
-	return file_movies_proto_rawDescGZIP(), []int{6}
+	return file_movies_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *MovieList) GetMovies() []*Movie {
@@ -353,34 +1880,183 @@ func (x *MovieList) GetMovies() []*Movie {
 	return nil
 }
 
+func (x *MovieList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *MovieList) GetTotalCount() int64 {
+	if x != nil && x.TotalCount != nil {
+		return *x.TotalCount
+	}
+	return 0
+}
+
 var File_movies_proto protoreflect.FileDescriptor
 
 const file_movies_proto_rawDesc = "" +
 	"\n" +
-	"\fmovies.proto\x12\x06movies\"A\n" +
+	"\fmovies.proto\x12\x06movies\x1a google/protobuf/field_mask.proto\"\xdc\x03\n" +
 	"\x05Movie\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
-	"\x04year\x18\x04 \x01(\x05R\x04year\"!\n" +
+	"\x04year\x18\x04 \x01(\x05R\x04year\x12\x16\n" +
+	"\x06genres\x18\x05 \x03(\tR\x06genres\x12\x1c\n" +
+	"\tdirectors\x18\x06 \x03(\tR\tdirectors\x12\x12\n" +
+	"\x04cast\x18\a \x03(\tR\x04cast\x12'\n" +
+	"\x0fruntime_minutes\x18\b \x01(\x05R\x0eruntimeMinutes\x12\x1a\n" +
+	"\bsynopsis\x18\t \x01(\tR\bsynopsis\x12+\n" +
+	"\x11original_language\x18\n" +
+	" \x01(\tR\x10originalLanguage\x12!\n" +
+	"\frelease_date\x18\v \x01(\tR\vreleaseDate\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\tR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\r \x01(\x03R\aversion\x12A\n" +
+	"\fexternal_ids\x18\x0e \x03(\v2\x1e.movies.Movie.ExternalIdsEntryR\vexternalIds\x1a>\n" +
+	"\x10ExternalIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"!\n" +
 	"\x0fGetMovieRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x1bGetMovieByExternalIDRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xd2\x03\n" +
 	"\x12CreateMovieRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
-	"\x04year\x18\x03 \x01(\x05R\x04year\"$\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x16\n" +
+	"\x06genres\x18\x04 \x03(\tR\x06genres\x12\x1c\n" +
+	"\tdirectors\x18\x05 \x03(\tR\tdirectors\x12\x12\n" +
+	"\x04cast\x18\x06 \x03(\tR\x04cast\x12'\n" +
+	"\x0fruntime_minutes\x18\a \x01(\x05R\x0eruntimeMinutes\x12\x1a\n" +
+	"\bsynopsis\x18\b \x01(\tR\bsynopsis\x12+\n" +
+	"\x11original_language\x18\t \x01(\tR\x10originalLanguage\x12!\n" +
+	"\frelease_date\x18\n" +
+	" \x01(\tR\vreleaseDate\x12#\n" +
+	"\rvalidate_only\x18\v \x01(\bR\fvalidateOnly\x12N\n" +
+	"\fexternal_ids\x18\f \x03(\v2+.movies.CreateMovieRequest.ExternalIdsEntryR\vexternalIds\x1a>\n" +
+	"\x10ExternalIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9b\x01\n" +
+	"\x12UpdateMovieRequest\x12#\n" +
+	"\x05movie\x18\x01 \x01(\v2\r.movies.MovieR\x05movie\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12#\n" +
+	"\rvalidate_only\x18\x03 \x01(\bR\fvalidateOnly\"$\n" +
 	"\x12DeleteMovieRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13RestoreMovieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x12RevertMovieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"s\n" +
+	"\x19ListMovieRevisionsRequest\x12\x19\n" +
+	"\bmovie_id\x18\x01 \x01(\tR\amovieId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12%\n" +
+	"\x0ebefore_version\x18\x03 \x01(\x03R\rbeforeVersion\"]\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\x8b\x02\n" +
+	"\rMovieRevision\x12\x19\n" +
+	"\bmovie_id\x18\x01 \x01(\tR\amovieId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\tR\ttimestamp\x12-\n" +
+	"\achanges\x18\x06 \x03(\v2\x13.movies.FieldChangeR\achanges\x12)\n" +
+	"\bsnapshot\x18\a \x01(\v2\r.movies.MovieR\bsnapshot\x12\x1f\n" +
+	"\vreverted_to\x18\b \x01(\x03R\n" +
+	"revertedTo\"H\n" +
+	"\x11MovieRevisionList\x123\n" +
+	"\trevisions\x18\x01 \x03(\v2\x15.movies.MovieRevisionR\trevisions\")\n" +
+	"\x15BatchGetMoviesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"r\n" +
+	"\x17BulkCreateMoviesRequest\x122\n" +
+	"\x06movies\x18\x01 \x03(\v2\x1a.movies.CreateMovieRequestR\x06movies\x12#\n" +
+	"\rvalidate_only\x18\x02 \x01(\bR\fvalidateOnly\"+\n" +
+	"\x17BulkDeleteMoviesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"H\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"|\n" +
+	"\tItemError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12A\n" +
+	"\x10field_violations\x18\x03 \x03(\v2\x16.movies.FieldViolationR\x0ffieldViolations\"i\n" +
+	"\vBatchResult\x12%\n" +
+	"\x05movie\x18\x01 \x01(\v2\r.movies.MovieH\x00R\x05movie\x12)\n" +
+	"\x05error\x18\x02 \x01(\v2\x11.movies.ItemErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"D\n" +
+	"\x13BatchMoviesResponse\x12-\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.movies.BatchResultR\aresults\"o\n" +
+	"\x13ImportMoviesRequest\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x03R\x04line\x120\n" +
+	"\x05movie\x18\x03 \x01(\v2\x1a.movies.CreateMovieRequestR\x05movie\"\x8b\x01\n" +
+	"\x0fImportRowResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12#\n" +
+	"\x05movie\x18\x03 \x01(\v2\r.movies.MovieR\x05movie\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.movies.ItemErrorR\x05error\"\x8f\x01\n" +
+	"\x14ImportMoviesResponse\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x03R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x03 \x01(\x03R\brejected\x12+\n" +
+	"\x04rows\x18\x04 \x03(\v2\x17.movies.ImportRowResultR\x04rows\"\x98\x02\n" +
 	"\x11ListMoviesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\a\n" +
-	"\x05Empty\"2\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04year\x18\x04 \x01(\x05R\x04year\x12\x1b\n" +
+	"\tyear_from\x18\x05 \x01(\x05R\byearFrom\x12\x17\n" +
+	"\ayear_to\x18\x06 \x01(\x05R\x06yearTo\x12\x16\n" +
+	"\x06decade\x18\a \x01(\x05R\x06decade\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\x12#\n" +
+	"\rinclude_total\x18\t \x01(\bR\fincludeTotal\x12\x19\n" +
+	"\border_by\x18\n" +
+	" \x01(\tR\aorderBy\"A\n" +
+	"\x13SearchMoviesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"A\n" +
+	"\x0fSearchHighlight\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\"\x82\x01\n" +
+	"\fSearchResult\x12#\n" +
+	"\x05movie\x18\x01 \x01(\v2\r.movies.MovieR\x05movie\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x127\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2\x17.movies.SearchHighlightR\n" +
+	"highlights\"F\n" +
+	"\x14SearchMoviesResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.movies.SearchResultR\aresults\"\a\n" +
+	"\x05Empty\"\x90\x01\n" +
 	"\tMovieList\x12%\n" +
-	"\x06movies\x18\x01 \x03(\v2\r.movies.MovieR\x06movies2\xf2\x01\n" +
+	"\x06movies\x18\x01 \x03(\v2\r.movies.MovieR\x06movies\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12$\n" +
+	"\vtotal_count\x18\x03 \x01(\x03H\x00R\n" +
+	"totalCount\x88\x01\x01B\x0e\n" +
+	"\f_total_count2\xcb\b\n" +
 	"\fMovieService\x122\n" +
-	"\bGetMovie\x12\x17.movies.GetMovieRequest\x1a\r.movies.Movie\x12:\n" +
+	"\bGetMovie\x12\x17.movies.GetMovieRequest\x1a\r.movies.Movie\x12J\n" +
+	"\x14GetMovieByExternalID\x12#.movies.GetMovieByExternalIDRequest\x1a\r.movies.Movie\x12:\n" +
 	"\n" +
 	"ListMovies\x12\x19.movies.ListMoviesRequest\x1a\x11.movies.MovieList\x128\n" +
 	"\vCreateMovie\x12\x1a.movies.CreateMovieRequest\x1a\r.movies.Movie\x128\n" +
-	"\vDeleteMovie\x12\x1a.movies.DeleteMovieRequest\x1a\r.movies.EmptyBIZGgithub.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go/moviesb\x06proto3"
+	"\vUpdateMovie\x12\x1a.movies.UpdateMovieRequest\x1a\r.movies.Movie\x128\n" +
+	"\vDeleteMovie\x12\x1a.movies.DeleteMovieRequest\x1a\r.movies.Empty\x12I\n" +
+	"\fSearchMovies\x12\x1b.movies.SearchMoviesRequest\x1a\x1c.movies.SearchMoviesResponse\x12A\n" +
+	"\x11ListDeletedMovies\x12\x19.movies.ListMoviesRequest\x1a\x11.movies.MovieList\x12:\n" +
+	"\fRestoreMovie\x12\x1b.movies.RestoreMovieRequest\x1a\r.movies.Movie\x12R\n" +
+	"\x12ListMovieRevisions\x12!.movies.ListMovieRevisionsRequest\x1a\x19.movies.MovieRevisionList\x128\n" +
+	"\vRevertMovie\x12\x1a.movies.RevertMovieRequest\x1a\r.movies.Movie\x12L\n" +
+	"\x0eBatchGetMovies\x12\x1d.movies.BatchGetMoviesRequest\x1a\x1b.movies.BatchMoviesResponse\x12P\n" +
+	"\x10BulkCreateMovies\x12\x1f.movies.BulkCreateMoviesRequest\x1a\x1b.movies.BatchMoviesResponse\x12P\n" +
+	"\x10BulkDeleteMovies\x12\x1f.movies.BulkDeleteMoviesRequest\x1a\x1b.movies.BatchMoviesResponse\x12:\n" +
+	"\fExportMovies\x12\x19.movies.ListMoviesRequest\x1a\r.movies.Movie0\x01\x12K\n" +
+	"\fImportMovies\x12\x1b.movies.ImportMoviesRequest\x1a\x1c.movies.ImportMoviesResponse(\x01BIZGgithub.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go/moviesb\x06proto3"
 
 var (
 	file_movies_proto_rawDescOnce sync.Once
@@ -394,31 +2070,99 @@ func file_movies_proto_rawDescGZIP() []byte {
 	return file_movies_proto_rawDescData
 }
 
-var file_movies_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_movies_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_movies_proto_goTypes = []any{
-	(*Movie)(nil),              // 0: movies.Movie
-	(*GetMovieRequest)(nil),    // 1: movies.GetMovieRequest
-	(*CreateMovieRequest)(nil), // 2: movies.CreateMovieRequest
-	(*DeleteMovieRequest)(nil), // 3: movies.DeleteMovieRequest
-	(*ListMoviesRequest)(nil),  // 4: movies.ListMoviesRequest
-	(*Empty)(nil),              // 5: movies.Empty
-	(*MovieList)(nil),          // 6: movies.MovieList
+	(*Movie)(nil),                       // 0: movies.Movie
+	(*GetMovieRequest)(nil),             // 1: movies.GetMovieRequest
+	(*GetMovieByExternalIDRequest)(nil), // 2: movies.GetMovieByExternalIDRequest
+	(*CreateMovieRequest)(nil),          // 3: movies.CreateMovieRequest
+	(*UpdateMovieRequest)(nil),          // 4: movies.UpdateMovieRequest
+	(*DeleteMovieRequest)(nil),          // 5: movies.DeleteMovieRequest
+	(*RestoreMovieRequest)(nil),         // 6: movies.RestoreMovieRequest
+	(*RevertMovieRequest)(nil),          // 7: movies.RevertMovieRequest
+	(*ListMovieRevisionsRequest)(nil),   // 8: movies.ListMovieRevisionsRequest
+	(*FieldChange)(nil),                 // 9: movies.FieldChange
+	(*MovieRevision)(nil),               // 10: movies.MovieRevision
+	(*MovieRevisionList)(nil),           // 11: movies.MovieRevisionList
+	(*BatchGetMoviesRequest)(nil),       // 12: movies.BatchGetMoviesRequest
+	(*BulkCreateMoviesRequest)(nil),     // 13: movies.BulkCreateMoviesRequest
+	(*BulkDeleteMoviesRequest)(nil),     // 14: movies.BulkDeleteMoviesRequest
+	(*FieldViolation)(nil),              // 15: movies.FieldViolation
+	(*ItemError)(nil),                   // 16: movies.ItemError
+	(*BatchResult)(nil),                 // 17: movies.BatchResult
+	(*BatchMoviesResponse)(nil),         // 18: movies.BatchMoviesResponse
+	(*ImportMoviesRequest)(nil),         // 19: movies.ImportMoviesRequest
+	(*ImportRowResult)(nil),             // 20: movies.ImportRowResult
+	(*ImportMoviesResponse)(nil),        // 21: movies.ImportMoviesResponse
+	(*ListMoviesRequest)(nil),           // 22: movies.ListMoviesRequest
+	(*SearchMoviesRequest)(nil),         // 23: movies.SearchMoviesRequest
+	(*SearchHighlight)(nil),             // 24: movies.SearchHighlight
+	(*SearchResult)(nil),                // 25: movies.SearchResult
+	(*SearchMoviesResponse)(nil),        // 26: movies.SearchMoviesResponse
+	(*Empty)(nil),                       // 27: movies.Empty
+	(*MovieList)(nil),                   // 28: movies.MovieList
+	nil,                                 // 29: movies.Movie.ExternalIdsEntry
+	nil,                                 // 30: movies.CreateMovieRequest.ExternalIdsEntry
+	(*fieldmaskpb.FieldMask)(nil),       // 31: google.protobuf.FieldMask
 }
 var file_movies_proto_depIdxs = []int32{
-	0, // 0: movies.MovieList.movies:type_name -> movies.Movie
-	1, // 1: movies.MovieService.GetMovie:input_type -> movies.GetMovieRequest
-	4, // 2: movies.MovieService.ListMovies:input_type -> movies.ListMoviesRequest
-	2, // 3: movies.MovieService.CreateMovie:input_type -> movies.CreateMovieRequest
-	3, // 4: movies.MovieService.DeleteMovie:input_type -> movies.DeleteMovieRequest
-	0, // 5: movies.MovieService.GetMovie:output_type -> movies.Movie
-	6, // 6: movies.MovieService.ListMovies:output_type -> movies.MovieList
-	0, // 7: movies.MovieService.CreateMovie:output_type -> movies.Movie
-	5, // 8: movies.MovieService.DeleteMovie:output_type -> movies.Empty
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	29, // 0: movies.Movie.external_ids:type_name -> movies.Movie.ExternalIdsEntry
+	30, // 1: movies.CreateMovieRequest.external_ids:type_name -> movies.CreateMovieRequest.ExternalIdsEntry
+	0,  // 2: movies.UpdateMovieRequest.movie:type_name -> movies.Movie
+	31, // 3: movies.UpdateMovieRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,  // 4: movies.MovieRevision.changes:type_name -> movies.FieldChange
+	0,  // 5: movies.MovieRevision.snapshot:type_name -> movies.Movie
+	10, // 6: movies.MovieRevisionList.revisions:type_name -> movies.MovieRevision
+	3,  // 7: movies.BulkCreateMoviesRequest.movies:type_name -> movies.CreateMovieRequest
+	15, // 8: movies.ItemError.field_violations:type_name -> movies.FieldViolation
+	0,  // 9: movies.BatchResult.movie:type_name -> movies.Movie
+	16, // 10: movies.BatchResult.error:type_name -> movies.ItemError
+	17, // 11: movies.BatchMoviesResponse.results:type_name -> movies.BatchResult
+	3,  // 12: movies.ImportMoviesRequest.movie:type_name -> movies.CreateMovieRequest
+	0,  // 13: movies.ImportRowResult.movie:type_name -> movies.Movie
+	16, // 14: movies.ImportRowResult.error:type_name -> movies.ItemError
+	20, // 15: movies.ImportMoviesResponse.rows:type_name -> movies.ImportRowResult
+	0,  // 16: movies.SearchResult.movie:type_name -> movies.Movie
+	24, // 17: movies.SearchResult.highlights:type_name -> movies.SearchHighlight
+	25, // 18: movies.SearchMoviesResponse.results:type_name -> movies.SearchResult
+	0,  // 19: movies.MovieList.movies:type_name -> movies.Movie
+	1,  // 20: movies.MovieService.GetMovie:input_type -> movies.GetMovieRequest
+	2,  // 21: movies.MovieService.GetMovieByExternalID:input_type -> movies.GetMovieByExternalIDRequest
+	22, // 22: movies.MovieService.ListMovies:input_type -> movies.ListMoviesRequest
+	3,  // 23: movies.MovieService.CreateMovie:input_type -> movies.CreateMovieRequest
+	4,  // 24: movies.MovieService.UpdateMovie:input_type -> movies.UpdateMovieRequest
+	5,  // 25: movies.MovieService.DeleteMovie:input_type -> movies.DeleteMovieRequest
+	23, // 26: movies.MovieService.SearchMovies:input_type -> movies.SearchMoviesRequest
+	22, // 27: movies.MovieService.ListDeletedMovies:input_type -> movies.ListMoviesRequest
+	6,  // 28: movies.MovieService.RestoreMovie:input_type -> movies.RestoreMovieRequest
+	8,  // 29: movies.MovieService.ListMovieRevisions:input_type -> movies.ListMovieRevisionsRequest
+	7,  // 30: movies.MovieService.RevertMovie:input_type -> movies.RevertMovieRequest
+	12, // 31: movies.MovieService.BatchGetMovies:input_type -> movies.BatchGetMoviesRequest
+	13, // 32: movies.MovieService.BulkCreateMovies:input_type -> movies.BulkCreateMoviesRequest
+	14, // 33: movies.MovieService.BulkDeleteMovies:input_type -> movies.BulkDeleteMoviesRequest
+	22, // 34: movies.MovieService.ExportMovies:input_type -> movies.ListMoviesRequest
+	19, // 35: movies.MovieService.ImportMovies:input_type -> movies.ImportMoviesRequest
+	0,  // 36: movies.MovieService.GetMovie:output_type -> movies.Movie
+	0,  // 37: movies.MovieService.GetMovieByExternalID:output_type -> movies.Movie
+	28, // 38: movies.MovieService.ListMovies:output_type -> movies.MovieList
+	0,  // 39: movies.MovieService.CreateMovie:output_type -> movies.Movie
+	0,  // 40: movies.MovieService.UpdateMovie:output_type -> movies.Movie
+	27, // 41: movies.MovieService.DeleteMovie:output_type -> movies.Empty
+	26, // 42: movies.MovieService.SearchMovies:output_type -> movies.SearchMoviesResponse
+	28, // 43: movies.MovieService.ListDeletedMovies:output_type -> movies.MovieList
+	0,  // 44: movies.MovieService.RestoreMovie:output_type -> movies.Movie
+	11, // 45: movies.MovieService.ListMovieRevisions:output_type -> movies.MovieRevisionList
+	0,  // 46: movies.MovieService.RevertMovie:output_type -> movies.Movie
+	18, // 47: movies.MovieService.BatchGetMovies:output_type -> movies.BatchMoviesResponse
+	18, // 48: movies.MovieService.BulkCreateMovies:output_type -> movies.BatchMoviesResponse
+	18, // 49: movies.MovieService.BulkDeleteMovies:output_type -> movies.BatchMoviesResponse
+	0,  // 50: movies.MovieService.ExportMovies:output_type -> movies.Movie
+	21, // 51: movies.MovieService.ImportMovies:output_type -> movies.ImportMoviesResponse
+	36, // [36:52] is the sub-list for method output_type
+	20, // [20:36] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_movies_proto_init() }
@@ -426,13 +2170,18 @@ func file_movies_proto_init() {
 	if File_movies_proto != nil {
 		return
 	}
+	file_movies_proto_msgTypes[17].OneofWrappers = []any{
+		(*BatchResult_Movie)(nil),
+		(*BatchResult_Error)(nil),
+	}
+	file_movies_proto_msgTypes[28].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_movies_proto_rawDesc), len(file_movies_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
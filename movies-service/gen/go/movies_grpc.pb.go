@@ -19,20 +19,62 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MovieService_GetMovie_FullMethodName    = "/movies.MovieService/GetMovie"
-	MovieService_ListMovies_FullMethodName  = "/movies.MovieService/ListMovies"
-	MovieService_CreateMovie_FullMethodName = "/movies.MovieService/CreateMovie"
-	MovieService_DeleteMovie_FullMethodName = "/movies.MovieService/DeleteMovie"
+	MovieService_GetMovie_FullMethodName             = "/movies.MovieService/GetMovie"
+	MovieService_GetMovieByExternalID_FullMethodName = "/movies.MovieService/GetMovieByExternalID"
+	MovieService_ListMovies_FullMethodName           = "/movies.MovieService/ListMovies"
+	MovieService_CreateMovie_FullMethodName          = "/movies.MovieService/CreateMovie"
+	MovieService_UpdateMovie_FullMethodName          = "/movies.MovieService/UpdateMovie"
+	MovieService_DeleteMovie_FullMethodName          = "/movies.MovieService/DeleteMovie"
+	MovieService_SearchMovies_FullMethodName         = "/movies.MovieService/SearchMovies"
+	MovieService_ListDeletedMovies_FullMethodName    = "/movies.MovieService/ListDeletedMovies"
+	MovieService_RestoreMovie_FullMethodName         = "/movies.MovieService/RestoreMovie"
+	MovieService_ListMovieRevisions_FullMethodName   = "/movies.MovieService/ListMovieRevisions"
+	MovieService_RevertMovie_FullMethodName          = "/movies.MovieService/RevertMovie"
+	MovieService_BatchGetMovies_FullMethodName       = "/movies.MovieService/BatchGetMovies"
+	MovieService_BulkCreateMovies_FullMethodName     = "/movies.MovieService/BulkCreateMovies"
+	MovieService_BulkDeleteMovies_FullMethodName     = "/movies.MovieService/BulkDeleteMovies"
+	MovieService_ExportMovies_FullMethodName         = "/movies.MovieService/ExportMovies"
+	MovieService_ImportMovies_FullMethodName         = "/movies.MovieService/ImportMovies"
 )
 
 // MovieServiceClient is the client API for MovieService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Filmes que não atendem às regras de validação (título, ano etc.) são recusados com INVALID_ARGUMENT,
+// trazendo nos detalhes um google.rpc.BadRequest com a violação de cada campo.
 type MovieServiceClient interface {
 	GetMovie(ctx context.Context, in *GetMovieRequest, opts ...grpc.CallOption) (*Movie, error)
+	// Busca o filme pelo id que ele tem em outro catálogo. Falha com NOT_FOUND se nenhum filme fora da lixeira
+	// tiver o id, e com INVALID_ARGUMENT (INVALID_EXTERNAL_ID) se o provedor ou o formato do id não forem aceitos.
+	GetMovieByExternalID(ctx context.Context, in *GetMovieByExternalIDRequest, opts ...grpc.CallOption) (*Movie, error)
 	ListMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (*MovieList, error)
 	CreateMovie(ctx context.Context, in *CreateMovieRequest, opts ...grpc.CallOption) (*Movie, error)
+	// Escritas concorrentes no mesmo filme falham com ABORTED e podem ser repetidas.
+	UpdateMovie(ctx context.Context, in *UpdateMovieRequest, opts ...grpc.CallOption) (*Movie, error)
 	DeleteMovie(ctx context.Context, in *DeleteMovieRequest, opts ...grpc.CallOption) (*Empty, error)
+	SearchMovies(ctx context.Context, in *SearchMoviesRequest, opts ...grpc.CallOption) (*SearchMoviesResponse, error)
+	// Lista a lixeira: filmes removidos que ainda não foram expurgados. Aceita os mesmos filtros, ordenação e paginação de ListMovies.
+	ListDeletedMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (*MovieList, error)
+	// Tira o filme da lixeira. Falha com ALREADY_EXISTS se outro filme com o mesmo título e ano foi criado nesse meio-tempo.
+	RestoreMovie(ctx context.Context, in *RestoreMovieRequest, opts ...grpc.CallOption) (*Movie, error)
+	// Histórico de escritas do filme, inclusive de filmes removidos ou expurgados. O autor de cada escrita
+	// vem do metadado x-actor da chamada (ou do evento, nas escritas assíncronas).
+	ListMovieRevisions(ctx context.Context, in *ListMovieRevisionsRequest, opts ...grpc.CallOption) (*MovieRevisionList, error)
+	// Volta os campos editáveis do filme aos valores de uma revisão. Gera uma nova versão e uma revisão "revert".
+	RevertMovie(ctx context.Context, in *RevertMovieRequest, opts ...grpc.CallOption) (*Movie, error)
+	// As operações em lote respondem item a item: um item que falha não impede os demais. O lote inteiro
+	// só falha (INVALID_ARGUMENT, BATCH_TOO_LARGE) quando passa de 500 itens.
+	BatchGetMovies(ctx context.Context, in *BatchGetMoviesRequest, opts ...grpc.CallOption) (*BatchMoviesResponse, error)
+	BulkCreateMovies(ctx context.Context, in *BulkCreateMoviesRequest, opts ...grpc.CallOption) (*BatchMoviesResponse, error)
+	// Move os filmes para a lixeira, cada um com a sua revisão "delete".
+	BulkDeleteMovies(ctx context.Context, in *BulkDeleteMoviesRequest, opts ...grpc.CallOption) (*BatchMoviesResponse, error)
+	// Envia todos os filmes que atendem aos filtros, um por mensagem, na ordenação de order_by. Aceita os mesmos
+	// filtros de ListMovies; limit, offset, page_token e include_total são ignorados.
+	ExportMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Movie], error)
+	// Recebe os filmes de um arquivo, um por mensagem, e responde com o relatório de linhas aceitas e recusadas.
+	// Uma linha recusada não impede as demais; as linhas aceitas são gravadas à medida que chegam.
+	ImportMovies(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportMoviesRequest, ImportMoviesResponse], error)
 }
 
 type movieServiceClient struct {
@@ -53,6 +95,16 @@ func (c *movieServiceClient) GetMovie(ctx context.Context, in *GetMovieRequest,
 	return out, nil
 }
 
+func (c *movieServiceClient) GetMovieByExternalID(ctx context.Context, in *GetMovieByExternalIDRequest, opts ...grpc.CallOption) (*Movie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Movie)
+	err := c.cc.Invoke(ctx, MovieService_GetMovieByExternalID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *movieServiceClient) ListMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (*MovieList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MovieList)
@@ -73,6 +125,16 @@ func (c *movieServiceClient) CreateMovie(ctx context.Context, in *CreateMovieReq
 	return out, nil
 }
 
+func (c *movieServiceClient) UpdateMovie(ctx context.Context, in *UpdateMovieRequest, opts ...grpc.CallOption) (*Movie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Movie)
+	err := c.cc.Invoke(ctx, MovieService_UpdateMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *movieServiceClient) DeleteMovie(ctx context.Context, in *DeleteMovieRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -83,14 +145,156 @@ func (c *movieServiceClient) DeleteMovie(ctx context.Context, in *DeleteMovieReq
 	return out, nil
 }
 
+func (c *movieServiceClient) SearchMovies(ctx context.Context, in *SearchMoviesRequest, opts ...grpc.CallOption) (*SearchMoviesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMoviesResponse)
+	err := c.cc.Invoke(ctx, MovieService_SearchMovies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) ListDeletedMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (*MovieList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieList)
+	err := c.cc.Invoke(ctx, MovieService_ListDeletedMovies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) RestoreMovie(ctx context.Context, in *RestoreMovieRequest, opts ...grpc.CallOption) (*Movie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Movie)
+	err := c.cc.Invoke(ctx, MovieService_RestoreMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) ListMovieRevisions(ctx context.Context, in *ListMovieRevisionsRequest, opts ...grpc.CallOption) (*MovieRevisionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieRevisionList)
+	err := c.cc.Invoke(ctx, MovieService_ListMovieRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) RevertMovie(ctx context.Context, in *RevertMovieRequest, opts ...grpc.CallOption) (*Movie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Movie)
+	err := c.cc.Invoke(ctx, MovieService_RevertMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) BatchGetMovies(ctx context.Context, in *BatchGetMoviesRequest, opts ...grpc.CallOption) (*BatchMoviesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchMoviesResponse)
+	err := c.cc.Invoke(ctx, MovieService_BatchGetMovies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) BulkCreateMovies(ctx context.Context, in *BulkCreateMoviesRequest, opts ...grpc.CallOption) (*BatchMoviesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchMoviesResponse)
+	err := c.cc.Invoke(ctx, MovieService_BulkCreateMovies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) BulkDeleteMovies(ctx context.Context, in *BulkDeleteMoviesRequest, opts ...grpc.CallOption) (*BatchMoviesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchMoviesResponse)
+	err := c.cc.Invoke(ctx, MovieService_BulkDeleteMovies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) ExportMovies(ctx context.Context, in *ListMoviesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Movie], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MovieService_ServiceDesc.Streams[0], MovieService_ExportMovies_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListMoviesRequest, Movie]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MovieService_ExportMoviesClient = grpc.ServerStreamingClient[Movie]
+
+func (c *movieServiceClient) ImportMovies(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportMoviesRequest, ImportMoviesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MovieService_ServiceDesc.Streams[1], MovieService_ImportMovies_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportMoviesRequest, ImportMoviesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MovieService_ImportMoviesClient = grpc.ClientStreamingClient[ImportMoviesRequest, ImportMoviesResponse]
+
 // MovieServiceServer is the server API for MovieService service.
 // All implementations must embed UnimplementedMovieServiceServer
 // for forward compatibility.
+//
+// Filmes que não atendem às regras de validação (título, ano etc.) são recusados com INVALID_ARGUMENT,
+// trazendo nos detalhes um google.rpc.BadRequest com a violação de cada campo.
 type MovieServiceServer interface {
 	GetMovie(context.Context, *GetMovieRequest) (*Movie, error)
+	// Busca o filme pelo id que ele tem em outro catálogo. Falha com NOT_FOUND se nenhum filme fora da lixeira
+	// tiver o id, e com INVALID_ARGUMENT (INVALID_EXTERNAL_ID) se o provedor ou o formato do id não forem aceitos.
+	GetMovieByExternalID(context.Context, *GetMovieByExternalIDRequest) (*Movie, error)
 	ListMovies(context.Context, *ListMoviesRequest) (*MovieList, error)
 	CreateMovie(context.Context, *CreateMovieRequest) (*Movie, error)
+	// Escritas concorrentes no mesmo filme falham com ABORTED e podem ser repetidas.
+	UpdateMovie(context.Context, *UpdateMovieRequest) (*Movie, error)
 	DeleteMovie(context.Context, *DeleteMovieRequest) (*Empty, error)
+	SearchMovies(context.Context, *SearchMoviesRequest) (*SearchMoviesResponse, error)
+	// Lista a lixeira: filmes removidos que ainda não foram expurgados. Aceita os mesmos filtros, ordenação e paginação de ListMovies.
+	ListDeletedMovies(context.Context, *ListMoviesRequest) (*MovieList, error)
+	// Tira o filme da lixeira. Falha com ALREADY_EXISTS se outro filme com o mesmo título e ano foi criado nesse meio-tempo.
+	RestoreMovie(context.Context, *RestoreMovieRequest) (*Movie, error)
+	// Histórico de escritas do filme, inclusive de filmes removidos ou expurgados. O autor de cada escrita
+	// vem do metadado x-actor da chamada (ou do evento, nas escritas assíncronas).
+	ListMovieRevisions(context.Context, *ListMovieRevisionsRequest) (*MovieRevisionList, error)
+	// Volta os campos editáveis do filme aos valores de uma revisão. Gera uma nova versão e uma revisão "revert".
+	RevertMovie(context.Context, *RevertMovieRequest) (*Movie, error)
+	// As operações em lote respondem item a item: um item que falha não impede os demais. O lote inteiro
+	// só falha (INVALID_ARGUMENT, BATCH_TOO_LARGE) quando passa de 500 itens.
+	BatchGetMovies(context.Context, *BatchGetMoviesRequest) (*BatchMoviesResponse, error)
+	BulkCreateMovies(context.Context, *BulkCreateMoviesRequest) (*BatchMoviesResponse, error)
+	// Move os filmes para a lixeira, cada um com a sua revisão "delete".
+	BulkDeleteMovies(context.Context, *BulkDeleteMoviesRequest) (*BatchMoviesResponse, error)
+	// Envia todos os filmes que atendem aos filtros, um por mensagem, na ordenação de order_by. Aceita os mesmos
+	// filtros de ListMovies; limit, offset, page_token e include_total são ignorados.
+	ExportMovies(*ListMoviesRequest, grpc.ServerStreamingServer[Movie]) error
+	// Recebe os filmes de um arquivo, um por mensagem, e responde com o relatório de linhas aceitas e recusadas.
+	// Uma linha recusada não impede as demais; as linhas aceitas são gravadas à medida que chegam.
+	ImportMovies(grpc.ClientStreamingServer[ImportMoviesRequest, ImportMoviesResponse]) error
 	mustEmbedUnimplementedMovieServiceServer()
 }
 
@@ -104,15 +308,51 @@ type UnimplementedMovieServiceServer struct{}
 func (UnimplementedMovieServiceServer) GetMovie(context.Context, *GetMovieRequest) (*Movie, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMovie not implemented")
 }
+func (UnimplementedMovieServiceServer) GetMovieByExternalID(context.Context, *GetMovieByExternalIDRequest) (*Movie, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMovieByExternalID not implemented")
+}
 func (UnimplementedMovieServiceServer) ListMovies(context.Context, *ListMoviesRequest) (*MovieList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMovies not implemented")
 }
 func (UnimplementedMovieServiceServer) CreateMovie(context.Context, *CreateMovieRequest) (*Movie, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMovie not implemented")
 }
+func (UnimplementedMovieServiceServer) UpdateMovie(context.Context, *UpdateMovieRequest) (*Movie, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMovie not implemented")
+}
 func (UnimplementedMovieServiceServer) DeleteMovie(context.Context, *DeleteMovieRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMovie not implemented")
 }
+func (UnimplementedMovieServiceServer) SearchMovies(context.Context, *SearchMoviesRequest) (*SearchMoviesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMovies not implemented")
+}
+func (UnimplementedMovieServiceServer) ListDeletedMovies(context.Context, *ListMoviesRequest) (*MovieList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedMovies not implemented")
+}
+func (UnimplementedMovieServiceServer) RestoreMovie(context.Context, *RestoreMovieRequest) (*Movie, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMovie not implemented")
+}
+func (UnimplementedMovieServiceServer) ListMovieRevisions(context.Context, *ListMovieRevisionsRequest) (*MovieRevisionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovieRevisions not implemented")
+}
+func (UnimplementedMovieServiceServer) RevertMovie(context.Context, *RevertMovieRequest) (*Movie, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertMovie not implemented")
+}
+func (UnimplementedMovieServiceServer) BatchGetMovies(context.Context, *BatchGetMoviesRequest) (*BatchMoviesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetMovies not implemented")
+}
+func (UnimplementedMovieServiceServer) BulkCreateMovies(context.Context, *BulkCreateMoviesRequest) (*BatchMoviesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreateMovies not implemented")
+}
+func (UnimplementedMovieServiceServer) BulkDeleteMovies(context.Context, *BulkDeleteMoviesRequest) (*BatchMoviesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteMovies not implemented")
+}
+func (UnimplementedMovieServiceServer) ExportMovies(*ListMoviesRequest, grpc.ServerStreamingServer[Movie]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMovies not implemented")
+}
+func (UnimplementedMovieServiceServer) ImportMovies(grpc.ClientStreamingServer[ImportMoviesRequest, ImportMoviesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportMovies not implemented")
+}
 func (UnimplementedMovieServiceServer) mustEmbedUnimplementedMovieServiceServer() {}
 func (UnimplementedMovieServiceServer) testEmbeddedByValue()                      {}
 
@@ -152,6 +392,24 @@ func _MovieService_GetMovie_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MovieService_GetMovieByExternalID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMovieByExternalIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).GetMovieByExternalID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_GetMovieByExternalID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).GetMovieByExternalID(ctx, req.(*GetMovieByExternalIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MovieService_ListMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListMoviesRequest)
 	if err := dec(in); err != nil {
@@ -188,6 +446,24 @@ func _MovieService_CreateMovie_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MovieService_UpdateMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).UpdateMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_UpdateMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).UpdateMovie(ctx, req.(*UpdateMovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MovieService_DeleteMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteMovieRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +482,168 @@ func _MovieService_DeleteMovie_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MovieService_SearchMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).SearchMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_SearchMovies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).SearchMovies(ctx, req.(*SearchMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_ListDeletedMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).ListDeletedMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_ListDeletedMovies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).ListDeletedMovies(ctx, req.(*ListMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_RestoreMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).RestoreMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_RestoreMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).RestoreMovie(ctx, req.(*RestoreMovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_ListMovieRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovieRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).ListMovieRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_ListMovieRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).ListMovieRevisions(ctx, req.(*ListMovieRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_RevertMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertMovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).RevertMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_RevertMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).RevertMovie(ctx, req.(*RevertMovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_BatchGetMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).BatchGetMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_BatchGetMovies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).BatchGetMovies(ctx, req.(*BatchGetMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_BulkCreateMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).BulkCreateMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_BulkCreateMovies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).BulkCreateMovies(ctx, req.(*BulkCreateMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_BulkDeleteMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).BulkDeleteMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_BulkDeleteMovies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).BulkDeleteMovies(ctx, req.(*BulkDeleteMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_ExportMovies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMoviesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MovieServiceServer).ExportMovies(m, &grpc.GenericServerStream[ListMoviesRequest, Movie]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MovieService_ExportMoviesServer = grpc.ServerStreamingServer[Movie]
+
+func _MovieService_ImportMovies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MovieServiceServer).ImportMovies(&grpc.GenericServerStream[ImportMoviesRequest, ImportMoviesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MovieService_ImportMoviesServer = grpc.ClientStreamingServer[ImportMoviesRequest, ImportMoviesResponse]
+
 // MovieService_ServiceDesc is the grpc.ServiceDesc for MovieService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,6 +655,10 @@ var MovieService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMovie",
 			Handler:    _MovieService_GetMovie_Handler,
 		},
+		{
+			MethodName: "GetMovieByExternalID",
+			Handler:    _MovieService_GetMovieByExternalID_Handler,
+		},
 		{
 			MethodName: "ListMovies",
 			Handler:    _MovieService_ListMovies_Handler,
@@ -225,11 +667,58 @@ var MovieService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMovie",
 			Handler:    _MovieService_CreateMovie_Handler,
 		},
+		{
+			MethodName: "UpdateMovie",
+			Handler:    _MovieService_UpdateMovie_Handler,
+		},
 		{
 			MethodName: "DeleteMovie",
 			Handler:    _MovieService_DeleteMovie_Handler,
 		},
+		{
+			MethodName: "SearchMovies",
+			Handler:    _MovieService_SearchMovies_Handler,
+		},
+		{
+			MethodName: "ListDeletedMovies",
+			Handler:    _MovieService_ListDeletedMovies_Handler,
+		},
+		{
+			MethodName: "RestoreMovie",
+			Handler:    _MovieService_RestoreMovie_Handler,
+		},
+		{
+			MethodName: "ListMovieRevisions",
+			Handler:    _MovieService_ListMovieRevisions_Handler,
+		},
+		{
+			MethodName: "RevertMovie",
+			Handler:    _MovieService_RevertMovie_Handler,
+		},
+		{
+			MethodName: "BatchGetMovies",
+			Handler:    _MovieService_BatchGetMovies_Handler,
+		},
+		{
+			MethodName: "BulkCreateMovies",
+			Handler:    _MovieService_BulkCreateMovies_Handler,
+		},
+		{
+			MethodName: "BulkDeleteMovies",
+			Handler:    _MovieService_BulkDeleteMovies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportMovies",
+			Handler:       _MovieService_ExportMovies_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportMovies",
+			Handler:       _MovieService_ImportMovies_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "movies.proto",
 }
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// actorMetadataKey é o metadado com quem fez a chamada. A API Gateway repassa nele o header X-Actor.
+const actorMetadataKey = "x-actor"
+
+// ActorInterceptor copia o autor informado nos metadados da chamada para o contexto, onde o serviço
+// o lê ao gravar o histórico de alterações.
+func ActorInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if actors := metadata.ValueFromIncomingContext(ctx, actorMetadataKey); len(actors) > 0 {
+		ctx = domain.WithActor(ctx, actors[0])
+	}
+	return handler(ctx, req)
+}
+
+// ActorStreamInterceptor faz o mesmo que ActorInterceptor nas chamadas com stream, como ImportMovies: o handler
+// recebe um stream cujo Context() carrega o autor.
+func ActorStreamInterceptor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	actors := metadata.ValueFromIncomingContext(stream.Context(), actorMetadataKey)
+	if len(actors) == 0 {
+		return handler(srv, stream)
+	}
+	return handler(srv, &actorServerStream{ServerStream: stream, ctx: domain.WithActor(stream.Context(), actors[0])})
+}
+
+// actorServerStream é o stream da chamada com o contexto trocado pelo que carrega o autor.
+type actorServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorServerStream) Context() context.Context {
+	return s.ctx
+}
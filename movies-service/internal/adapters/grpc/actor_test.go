@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeServerStream é um stream de servidor que só sabe devolver o contexto da chamada.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestActorStreamInterceptor(t *testing.T) {
+	tests := []struct {
+		name  string
+		md    metadata.MD
+		actor string
+	}{
+		{"com autor", metadata.Pairs(actorMetadataKey, "ana"), "ana"},
+		{"sem autor", metadata.MD{}, domain.AnonymousActor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), tt.md)}
+			var actor string
+			err := ActorStreamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/movies.MovieService/ImportMovies"},
+				func(_ any, stream grpc.ServerStream) error {
+					actor = domain.ActorFromContext(stream.Context())
+					return nil
+				})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.actor, actor)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+
+	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifica este serviço no detalhe ErrorInfo dos erros devolvidos.
+const errorDomain = "movies-service"
+
+// grpcCodes diz qual código gRPC corresponde a cada erro do catálogo do domínio.
+var grpcCodes = map[*domain.Error]codes.Code{
+	domain.ErrMissingID:          codes.InvalidArgument,
+	domain.ErrInvalidIDFormat:    codes.InvalidArgument,
+	domain.ErrMovieNotFound:      codes.NotFound,
+	domain.ErrMovieAlreadyExists: codes.AlreadyExists,
+	domain.ErrVersionMismatch:    codes.FailedPrecondition,
+	domain.ErrVersionConflict:    codes.Aborted,
+	domain.ErrInvalidVersion:     codes.InvalidArgument,
+	domain.ErrInvalidMovie:       codes.InvalidArgument,
+	domain.ErrInvalidReleaseDate: codes.InvalidArgument,
+	domain.ErrInvalidUpdateMask:  codes.InvalidArgument,
+	domain.ErrInvalidFilter:      codes.InvalidArgument,
+	domain.ErrInvalidPageToken:   codes.InvalidArgument,
+	domain.ErrInvalidOrderBy:     codes.InvalidArgument,
+	domain.ErrEmptySearchQuery:   codes.InvalidArgument,
+	domain.ErrSearchUnavailable:  codes.Unimplemented,
+	domain.ErrRevisionNotFound:   codes.NotFound,
+	domain.ErrHistoryUnavailable: codes.Unimplemented,
+	domain.ErrBatchTooLarge:      codes.InvalidArgument,
+	domain.ErrInvalidImportMode:  codes.InvalidArgument,
+	domain.ErrInvalidExternalID:  codes.InvalidArgument,
+	domain.ErrInternal:           codes.Internal,
+}
+
+// mapDomainErrorToGRPCStatus é uma função auxiliar que traduz os erros internos do nosso domínio.
+// O código estável do catálogo segue no detalhe ErrorInfo (reason), e as violações de validação no detalhe BadRequest.
+// Erros fora do catálogo são registrados em log e chegam ao cliente apenas como erro interno.
+func mapDomainErrorToGRPCStatus(err error) error {
+	domainErr, message := describeError(err)
+
+	code, ok := grpcCodes[domainErr]
+	if !ok {
+		code = codes.Internal
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: domainErr.Code, Domain: errorDomain}}
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		details = append(details, badRequest(validationErr))
+	}
+
+	st, detailErr := status.New(code, message).WithDetails(details...)
+	if detailErr != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// badRequest leva as violações de cada campo, para que o cliente possa apontá-las no formulário.
+func badRequest(err *domain.ValidationError) *errdetails.BadRequest {
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range err.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	return badRequest
+}
+
+// describeError devolve o erro do catálogo e a mensagem para o cliente. A mensagem de um erro fora
+// do catálogo não é exposta; o erro original fica no log.
+func describeError(err error) (*domain.Error, string) {
+	domainErr := domain.AsError(err)
+	if domainErr == domain.ErrInternal {
+		log.Printf("Erro interno: %v", err)
+		return domainErr, domainErr.Message
+	}
+	return domainErr, err.Error()
+}
+
+// toItemError descreve o erro de um item de lote com o mesmo código e mensagem de uma chamada unitária.
+func toItemError(err error) *pb.ItemError {
+	domainErr, message := describeError(err)
+	itemErr := &pb.ItemError{Code: domainErr.Code, Message: message}
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, violation := range validationErr.Violations {
+			itemErr.FieldViolations = append(itemErr.FieldViolations, &pb.FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+	}
+	return itemErr
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func reasonOf(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestMapDomainErrorToGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		reason  string
+		message string
+	}{
+		{"não encontrado", domain.ErrMovieNotFound, codes.NotFound, "MOVIE_NOT_FOUND", "Filme não encontrado"},
+		{"ID inválido", domain.ErrInvalidIDFormat, codes.InvalidArgument, "INVALID_ID", "Formato de ID de filme inválido"},
+		{"versão divergente", domain.ErrVersionMismatch, codes.FailedPrecondition, "VERSION_MISMATCH", "A versão do filme não é a esperada"},
+		{"erro embrulhado", fmt.Errorf("%w: campo \"id\" repetido", domain.ErrInvalidOrderBy), codes.InvalidArgument, "INVALID_ORDER_BY", "Ordenação inválida: campo \"id\" repetido"},
+		{"fora do catálogo", errors.New("conexão recusada"), codes.Internal, "INTERNAL", "Um erro interno ocorreu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(mapDomainErrorToGRPCStatus(tt.err))
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+			assert.Equal(t, tt.reason, reasonOf(st))
+		})
+	}
+}
+
+func TestMapDomainErrorToGRPCStatus_Validation(t *testing.T) {
+	err := &domain.ValidationError{Violations: []domain.FieldViolation{{Field: "title", Description: "O título é obrigatório"}}}
+
+	st := status.Convert(mapDomainErrorToGRPCStatus(err))
+
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "INVALID_MOVIE", reasonOf(st))
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.FieldViolations
+		}
+	}
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "title", violations[0].Field)
+	}
+}
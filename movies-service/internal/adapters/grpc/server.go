@@ -2,16 +2,17 @@ package grpc
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
+	"io"
 	"log"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/metadata"
 
 	pb "github.com/jamescookdev/projeto-sipub-tech/movies-service/gen/go"
-	repository "github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/adapters/mongodb"
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
-
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // serverAdapter é a implementação do servidor gRPC gerado pelo Protobuf.
@@ -25,22 +26,17 @@ func NewGRPCServerAdapter(service ports.MovieService) pb.MovieServiceServer {
 	return &serverAdapter{service: service}
 }
 
-// mapDomainErrorToGRPCStatus é uma função auxiliar que traduz os erros internos do nosso domínio
-func mapDomainErrorToGRPCStatus(err error) error {
-	switch {
-		case errors.Is(err, repository.ErrMovieNotFound):
-			return status.Error(codes.NotFound, err.Error())
-		case errors.Is(err, repository.ErrInvalidIDFormat):
-			return status.Error(codes.InvalidArgument, err.Error())
-		default:
-			return status.Error(codes.Internal, "Um erro interno ocorreu")
-	}
-}
-		
+// cacheControlMetadataKey é o metadado com que a API Gateway pede a versão atual do filme: com "no-cache",
+// GetMovie não usa o cache do serviço.
+const cacheControlMetadataKey = "cache-control"
+
 // GetMovie é o handler para a chamada RPC GetMovie.
 func (s *serverAdapter) GetMovie(ctx context.Context, req *pb.GetMovieRequest) (*pb.Movie, error) {
 	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "Movie ID cannot be empty")
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrMissingID)
+	}
+	if slices.Contains(metadata.ValueFromIncomingContext(ctx, cacheControlMetadataKey), "no-cache") {
+		ctx = domain.WithFreshRead(ctx)
 	}
 
 	movie, err := s.service.GetMovie(ctx, req.Id)
@@ -51,9 +47,145 @@ func (s *serverAdapter) GetMovie(ctx context.Context, req *pb.GetMovieRequest) (
 	return toGRPCMovie(movie), nil
 }
 
+// GetMovieByExternalID é o handler para a chamada RPC GetMovieByExternalID.
+func (s *serverAdapter) GetMovieByExternalID(ctx context.Context, req *pb.GetMovieByExternalIDRequest) (*pb.Movie, error) {
+	movie, err := s.service.GetMovieByExternalID(ctx, req.Provider, req.Id)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	return toGRPCMovie(movie), nil
+}
+
 // ListMovies é o handler para a chamada RPC ListMovies
 func (s *serverAdapter) ListMovies(ctx context.Context, req *pb.ListMoviesRequest) (*pb.MovieList, error) {
+	query, err := toMovieQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.service.ListMovies(ctx, query)
+	if err != nil {
+		log.Printf("Error ao listar filmes: %v", err)
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	return toGRPCMovieList(page), nil
+}
+
+// ListDeletedMovies é o handler para a chamada RPC ListDeletedMovies.
+func (s *serverAdapter) ListDeletedMovies(ctx context.Context, req *pb.ListMoviesRequest) (*pb.MovieList, error) {
+	query, err := toMovieQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.service.ListDeletedMovies(ctx, query)
+	if err != nil {
+		log.Printf("Error ao listar a lixeira: %v", err)
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	return toGRPCMovieList(page), nil
+}
+
+// ExportMovies é o handler para a chamada RPC ExportMovies. Cada filme é enviado assim que sai do banco,
+// então o catálogo inteiro nunca fica em memória.
+func (s *serverAdapter) ExportMovies(req *pb.ListMoviesRequest, stream pb.MovieService_ExportMoviesServer) error {
+	query, err := toMovieQuery(req)
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = s.service.ExportMovies(stream.Context(), query, func(movie domain.Movie) error {
+		sendErr = stream.Send(toGRPCMovie(&movie))
+		return sendErr
+	})
+	if sendErr != nil {
+		// O cliente desistiu ou a conexão caiu; o erro do envio já é um status gRPC.
+		return sendErr
+	}
+	if err != nil {
+		log.Printf("Erro ao exportar filmes: %v", err)
+		return mapDomainErrorToGRPCStatus(err)
+	}
+	return nil
+}
+
+// ImportMovies é o handler para a chamada RPC ImportMovies. O modo vem da primeira mensagem; cada mensagem
+// com filme é uma linha, aplicada assim que chega.
+func (s *serverAdapter) ImportMovies(stream pb.MovieService_ImportMoviesServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return stream.SendAndClose(toGRPCImportReport(&domain.ImportReport{Mode: domain.ImportInsert}))
+	}
+	if err != nil {
+		return err
+	}
+	mode, err := domain.ParseImportMode(first.GetMode())
+	if err != nil {
+		return mapDomainErrorToGRPCStatus(err)
+	}
+
+	pending := first
+	var position int64
+	var recvErr error
+	next := func() (domain.ImportRow, error) {
+		for {
+			req := pending
+			pending = nil
+			if req == nil {
+				if req, recvErr = stream.Recv(); recvErr != nil {
+					return domain.ImportRow{}, recvErr
+				}
+			}
+			if req.Movie == nil {
+				continue
+			}
+			position++
+			row := domain.ImportRow{Line: req.Line}
+			if row.Line == 0 {
+				row.Line = position
+			}
+			row.Movie, row.Err = fromCreateRequest(req.Movie)
+			return row, nil
+		}
+	}
+
+	report, err := s.service.ImportMovies(stream.Context(), mode, next)
+	if recvErr != nil && recvErr != io.EOF {
+		// O cliente desistiu ou a conexão caiu; o erro do recebimento já é um status gRPC.
+		return recvErr
+	}
+	if err != nil {
+		log.Printf("Erro ao importar filmes: %v", err)
+		return mapDomainErrorToGRPCStatus(err)
+	}
+	return stream.SendAndClose(toGRPCImportReport(report))
+}
 
+// toGRPCImportReport converte o relatório da importação para a resposta gRPC.
+func toGRPCImportReport(report *domain.ImportReport) *pb.ImportMoviesResponse {
+	res := &pb.ImportMoviesResponse{
+		Mode:     string(report.Mode),
+		Accepted: report.Accepted,
+		Rejected: report.Rejected,
+		Rows:     make([]*pb.ImportRowResult, len(report.Rows)),
+	}
+	for i, row := range report.Rows {
+		res.Rows[i] = &pb.ImportRowResult{Line: row.Line, Action: string(row.Action)}
+		if row.Err != nil {
+			res.Rows[i].Error = toItemError(row.Err)
+			continue
+		}
+		res.Rows[i].Movie = toGRPCMovie(row.Movie)
+	}
+	return res
+}
+
+// toMovieQuery traduz a requisição de listagem para a consulta do domínio.
+func toMovieQuery(req *pb.ListMoviesRequest) (domain.MovieQuery, error) {
 	var limit int64 = 20
 	if req.Limit > 0 {
 		limit = int64(req.Limit)
@@ -65,42 +197,118 @@ func (s *serverAdapter) ListMovies(ctx context.Context, req *pb.ListMoviesReques
 	}
 
 
-	movies, err := s.service.ListMovies(ctx, limit, offset)
+	orderBy, err := domain.ParseOrderBy(req.OrderBy)
 	if err != nil {
-		log.Printf("Error ao listar filmes: %v", err)
-		return nil, mapDomainErrorToGRPCStatus(err)
+		return domain.MovieQuery{}, mapDomainErrorToGRPCStatus(err)
 	}
-	
-	grpcMovies := make([]*pb.Movie, len(movies)) 
-	for i, movie := range movies {
+
+	return domain.MovieQuery{
+		Filter: domain.MovieFilter{
+			Title:    req.Title,
+			Year:     int(req.Year),
+			YearFrom: int(req.YearFrom),
+			YearTo:   int(req.YearTo),
+			Decade:   int(req.Decade),
+		},
+		OrderBy:      orderBy,
+		Limit:        limit,
+		Offset:       offset,
+		PageToken:    req.PageToken,
+		IncludeTotal: req.IncludeTotal,
+	}, nil
+}
+
+// toGRPCMovieList converte uma página de filmes do domínio para a resposta gRPC.
+func toGRPCMovieList(page *domain.MoviePage) *pb.MovieList {
+	grpcMovies := make([]*pb.Movie, len(page.Movies)) 
+	for i, movie := range page.Movies {
     	grpcMovies[i] = toGRPCMovie(&movie)
 	}
 	
-	return &pb.MovieList{Movies: grpcMovies}, nil
+	return &pb.MovieList{Movies: grpcMovies, NextPageToken: page.NextPageToken, TotalCount: page.TotalCount}
 }
 
 // CreateMovie é o handler para a chamada RPC CreateMovie.
+// As regras do filme são validadas pelo serviço; com validate_only, nada é gravado.
 func (s *serverAdapter) CreateMovie(ctx context.Context, req *pb.CreateMovieRequest) (*pb.Movie, error) {
-	if req.Title == "" {
-		return nil, status.Error(codes.InvalidArgument, "Titulo não pode ser vazio")
+	movie, err := fromCreateRequest(req)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	if req.ValidateOnly {
+		validated, err := s.service.ValidateMovie(ctx, movie, nil)
+		if err != nil {
+			return nil, mapDomainErrorToGRPCStatus(err)
+		}
+		return toGRPCMovie(validated), nil
 	}
 
-	movie, err := s.service.CreateMovie(ctx, req.Title, int(req.Year))
+	created, err := s.service.CreateMovie(ctx, movie)
 	if err != nil {
 		return nil, mapDomainErrorToGRPCStatus(err)
 	}
 
-	return toGRPCMovie(movie), nil
+	return toGRPCMovie(created), nil
+}
+
+// fromCreateRequest converte os dados de um filme novo para o domínio.
+func fromCreateRequest(req *pb.CreateMovieRequest) (domain.Movie, error) {
+	releaseDate, err := parseReleaseDate(req.ReleaseDate)
+	if err != nil {
+		return domain.Movie{}, err
+	}
+	return domain.Movie{
+		Title:            req.Title,
+		Year:             int(req.Year),
+		Genres:           req.Genres,
+		Directors:        req.Directors,
+		Cast:             req.Cast,
+		RuntimeMinutes:   int(req.RuntimeMinutes),
+		Synopsis:         req.Synopsis,
+		OriginalLanguage: req.OriginalLanguage,
+		ReleaseDate:      releaseDate,
+		ExternalIDs:      req.ExternalIds,
+	}, nil
+}
+
+// UpdateMovie é o handler para a chamada RPC UpdateMovie. Só os campos da máscara são alterados.
+// Com validate_only, a alteração é validada sobre o filme atual sem ser gravada.
+func (s *serverAdapter) UpdateMovie(ctx context.Context, req *pb.UpdateMovieRequest) (*pb.Movie, error) {
+	if req.Movie == nil || req.Movie.Id == "" {
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrMissingID)
+	}
+
+	fields := req.GetUpdateMask().GetPaths()
+	movie, err := fromGRPCMovie(req.Movie)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	if req.ValidateOnly {
+		validated, err := s.service.ValidateMovie(ctx, movie, fields)
+		if err != nil {
+			return nil, mapDomainErrorToGRPCStatus(err)
+		}
+		return toGRPCMovie(validated), nil
+	}
+
+	updated, err := s.service.UpdateMovie(ctx, movie, fields)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	return toGRPCMovie(updated), nil
 }
 
 // DeleteMovie é o handler para a chamada RPC DeleteMovie.
 func (s *serverAdapter) DeleteMovie(ctx context.Context, req *pb.DeleteMovieRequest) (*pb.Empty, error) {
 	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "Filme \"ID\" não pode ser vazio")
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrMissingID)
 	}
 
 	
-	err := s.service.DeleteMovie(ctx, req.Id)
+	err := s.service.DeleteMovie(ctx, req.Id, 0)
 	if err != nil {
 			return nil, mapDomainErrorToGRPCStatus(err)
 	}
@@ -108,11 +316,233 @@ func (s *serverAdapter) DeleteMovie(ctx context.Context, req *pb.DeleteMovieRequ
 	return &pb.Empty{}, nil
 }
 
+// RestoreMovie é o handler para a chamada RPC RestoreMovie.
+func (s *serverAdapter) RestoreMovie(ctx context.Context, req *pb.RestoreMovieRequest) (*pb.Movie, error) {
+	if req.Id == "" {
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrMissingID)
+	}
+
+	restored, err := s.service.RestoreMovie(ctx, req.Id)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	return toGRPCMovie(restored), nil
+}
+
+// ListMovieRevisions é o handler para a chamada RPC ListMovieRevisions.
+func (s *serverAdapter) ListMovieRevisions(ctx context.Context, req *pb.ListMovieRevisionsRequest) (*pb.MovieRevisionList, error) {
+	if req.MovieId == "" {
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrMissingID)
+	}
+
+	revisions, err := s.service.ListMovieRevisions(ctx, req.MovieId, req.BeforeVersion, int64(req.Limit))
+	if err != nil {
+		log.Printf("Error ao listar o histórico do filme %s: %v", req.MovieId, err)
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	grpcRevisions := make([]*pb.MovieRevision, len(revisions))
+	for i, revision := range revisions {
+		grpcRevisions[i] = toGRPCRevision(revision)
+	}
+	return &pb.MovieRevisionList{Revisions: grpcRevisions}, nil
+}
+
+// RevertMovie é o handler para a chamada RPC RevertMovie.
+func (s *serverAdapter) RevertMovie(ctx context.Context, req *pb.RevertMovieRequest) (*pb.Movie, error) {
+	if req.Id == "" {
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrMissingID)
+	}
+	if req.Version <= 0 {
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrInvalidVersion)
+	}
+
+	reverted, err := s.service.RevertMovie(ctx, req.Id, req.Version)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	return toGRPCMovie(reverted), nil
+}
+
+// BatchGetMovies é o handler para a chamada RPC BatchGetMovies.
+func (s *serverAdapter) BatchGetMovies(ctx context.Context, req *pb.BatchGetMoviesRequest) (*pb.BatchMoviesResponse, error) {
+	results, err := s.service.BatchGetMovies(ctx, req.Ids)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+	return toGRPCBatch(results), nil
+}
+
+// BulkCreateMovies é o handler para a chamada RPC BulkCreateMovies. Um filme com data de lançamento
+// mal formatada recebe o próprio erro, como os que não passam na validação do serviço.
+func (s *serverAdapter) BulkCreateMovies(ctx context.Context, req *pb.BulkCreateMoviesRequest) (*pb.BatchMoviesResponse, error) {
+	if len(req.Movies) > domain.MaxBatchSize {
+		return nil, mapDomainErrorToGRPCStatus(domain.ErrBatchTooLarge)
+	}
+
+	results := make([]domain.BatchResult, len(req.Movies))
+	movies := make([]domain.Movie, 0, len(req.Movies))
+	positions := make([]int, 0, len(req.Movies))
+	for i, item := range req.Movies {
+		movie, err := fromCreateRequest(item)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if req.ValidateOnly {
+			results[i].Movie, results[i].Err = s.service.ValidateMovie(ctx, movie, nil)
+			continue
+		}
+		movies = append(movies, movie)
+		positions = append(positions, i)
+	}
+
+	if len(movies) > 0 {
+		created, err := s.service.BulkCreateMovies(ctx, movies)
+		if err != nil {
+			return nil, mapDomainErrorToGRPCStatus(err)
+		}
+		for j, result := range created {
+			results[positions[j]] = result
+		}
+	}
+	return toGRPCBatch(results), nil
+}
+
+// BulkDeleteMovies é o handler para a chamada RPC BulkDeleteMovies.
+func (s *serverAdapter) BulkDeleteMovies(ctx context.Context, req *pb.BulkDeleteMoviesRequest) (*pb.BatchMoviesResponse, error) {
+	results, err := s.service.BulkDeleteMovies(ctx, req.Ids)
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+	return toGRPCBatch(results), nil
+}
+
+// toGRPCBatch converte os resultados de um lote, item a item.
+func toGRPCBatch(results []domain.BatchResult) *pb.BatchMoviesResponse {
+	grpcResults := make([]*pb.BatchResult, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			grpcResults[i] = &pb.BatchResult{Result: &pb.BatchResult_Error{Error: toItemError(result.Err)}}
+			continue
+		}
+		grpcResults[i] = &pb.BatchResult{Result: &pb.BatchResult_Movie{Movie: toGRPCMovie(result.Movie)}}
+	}
+	return &pb.BatchMoviesResponse{Results: grpcResults}
+}
+
+// toGRPCRevision converte uma revisão do histórico. Os valores alterados seguem em JSON, já que cada campo tem um tipo.
+func toGRPCRevision(revision domain.MovieRevision) *pb.MovieRevision {
+	changes := make([]*pb.FieldChange, len(revision.Changes))
+	for i, change := range revision.Changes {
+		changes[i] = &pb.FieldChange{
+			Field:    change.Field,
+			OldValue: encodeFieldValue(change.Old),
+			NewValue: encodeFieldValue(change.New),
+		}
+	}
+	return &pb.MovieRevision{
+		MovieId:    revision.MovieID,
+		Version:    revision.Version,
+		Action:     string(revision.Action),
+		Actor:      revision.Actor,
+		Timestamp:  revision.Timestamp.Format(time.RFC3339),
+		Changes:    changes,
+		Snapshot:   toGRPCMovie(&revision.Snapshot),
+		RevertedTo: revision.RevertedTo,
+	}
+}
+
+func encodeFieldValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// SearchMovies é o handler para a chamada RPC SearchMovies.
+func (s *serverAdapter) SearchMovies(ctx context.Context, req *pb.SearchMoviesRequest) (*pb.SearchMoviesResponse, error) {
+	results, err := s.service.SearchMovies(ctx, req.Query, int64(req.Limit))
+	if err != nil {
+		return nil, mapDomainErrorToGRPCStatus(err)
+	}
+
+	grpcResults := make([]*pb.SearchResult, len(results))
+	for i, result := range results {
+		highlights := make([]*pb.SearchHighlight, len(result.Highlights))
+		for j, highlight := range result.Highlights {
+			highlights[j] = &pb.SearchHighlight{Field: highlight.Field, Snippet: highlight.Snippet}
+		}
+		grpcResults[i] = &pb.SearchResult{
+			Movie:      toGRPCMovie(&result.Movie),
+			Score:      result.Score,
+			Highlights: highlights,
+		}
+	}
+
+	return &pb.SearchMoviesResponse{Results: grpcResults}, nil
+}
+
 // toGRPCMovie é uma função de conversão que traduz um struct do nosso domínio (`domain.Movie`)
 func toGRPCMovie(movie *domain.Movie) *pb.Movie {
-	return &pb.Movie{
-		Id:       movie.ID,
-		Title:    movie.Title,
-		Year:     int32(movie.Year),
+	grpcMovie := &pb.Movie{
+		Id:               movie.ID,
+		Title:            movie.Title,
+		Year:             int32(movie.Year),
+		Genres:           movie.Genres,
+		Directors:        movie.Directors,
+		Cast:             movie.Cast,
+		RuntimeMinutes:   int32(movie.RuntimeMinutes),
+		Synopsis:         movie.Synopsis,
+		OriginalLanguage: movie.OriginalLanguage,
+		Version:          movie.Version,
+		ExternalIds:      movie.ExternalIDs,
+	}
+	if movie.ReleaseDate != nil {
+		grpcMovie.ReleaseDate = movie.ReleaseDate.Format(domain.ReleaseDateLayout)
+	}
+	if movie.DeletedAt != nil {
+		grpcMovie.DeletedAt = movie.DeletedAt.Format(time.RFC3339)
+	}
+	return grpcMovie
+}
+
+// fromGRPCMovie faz o caminho inverso de toGRPCMovie.
+func fromGRPCMovie(movie *pb.Movie) (domain.Movie, error) {
+	releaseDate, err := parseReleaseDate(movie.ReleaseDate)
+	if err != nil {
+		return domain.Movie{}, err
+	}
+	return domain.Movie{
+		ID:               movie.Id,
+		Title:            movie.Title,
+		Year:             int(movie.Year),
+		Genres:           movie.Genres,
+		Directors:        movie.Directors,
+		Cast:             movie.Cast,
+		RuntimeMinutes:   int(movie.RuntimeMinutes),
+		Synopsis:         movie.Synopsis,
+		OriginalLanguage: movie.OriginalLanguage,
+		ReleaseDate:      releaseDate,
+		Version:          movie.Version,
+		ExternalIDs:      movie.ExternalIds,
+	}, nil
+}
+
+// parseReleaseDate converte a data de lançamento recebida (AAAA-MM-DD). Uma string vazia significa data desconhecida.
+func parseReleaseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	releaseDate, err := time.Parse(domain.ReleaseDateLayout, value)
+	if err != nil {
+		return nil, domain.ErrInvalidReleaseDate
 	}
+	return &releaseDate, nil
 }
\ No newline at end of file
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+)
+
+// MemoryLocker implementa `ports.Locker` dentro do processo. Acompanha o MemoryRepository, que também
+// não é compartilhado entre instâncias; é seguro para uso concorrente.
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]memoryLock
+	// next numera as travas obtidas, para que unlock não libere a trava que outro obteve depois da expiração.
+	next uint64
+}
+
+type memoryLock struct {
+	owner     uint64
+	expiresAt time.Time
+}
+
+// NewMemoryLocker é o construtor do MemoryLocker.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]memoryLock)}
+}
+
+func (l *MemoryLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(ctx context.Context) error, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if lock, held := l.locks[name]; held && now.Before(lock.expiresAt) {
+		return nil, domain.ErrLockHeld
+	}
+	l.next++
+	owner := l.next
+	l.locks[name] = memoryLock{owner: owner, expiresAt: now.Add(ttl)}
+
+	unlock := func(context.Context) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.locks[name].owner == owner {
+			delete(l.locks, name)
+		}
+		return nil
+	}
+	return unlock, nil
+}
@@ -0,0 +1,423 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Pesos da busca textual, os mesmos do índice de texto do adaptador MongoDB.
+const (
+	titleWeight    = 10
+	synopsisWeight = 2
+)
+
+// MemoryRepository guarda os filmes em memória. Implementa `ports.MovieRepository` e `ports.MovieSearcher`
+// com a mesma semântica do adaptador MongoDB (IDs no formato ObjectID, mesmos erros, filtros, ordenação e paginação),
+// para desenvolvimento local e demonstrações sem banco de dados. É seguro para uso concorrente.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	movies map[string]domain.Movie
+	// keys indexa o ID de cada filme pela chave de duplicidade (título normalizado e ano).
+	keys map[string]string
+	// sources indexa o ID de cada filme fora da lixeira pelo SourceID, como o índice único dos bancos.
+	sources map[string]string
+	// externals indexa o ID de cada filme fora da lixeira por provedor e id externo, como os índices únicos dos bancos.
+	externals map[string]string
+}
+
+// NewMemoryRepository é o construtor do MemoryRepository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		movies:    make(map[string]domain.Movie),
+		keys:      make(map[string]string),
+		sources:   make(map[string]string),
+		externals: make(map[string]string),
+	}
+}
+
+func uniqueKey(movie domain.Movie) string {
+	return domain.TitleKey(movie.Title) + "\x00" + strconv.Itoa(movie.Year)
+}
+
+func externalKey(provider, id string) string {
+	return provider + "\x00" + id
+}
+
+// externalIDTaken diz se algum id externo do filme já pertence a outro filme.
+func (r *MemoryRepository) externalIDTaken(movie domain.Movie) bool {
+	for provider, id := range movie.ExternalIDs {
+		if owner, taken := r.externals[externalKey(provider, id)]; taken && owner != movie.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// indexSources coloca o SourceID e os ids externos do filme nos índices.
+func (r *MemoryRepository) indexSources(movie domain.Movie) {
+	if movie.SourceID != "" {
+		r.sources[movie.SourceID] = movie.ID
+	}
+	for provider, id := range movie.ExternalIDs {
+		r.externals[externalKey(provider, id)] = movie.ID
+	}
+}
+
+// unindexSources tira o SourceID e os ids externos do filme dos índices.
+func (r *MemoryRepository) unindexSources(movie domain.Movie) {
+	delete(r.sources, movie.SourceID)
+	for provider, id := range movie.ExternalIDs {
+		delete(r.externals, externalKey(provider, id))
+	}
+}
+
+func (r *MemoryRepository) Get(ctx context.Context, id string) (*domain.Movie, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movie, ok := r.movies[id]
+	if !ok || movie.DeletedAt != nil {
+		return nil, domain.ErrMovieNotFound
+	}
+	movie = movie.Clone()
+	return &movie, nil
+}
+
+func (r *MemoryRepository) GetAll(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) {
+	var cursor *domain.PageCursor
+	if query.PageToken != "" {
+		decoded, err := domain.DecodePageToken(query.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.Values, err = query.OrderBy.CursorValues(decoded); err != nil {
+			return nil, err
+		}
+		cursor = &decoded
+	}
+
+	r.mu.RLock()
+	matches := make([]domain.Movie, 0)
+	for _, movie := range r.movies {
+		if (movie.DeletedAt != nil) == query.Deleted && query.Filter.Matches(movie) {
+			matches = append(matches, movie.Clone())
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		return query.OrderBy.Compare(matches[i], matches[j]) < 0
+	})
+
+	page := &domain.MoviePage{}
+	if query.IncludeTotal {
+		total := int64(len(matches))
+		page.TotalCount = &total
+	}
+
+	start := min(int(max(query.Offset, 0)), len(matches))
+	if cursor != nil {
+		start = sort.Search(len(matches), func(i int) bool {
+			return query.OrderBy.CompareToCursor(matches[i], *cursor) > 0
+		})
+	}
+	matches = matches[start:]
+
+	if query.Limit > 0 && int64(len(matches)) > query.Limit {
+		matches = matches[:query.Limit]
+		page.NextPageToken = domain.EncodePageToken(query.OrderBy.CursorFor(matches[len(matches)-1]))
+	}
+	page.Movies = matches
+
+	return page, nil
+}
+
+func (r *MemoryRepository) Save(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := uniqueKey(movie)
+	if movie.ID == "" {
+		if _, taken := r.keys[key]; taken {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		if _, taken := r.sources[movie.SourceID]; taken && movie.SourceID != "" {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		if r.externalIDTaken(movie) {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		movie.ID = primitive.NewObjectID().Hex()
+		movie.Version = 1
+	} else {
+		if _, err := primitive.ObjectIDFromHex(movie.ID); err != nil {
+			return nil, domain.ErrInvalidIDFormat
+		}
+		current, ok := r.movies[movie.ID]
+		if !ok || current.DeletedAt != nil {
+			return nil, domain.ErrMovieNotFound
+		}
+		if current.Version != movie.Version {
+			return nil, domain.ErrVersionConflict
+		}
+		if owner, taken := r.keys[key]; taken && owner != movie.ID {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		if owner, taken := r.sources[movie.SourceID]; taken && owner != movie.ID && movie.SourceID != "" {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		if r.externalIDTaken(movie) {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		delete(r.keys, uniqueKey(current))
+		r.unindexSources(current)
+		movie.Version++
+	}
+
+	movie.DeletedAt = nil
+	r.movies[movie.ID] = movie.Clone()
+	r.keys[key] = movie.ID
+	r.indexSources(movie)
+	return &movie, nil
+}
+
+// GetMany devolve os filmes encontrados entre os IDs informados, na ordem dos IDs.
+func (r *MemoryRepository) GetMany(ctx context.Context, ids []string) ([]domain.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movies := make([]domain.Movie, 0, len(ids))
+	for _, id := range ids {
+		if movie, ok := r.movies[id]; ok && movie.DeletedAt == nil {
+			movies = append(movies, movie.Clone())
+		}
+	}
+	return movies, nil
+}
+
+// InsertMany cria os filmes um a um, como Save; os repetidos (inclusive dentro do próprio lote) recebem
+// domain.ErrMovieAlreadyExists.
+func (r *MemoryRepository) InsertMany(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	results := make([]domain.BatchResult, len(movies))
+	for i, movie := range movies {
+		movie.ID = ""
+		results[i].Movie, results[i].Err = r.Save(ctx, movie)
+	}
+	return results, nil
+}
+
+// LinkSources grava o SourceID e os ids externos de cada filme com as mesmas conferências de Save, sem mexer nos
+// demais campos.
+func (r *MemoryRepository) LinkSources(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]domain.BatchResult, len(movies))
+	for i, link := range movies {
+		current, ok := r.movies[link.ID]
+		if !ok || current.DeletedAt != nil || current.SourceID != "" || current.Version != link.Version {
+			results[i].Err = domain.ErrVersionConflict
+			continue
+		}
+		if _, taken := r.sources[link.SourceID]; taken || r.externalIDTaken(link) {
+			results[i].Err = domain.ErrMovieAlreadyExists
+			continue
+		}
+		r.unindexSources(current)
+		current.SourceID = link.SourceID
+		current.ExternalIDs = maps.Clone(link.ExternalIDs)
+		current.Version++
+		r.movies[current.ID] = current
+		r.indexSources(current)
+		linked := current.Clone()
+		results[i].Movie = &linked
+	}
+	return results, nil
+}
+
+// FindByTitle usa o mesmo índice de duplicidade de Save.
+func (r *MemoryRepository) FindByTitle(ctx context.Context, title string, year int) (*domain.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.keys[uniqueKey(domain.Movie{Title: title, Year: year})]
+	if !ok {
+		return nil, domain.ErrMovieNotFound
+	}
+	movie := r.movies[id].Clone()
+	return &movie, nil
+}
+
+// FindBySourceIDs percorre o catálogo, porque o índice de SourceID de Save não tem os filmes da lixeira.
+func (r *MemoryRepository) FindBySourceIDs(ctx context.Context, sourceIDs []string) ([]domain.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movies := make([]domain.Movie, 0, len(sourceIDs))
+	for _, movie := range r.movies {
+		if movie.SourceID != "" && slices.Contains(sourceIDs, movie.SourceID) {
+			movies = append(movies, movie.Clone())
+		}
+	}
+	return movies, nil
+}
+
+// FindByExternalID usa o índice de ids externos de Save.
+func (r *MemoryRepository) FindByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movieID, ok := r.externals[externalKey(provider, id)]
+	if !ok || r.movies[movieID].DeletedAt != nil {
+		return nil, domain.ErrMovieNotFound
+	}
+	movie := r.movies[movieID].Clone()
+	return &movie, nil
+}
+
+// Stream percorre uma cópia dos filmes que atendem à consulta, então fn pode escrever no repositório.
+func (r *MemoryRepository) Stream(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error {
+	page, err := r.GetAll(ctx, domain.MovieQuery{Filter: query.Filter, OrderBy: query.OrderBy, Deleted: query.Deleted})
+	if err != nil {
+		return err
+	}
+	for _, movie := range page.Movies {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(movie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id string, expectedVersion int64) (*domain.Movie, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movie, ok := r.movies[id]
+	if !ok || movie.DeletedAt != nil {
+		return nil, domain.ErrMovieNotFound
+	}
+	if expectedVersion > 0 && movie.Version != expectedVersion {
+		return nil, domain.ErrVersionMismatch
+	}
+	// Fora dos índices, o filme removido não impede que o mesmo título e ano, ou os mesmos ids, sejam cadastrados de novo.
+	delete(r.keys, uniqueKey(movie))
+	r.unindexSources(movie)
+	deletedAt := time.Now().UTC()
+	movie.DeletedAt = &deletedAt
+	movie.Version++
+	r.movies[id] = movie
+
+	movie = movie.Clone()
+	return &movie, nil
+}
+
+func (r *MemoryRepository) Restore(ctx context.Context, id string) (*domain.Movie, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movie, ok := r.movies[id]
+	if !ok || movie.DeletedAt == nil {
+		return nil, domain.ErrMovieNotFound
+	}
+	key := uniqueKey(movie)
+	if _, taken := r.keys[key]; taken {
+		return nil, domain.ErrMovieAlreadyExists
+	}
+	if _, taken := r.sources[movie.SourceID]; taken && movie.SourceID != "" {
+		return nil, domain.ErrMovieAlreadyExists
+	}
+	if r.externalIDTaken(movie) {
+		return nil, domain.ErrMovieAlreadyExists
+	}
+	movie.DeletedAt = nil
+	movie.Version++
+	r.movies[id] = movie
+	r.keys[key] = id
+	r.indexSources(movie)
+
+	movie = movie.Clone()
+	return &movie, nil
+}
+
+func (r *MemoryRepository) Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.Movie, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := []domain.Movie{}
+	for _, movie := range r.movies {
+		if movie.DeletedAt != nil && movie.DeletedAt.Before(deletedBefore) {
+			purged = append(purged, movie)
+		}
+	}
+	sort.Slice(purged, func(i, j int) bool { return purged[i].DeletedAt.Before(*purged[j].DeletedAt) })
+	purged = purged[:min(limit, len(purged))]
+	for _, movie := range purged {
+		delete(r.movies, movie.ID)
+	}
+	return purged, nil
+}
+
+// Search pontua cada filme pelos termos que aparecem como palavras inteiras no título e na sinopse,
+// ignorando maiúsculas e acentos, com os mesmos pesos do índice de texto do MongoDB.
+func (r *MemoryRepository) Search(ctx context.Context, query string, limit int64) ([]domain.SearchResult, error) {
+	terms := domain.SearchTerms(domain.FoldText(query))
+
+	r.mu.RLock()
+	results := make([]domain.SearchResult, 0)
+	for _, movie := range r.movies {
+		if movie.DeletedAt != nil {
+			continue
+		}
+		score := titleWeight*countTerms(movie.Title, terms) + synopsisWeight*countTerms(movie.Synopsis, terms)
+		if score > 0 {
+			results = append(results, domain.SearchResult{Movie: movie.Clone(), Score: float64(score)})
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Movie.ID < results[j].Movie.ID
+	})
+	if limit > 0 && int64(len(results)) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// countTerms conta quantas palavras do texto são iguais a algum dos termos (já normalizados).
+func countTerms(text string, terms []string) int {
+	count := 0
+	for _, word := range domain.SearchTerms(domain.FoldText(text)) {
+		if slices.Contains(terms, word) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,427 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedRepository(t *testing.T, movies ...domain.Movie) *MemoryRepository {
+	t.Helper()
+	repo := NewMemoryRepository()
+	for _, movie := range movies {
+		_, err := repo.Save(context.Background(), movie)
+		require.NoError(t, err)
+	}
+	return repo
+}
+
+func TestMemoryRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	saved, err := repo.Save(ctx, domain.Movie{Title: "Bacurau", Year: 2019, Genres: []string{"Drama"}})
+	require.NoError(t, err)
+	assert.Len(t, saved.ID, 24)
+
+	found, err := repo.Get(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, found)
+
+	found.Genres[0] = "Alterado fora do repositório"
+	found, _ = repo.Get(ctx, saved.ID)
+	assert.Equal(t, []string{"Drama"}, found.Genres)
+
+	_, err = repo.Get(ctx, "id-invalido")
+	assert.ErrorIs(t, err, domain.ErrInvalidIDFormat)
+
+	_, err = repo.Save(ctx, domain.Movie{ID: "64b7f0c2a1b2c3d4e5f60718", Title: "Inexistente"})
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	// A remoção condicionada a outra versão não acontece.
+	_, err = repo.Delete(ctx, saved.ID, saved.Version+1)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	deleted, err := repo.Delete(ctx, saved.ID, saved.Version)
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+	assert.Equal(t, saved.Version+1, deleted.Version)
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Get(ctx, saved.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
+func TestMemoryRepository_GetAllPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := seedRepository(t,
+		domain.Movie{Title: "Le manoir du diable", Year: 1896},
+		domain.Movie{Title: "La sortie des usines Lumière", Year: 1895},
+		domain.Movie{Title: "The Arrival of a Train", Year: 1896},
+		domain.Movie{Title: "Bacurau", Year: 2019},
+	)
+	order, err := domain.ParseOrderBy("-year,title")
+	require.NoError(t, err)
+	query := domain.MovieQuery{Filter: domain.MovieFilter{Decade: 1890}, OrderBy: order, Limit: 2, IncludeTotal: true}
+
+	first, err := repo.GetAll(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), *first.TotalCount)
+	// Os artigos iniciais não contam na ordenação: "The Arrival..." vem antes de "Le manoir...".
+	assert.Equal(t, []string{"The Arrival of a Train", "Le manoir du diable"}, titles(first.Movies))
+	require.NotEmpty(t, first.NextPageToken)
+
+	// Um filme criado entre as páginas não desloca a página seguinte.
+	_, err = repo.Save(ctx, domain.Movie{Title: "A Abertura", Year: 1897})
+	require.NoError(t, err)
+
+	query.PageToken = first.NextPageToken
+	second, err := repo.GetAll(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"La sortie des usines Lumière"}, titles(second.Movies))
+	assert.Empty(t, second.NextPageToken)
+
+	query.OrderBy = nil
+	_, err = repo.GetAll(ctx, query)
+	assert.ErrorIs(t, err, domain.ErrInvalidPageToken)
+}
+
+func TestMemoryRepository_Search(t *testing.T) {
+	repo := seedRepository(t,
+		domain.Movie{Title: "La sortie des usines Lumière", Year: 1895},
+		domain.Movie{Title: "Le manoir du diable", Year: 1896, Synopsis: "Um truque dos irmãos Lumiere"},
+		domain.Movie{Title: "Bacurau", Year: 2019},
+	)
+
+	results, err := repo.Search(context.Background(), "lumière", 10)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "La sortie des usines Lumière", results[0].Movie.Title)
+	assert.Greater(t, results[0].Score, results[1].Score)
+}
+
+func titles(movies []domain.Movie) []string {
+	out := make([]string, len(movies))
+	for i, movie := range movies {
+		out[i] = movie.Title
+	}
+	return out
+}
+
+func TestMemoryRepository_Duplicates(t *testing.T) {
+	ctx := context.Background()
+	repo := seedRepository(t, domain.Movie{Title: "Central do Brasil", Year: 1998})
+
+	_, err := repo.Save(ctx, domain.Movie{Title: "  central do  BRÁSIL", Year: 1998})
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	other, err := repo.Save(ctx, domain.Movie{Title: "Central do Brasil", Year: 2024})
+	require.NoError(t, err)
+	other.Year = 1998
+	_, err = repo.Save(ctx, *other)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	// O título antigo fica livre depois de uma edição ou remoção.
+	other.Year = 2024
+	other.Title = "Central do Brasil (remake)"
+	_, err = repo.Save(ctx, *other)
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, domain.Movie{Title: "Central do Brasil", Year: 2024})
+	assert.NoError(t, err)
+}
+
+func TestMemoryRepository_Batch(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{
+		{Title: "Bacurau", Year: 2019},
+		{Title: "bacurau", Year: 2019},
+		{Title: "Aquarius", Year: 2016},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrMovieAlreadyExists)
+	require.NoError(t, results[2].Err)
+
+	_, err = repo.Delete(ctx, results[2].Movie.ID, 0)
+	require.NoError(t, err)
+
+	movies, err := repo.GetMany(ctx, []string{results[0].Movie.ID, results[2].Movie.ID, "abc"})
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	assert.Equal(t, "Bacurau", movies[0].Title)
+}
+
+func TestMemoryRepository_SourceIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := seedRepository(t, domain.Movie{Title: "Bacurau", Year: 2019, SourceID: "1"}, domain.Movie{Title: "Aquarius", Year: 2016, SourceID: "2"})
+
+	_, err := repo.Save(ctx, domain.Movie{Title: "Tatuagem", Year: 2013, SourceID: "1"})
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	found, err := repo.FindBySourceIDs(ctx, []string{"2", "3"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Aquarius", found[0].Title)
+
+	// O filme na lixeira continua sendo encontrado, mas não ocupa mais o SourceID.
+	trashed, err := repo.Delete(ctx, found[0].ID, 0)
+	require.NoError(t, err)
+	found, err = repo.FindBySourceIDs(ctx, []string{"2"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.NotNil(t, found[0].DeletedAt)
+	_, err = repo.Save(ctx, domain.Movie{Title: "Aquarius", Year: 2016, SourceID: "2", Synopsis: "Nova"})
+	require.NoError(t, err)
+	found, err = repo.FindBySourceIDs(ctx, []string{"2"})
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+	_, err = repo.Restore(ctx, trashed.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+}
+
+func TestMemoryRepository_LinkSources(t *testing.T) {
+	ctx := context.Background()
+	repo := seedRepository(t,
+		domain.Movie{Title: "Bacurau", Year: 2019},
+		domain.Movie{Title: "Aquarius", Year: 2016, SourceID: "2"},
+		domain.Movie{Title: "Tatuagem", Year: 2013},
+	)
+	bacurau, _ := repo.FindByTitle(ctx, "Bacurau", 2019)
+	aquarius, _ := repo.FindByTitle(ctx, "Aquarius", 2016)
+	tatuagem, _ := repo.FindByTitle(ctx, "Tatuagem", 2013)
+
+	bacurau.SourceID, bacurau.ExternalIDs = "1", map[string]string{"tmdb": "473074"}
+	aquarius.SourceID = "3"
+	tatuagem.SourceID = "2"
+	stale := *bacurau
+	stale.Version--
+	results, err := repo.LinkSources(ctx, []domain.Movie{*bacurau, *aquarius, *tatuagem, stale})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, bacurau.Version+1, results[0].Movie.Version)
+	assert.ErrorIs(t, results[1].Err, domain.ErrVersionConflict, "o filme já tem um SourceID")
+	assert.ErrorIs(t, results[2].Err, domain.ErrMovieAlreadyExists)
+	assert.ErrorIs(t, results[3].Err, domain.ErrVersionConflict)
+
+	found, err := repo.FindBySourceIDs(ctx, []string{"1"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, map[string]string{"tmdb": "473074"}, found[0].ExternalIDs)
+	byExternal, err := repo.FindByExternalID(ctx, "tmdb", "473074")
+	require.NoError(t, err)
+	assert.Equal(t, bacurau.ID, byExternal.ID)
+}
+
+func TestMemoryRepository_ExternalIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := seedRepository(t,
+		domain.Movie{Title: "Bacurau", Year: 2019, ExternalIDs: map[string]string{"imdb": "tt2762506", "tmdb": "453278"}},
+		domain.Movie{Title: "Aquarius", Year: 2016},
+	)
+
+	found, err := repo.FindByExternalID(ctx, "tmdb", "453278")
+	require.NoError(t, err)
+	assert.Equal(t, "Bacurau", found.Title)
+	_, err = repo.FindByExternalID(ctx, "imdb", "tt0000010")
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	// O mesmo id em outro provedor não conflita; no mesmo provedor, sim.
+	_, err = repo.Save(ctx, domain.Movie{Title: "Tatuagem", Year: 2013, ExternalIDs: map[string]string{"movielens": "453278"}})
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, domain.Movie{Title: "O Som ao Redor", Year: 2012, ExternalIDs: map[string]string{"imdb": "tt2762506"}})
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	// Trocar o id libera o antigo, e o filme na lixeira libera os seus até ser restaurado.
+	found.ExternalIDs = map[string]string{"imdb": "tt2762507"}
+	_, err = repo.Save(ctx, *found)
+	require.NoError(t, err)
+	_, err = repo.FindByExternalID(ctx, "tmdb", "453278")
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Delete(ctx, found.ID, 0)
+	require.NoError(t, err)
+	_, err = repo.FindByExternalID(ctx, "imdb", "tt2762507")
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	restored, err := repo.Restore(ctx, found.ID)
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, domain.Movie{Title: "O Som ao Redor", Year: 2012, ExternalIDs: map[string]string{"imdb": "tt2762507"}})
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	_, err = repo.Delete(ctx, restored.ID, 0)
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, domain.Movie{Title: "O Som ao Redor", Year: 2012, ExternalIDs: map[string]string{"imdb": "tt2762507"}})
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, restored.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+}
+
+func TestMemoryRepository_Stream(t *testing.T) {
+	repo := seedRepository(t,
+		domain.Movie{Title: "Bacurau", Year: 2019},
+		domain.Movie{Title: "Aquarius", Year: 2016},
+		domain.Movie{Title: "Central do Brasil", Year: 1998},
+	)
+
+	order, _ := domain.ParseOrderBy("-year")
+	var streamed []string
+	err := repo.Stream(context.Background(), domain.MovieQuery{Filter: domain.MovieFilter{YearFrom: 2000}, OrderBy: order, Limit: 1}, func(movie domain.Movie) error {
+		streamed = append(streamed, movie.Title)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bacurau", "Aquarius"}, streamed)
+}
+
+func TestMemoryRepository_SoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := seedRepository(t, domain.Movie{Title: "Bacurau", Year: 2019}, domain.Movie{Title: "Aquarius", Year: 2016})
+	page, err := repo.GetAll(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Title: "bacurau"}})
+	require.NoError(t, err)
+	id := page.Movies[0].ID
+
+	_, err = repo.Delete(ctx, id, 0)
+	require.NoError(t, err)
+	_, err = repo.Get(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Save(ctx, domain.Movie{ID: id, Title: "Bacurau", Year: 2019})
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	page, err = repo.GetAll(ctx, domain.MovieQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Aquarius"}, titles(page.Movies))
+	trash, err := repo.GetAll(ctx, domain.MovieQuery{Deleted: true})
+	require.NoError(t, err)
+	require.Len(t, trash.Movies, 1)
+	assert.NotNil(t, trash.Movies[0].DeletedAt)
+
+	restored, err := repo.Restore(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	_, err = repo.Restore(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	// Um filme igual criado enquanto o original estava na lixeira impede a restauração.
+	_, err = repo.Delete(ctx, id, 0)
+	require.NoError(t, err)
+	duplicate, err := repo.Save(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	// O expurgo respeita o limite e remove primeiro os que estão na lixeira há mais tempo.
+	_, err = repo.Delete(ctx, duplicate.ID, 0)
+	require.NoError(t, err)
+	purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+	purged, err = repo.Purge(ctx, time.Now().Add(time.Second), 1)
+	require.NoError(t, err)
+	if assert.Len(t, purged, 1) {
+		assert.Equal(t, id, purged[0].ID)
+		assert.NotNil(t, purged[0].DeletedAt)
+	}
+	purged, err = repo.Purge(ctx, time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	if assert.Len(t, purged, 1) {
+		assert.Equal(t, duplicate.ID, purged[0].ID)
+	}
+	_, err = repo.Restore(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
+func TestMemoryRepository_Versions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	saved, err := repo.Save(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), saved.Version)
+
+	first, second := *saved, *saved
+	first.Synopsis = "Primeira escrita"
+	updated, err := repo.Save(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+
+	second.Synopsis = "Escrita concorrente"
+	_, err = repo.Save(ctx, second)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	require.NoError(t, err)
+	restored, err := repo.Restore(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), restored.Version)
+}
+
+func TestMemoryRevisionStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRevisionStore()
+	movieID := "64b7f0c2a1b2c3d4e5f60718"
+
+	for _, version := range []int64{2, 1, 3} {
+		require.NoError(t, store.Append(ctx, domain.MovieRevision{
+			MovieID:  movieID,
+			Version:  version,
+			Action:   domain.RevisionUpdate,
+			Snapshot: domain.Movie{ID: movieID, Title: "Bacurau", Genres: []string{"Drama"}, Version: version},
+		}))
+	}
+
+	versions := func(revisions []domain.MovieRevision) []int64 {
+		result := make([]int64, len(revisions))
+		for i, revision := range revisions {
+			result[i] = revision.Version
+		}
+		return result
+	}
+
+	revisions, err := store.List(ctx, movieID, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, versions(revisions))
+
+	revisions, err = store.List(ctx, movieID, 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, versions(revisions))
+
+	revisions, err = store.List(ctx, "64b7f0c2a1b2c3d4e5f60719", 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	revision, err := store.Get(ctx, movieID, 2)
+	require.NoError(t, err)
+	revision.Snapshot.Genres[0] = "Alterado fora do store"
+	revision, _ = store.Get(ctx, movieID, 2)
+	assert.Equal(t, []string{"Drama"}, revision.Snapshot.Genres)
+
+	_, err = store.Get(ctx, movieID, 4)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	_, err = store.List(ctx, "id-invalido", 0, 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidIDFormat)
+}
+
+func TestMemoryLocker(t *testing.T) {
+	ctx := context.Background()
+	locker := NewMemoryLocker()
+
+	unlock, err := locker.TryLock(ctx, "seed", time.Minute)
+	require.NoError(t, err)
+	_, err = locker.TryLock(ctx, "seed", time.Minute)
+	assert.ErrorIs(t, err, domain.ErrLockHeld)
+
+	require.NoError(t, unlock(ctx))
+	expired, err := locker.TryLock(ctx, "seed", -time.Second)
+	require.NoError(t, err)
+	_, err = locker.TryLock(ctx, "seed", time.Minute)
+	require.NoError(t, err, "a trava vencida pode ser tomada")
+	require.NoError(t, expired(ctx))
+	_, err = locker.TryLock(ctx, "seed", time.Minute)
+	assert.ErrorIs(t, err, domain.ErrLockHeld, "unlock não libera a trava tomada por outro")
+}
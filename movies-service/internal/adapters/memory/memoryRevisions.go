@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryRevisionStore guarda o histórico de alterações em memória. Implementa `ports.MovieRevisionStore`
+// e acompanha o MemoryRepository; é seguro para uso concorrente.
+type MemoryRevisionStore struct {
+	mu sync.RWMutex
+	// revisions guarda as revisões de cada filme em ordem crescente de versão.
+	revisions map[string][]domain.MovieRevision
+}
+
+// NewMemoryRevisionStore é o construtor do MemoryRevisionStore.
+func NewMemoryRevisionStore() *MemoryRevisionStore {
+	return &MemoryRevisionStore{revisions: make(map[string][]domain.MovieRevision)}
+}
+
+func (s *MemoryRevisionStore) Append(ctx context.Context, revision domain.MovieRevision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := s.revisions[revision.MovieID]
+	i, found := slices.BinarySearchFunc(history, revision.Version, compareVersion)
+	if found {
+		history[i] = cloneRevision(revision)
+	} else {
+		history = slices.Insert(history, i, cloneRevision(revision))
+	}
+	s.revisions[revision.MovieID] = history
+	return nil
+}
+
+func (s *MemoryRevisionStore) List(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error) {
+	if _, err := primitive.ObjectIDFromHex(movieID); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.revisions[movieID]
+	end := len(history)
+	if beforeVersion > 0 {
+		end, _ = slices.BinarySearchFunc(history, beforeVersion, compareVersion)
+	}
+
+	revisions := make([]domain.MovieRevision, 0)
+	for i := end - 1; i >= 0 && (limit <= 0 || int64(len(revisions)) < limit); i-- {
+		revisions = append(revisions, cloneRevision(history[i]))
+	}
+	return revisions, nil
+}
+
+func (s *MemoryRevisionStore) Get(ctx context.Context, movieID string, version int64) (*domain.MovieRevision, error) {
+	if _, err := primitive.ObjectIDFromHex(movieID); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.revisions[movieID]
+	i, found := slices.BinarySearchFunc(history, version, compareVersion)
+	if !found {
+		return nil, domain.ErrRevisionNotFound
+	}
+	revision := cloneRevision(history[i])
+	return &revision, nil
+}
+
+func compareVersion(revision domain.MovieRevision, version int64) int {
+	switch {
+	case revision.Version < version:
+		return -1
+	case revision.Version > version:
+		return 1
+	}
+	return 0
+}
+
+// cloneRevision copia o filme e a lista de alterações, como os filmes do repositório são copiados com domain.Movie.Clone.
+func cloneRevision(revision domain.MovieRevision) domain.MovieRevision {
+	revision.Snapshot = revision.Snapshot.Clone()
+	revision.Changes = slices.Clone(revision.Changes)
+	return revision
+}
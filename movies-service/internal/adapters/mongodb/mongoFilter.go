@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// accentVariants lista, para cada letra sem acento, as variantes acentuadas aceitas na busca por título.
+var accentVariants = map[rune]string{
+	'a': "aàáâãäåā",
+	'c': "cç",
+	'e': "eèéêëē",
+	'i': "iìíîïī",
+	'n': "nñ",
+	'o': "oòóôõöøō",
+	'u': "uùúûüū",
+	'y': "yýÿ",
+}
+
+// buildFilter traduz o filtro do domínio para uma consulta do MongoDB.
+func buildFilter(f domain.MovieFilter) bson.M {
+	filter := bson.M{}
+
+	if title := strings.TrimSpace(f.Title); title != "" {
+		filter["title"] = primitive.Regex{Pattern: accentInsensitivePattern(title), Options: "i"}
+	}
+
+	from, to := f.YearBounds()
+	switch {
+	case from > 0 && from == to:
+		filter["year"] = from
+	case from > 0 || to > 0:
+		year := bson.M{}
+		if from > 0 {
+			year["$gte"] = from
+		}
+		if to > 0 {
+			year["$lte"] = to
+		}
+		filter["year"] = year
+	}
+
+	return filter
+}
+
+// accentInsensitivePattern monta uma regex que casa o texto com ou sem acentos,
+// trocando cada letra por uma classe com suas variantes ("jose" casa "José").
+func accentInsensitivePattern(s string) string {
+	var b strings.Builder
+	for _, r := range domain.FoldText(s) {
+		if variants, ok := accentVariants[r]; ok {
+			b.WriteString("[" + variants + "]")
+			continue
+		}
+		b.WriteString(regexp.QuoteMeta(string(r)))
+	}
+	return b.String()
+}
+
+// sortKeys são os campos do documento que guardam a forma ordenável de um critério, quando ela não é o próprio campo.
+var sortKeys = map[string]string{"title": "sort_title"}
+
+// sortKey devolve o campo do documento usado para ordenar pelo critério.
+func sortKey(field domain.SortField) string {
+	if key, ok := sortKeys[field.Field]; ok {
+		return key
+	}
+	return field.Field
+}
+
+// sortSpec traduz a ordenação do domínio, sempre terminando em _id para que a ordem seja determinística.
+func sortSpec(order domain.SortOrder) bson.D {
+	spec := bson.D{}
+	for _, field := range order {
+		direction := 1
+		if field.Desc {
+			direction = -1
+		}
+		spec = append(spec, bson.E{Key: sortKey(field), Value: direction})
+	}
+	return append(spec, bson.E{Key: "_id", Value: 1})
+}
+
+// keysetFilter seleciona os documentos que vêm depois do cursor na ordenação informada:
+// (f1 depois de v1) OU (f1 = v1 E f2 depois de v2) OU ... OU (todos iguais E _id > id).
+// No MongoDB, ausente/null ordena antes de qualquer valor, o que muda a condição de "depois".
+func keysetFilter(order domain.SortOrder, values []any, lastID primitive.ObjectID) bson.M {
+	var clauses []bson.M
+	equal := bson.A{}
+	for i, field := range order {
+		if after, ok := afterValue(field, values[i]); ok {
+			clauses = append(clauses, bson.M{"$and": append(append(bson.A{}, equal...), after)})
+		}
+		equal = append(equal, bson.M{sortKey(field): values[i]})
+	}
+	clauses = append(clauses, bson.M{"$and": append(equal, bson.M{"_id": bson.M{"$gt": lastID}})})
+	return bson.M{"$or": clauses}
+}
+
+// afterValue devolve a condição "campo vem depois de value" para um critério; ok é falso quando nada vem depois.
+func afterValue(field domain.SortField, value any) (bson.M, bool) {
+	key := sortKey(field)
+	switch {
+	case !field.Desc && value == nil:
+		return bson.M{key: bson.M{"$ne": nil}}, true
+	case !field.Desc:
+		return bson.M{key: bson.M{"$gt": value}}, true
+	case value == nil:
+		return nil, false
+	default:
+		return bson.M{"$or": bson.A{
+			bson.M{key: bson.M{"$lt": value}},
+			bson.M{key: nil},
+		}}, true
+	}
+}
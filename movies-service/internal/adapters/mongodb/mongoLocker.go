@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// locksCollection guarda uma entrada por trava obtida, com o nome da trava como _id.
+const locksCollection = "locks"
+
+// mongoLocker é a implementação de `ports.Locker` sobre a coleção locks.
+type mongoLocker struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLocker é o construtor do mongoLocker.
+func NewMongoLocker(db *mongo.Database) ports.Locker {
+	return &mongoLocker{collection: db.Collection(locksCollection)}
+}
+
+// TryLock toma a trava com um upsert que só casa com a entrada vencida: se a entrada existe e ainda vale,
+// o upsert tenta inserir outra com o mesmo _id e o índice de _id recusa.
+func (l *mongoLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(ctx context.Context) error, error) {
+	owner := primitive.NewObjectID()
+	now := time.Now().UTC()
+	_, err := l.collection.UpdateOne(ctx,
+		bson.M{"_id": name, "expires_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, domain.ErrLockHeld
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := func(ctx context.Context) error {
+		_, err := l.collection.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+		return err
+	}
+	return unlock, nil
+}
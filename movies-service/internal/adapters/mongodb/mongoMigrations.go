@@ -0,0 +1,689 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrationsCollection guarda uma entrada por migração aplicada, com a versão como _id.
+const migrationsCollection = "schema_migrations"
+
+// ErrIrreversibleMigration indica que a migração não tem passo de reversão.
+var ErrIrreversibleMigration = errors.New("migração não pode ser revertida")
+
+// Migration é um passo versionado do esquema do banco: índices, validadores ou backfills.
+// Up e Down devem ser idempotentes, pois duas instâncias podem aplicar a mesma versão ao mesmo tempo.
+// Down nulo marca a migração como irreversível.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+// MigrationStatus descreve uma migração conhecida e, se aplicada, quando.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// appliedMigration é o documento gravado em schema_migrations.
+type appliedMigration struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// Migrator aplica e reverte as migrações do catálogo, registrando as versões em schema_migrations.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// NewMigrator é o construtor do Migrator com as migrações do serviço.
+func NewMigrator(db *mongo.Database) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{db: db, migrations: sorted}
+}
+
+// Up aplica, em ordem, as migrações pendentes e devolve as que foram aplicadas.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("erro na migração %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		record := appliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}
+		// Outra instância pode ter registrado a mesma versão nesse meio-tempo; como os passos são idempotentes, basta seguir.
+		if _, err := m.db.Collection(migrationsCollection).InsertOne(ctx, record); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return done, err
+		}
+		log.Printf("[mongo] migração %04d_%s aplicada", migration.Version, migration.Name)
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down reverte a última migração aplicada. Devolve nil quando não há nada a reverter.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return nil, fmt.Errorf("%04d_%s: %w", migration.Version, migration.Name, ErrIrreversibleMigration)
+		}
+		if err := migration.Down(ctx, m.db); err != nil {
+			return nil, fmt.Errorf("erro ao reverter a migração %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := m.db.Collection(migrationsCollection).DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return nil, err
+		}
+		log.Printf("[mongo] migração %04d_%s revertida", migration.Version, migration.Name)
+		return &migration, nil
+	}
+	return nil, nil
+}
+
+// Status lista todas as migrações conhecidas, em ordem, com a data de aplicação das já aplicadas.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	cursor, err := m.db.Collection(migrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// migrations são os passos do esquema da coleção de filmes. Novas versões entram no fim da lista.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_sort_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("movies").Indexes().CreateMany(ctx, sortIndexes)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection("movies"), sortIndexes)
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_text_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return ensureTextIndex(ctx, db.Collection("movies"))
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection("movies"), []mongo.IndexModel{{Options: options.Index().SetName(textIndexName)}})
+		},
+	},
+	{
+		// Importações feitas direto do data/movies.json (mongoimport) gravam o ano como string.
+		Version: 3,
+		Name:    "backfill_year_as_int",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("movies").UpdateMany(ctx,
+				bson.M{"year": bson.M{"$type": "string"}},
+				mongo.Pipeline{{{Key: "$set", Value: bson.M{
+					"year": bson.M{"$convert": bson.M{"input": "$year", "to": "int", "onError": "$year"}},
+				}}}},
+			)
+			return err
+		},
+	},
+	{
+		Version: 4,
+		Name:    "add_movies_validator",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return setValidator(ctx, db, "movies", bson.M{"$jsonSchema": movieSchema})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return setValidator(ctx, db, "movies", bson.M{})
+		},
+	},
+	{
+		// O catálogo original já tem filmes diferentes com o mesmo título e ano; só o mais antigo de cada grupo
+		// recebe title_key, e o índice parcial ignora os demais, que continuam legíveis e editáveis.
+		Version: 5,
+		Name:    "add_title_key_unique_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if err := backfillTitleKeys(ctx, collection); err != nil {
+				return err
+			}
+			_, err := collection.Indexes().CreateOne(ctx, titleKeyIndex)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if err := dropIndexes(ctx, collection, []mongo.IndexModel{titleKeyIndex}); err != nil {
+				return err
+			}
+			_, err := collection.UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"title_key": ""}})
+			return err
+		},
+	},
+	{
+		Version: 6,
+		Name:    "create_deleted_at_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("movies").Indexes().CreateOne(ctx, deletedAtIndex)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection("movies"), []mongo.IndexModel{deletedAtIndex})
+		},
+	},
+	{
+		// Filmes gravados antes do controle de concorrência otimista começam na versão 1.
+		Version: 7,
+		Name:    "backfill_movie_version",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("movies").UpdateMany(ctx,
+				bson.M{"version": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"version": 1}},
+			)
+			return err
+		},
+	},
+	{
+		Version: 8,
+		Name:    "create_movie_revisions_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(revisionsCollection).Indexes().CreateOne(ctx, revisionIndex)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection(revisionsCollection), []mongo.IndexModel{revisionIndex})
+		},
+	},
+	{
+		Version: 9,
+		Name:    "create_source_id_unique_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("movies").Indexes().CreateOne(ctx, sourceIDIndex)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection("movies"), []mongo.IndexModel{sourceIDIndex})
+		},
+	},
+	{
+		// Títulos do seed trazem o ano no fim ("La sortie des usines Lumière (1895)"). Não há como saber,
+		// depois, quais títulos tinham o sufixo, por isso a migração não tem volta.
+		Version: 10,
+		Name:    "backfill_normalized_titles",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return backfillNormalizedTitles(ctx, db.Collection("movies"))
+		},
+	},
+	{
+		Version: 11,
+		Name:    "replace_title_sort_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if _, err := collection.Indexes().CreateOne(ctx, sortTitleIndex); err != nil {
+				return err
+			}
+			return dropIndexes(ctx, collection, []mongo.IndexModel{titleSortIndex})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if _, err := collection.Indexes().CreateOne(ctx, titleSortIndex); err != nil {
+				return err
+			}
+			return dropIndexes(ctx, collection, []mongo.IndexModel{sortTitleIndex})
+		},
+	},
+	{
+		// O id de data/movies.json, gravado em source_id pelo seed, é o do título no IMDb sem o prefixo "tt".
+		Version: 12,
+		Name:    "add_external_id_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if err := backfillIMDbIDs(ctx, collection); err != nil {
+				return err
+			}
+			_, err := collection.Indexes().CreateMany(ctx, externalIDIndexes())
+			return err
+		},
+		// Os ids externos ficam nos documentos: desfazer a migração só tira os índices.
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection("movies"), externalIDIndexes())
+		},
+	},
+	{
+		// A coleção é criada aqui porque a primeira gravação nela acontece dentro de uma transação.
+		Version: 13,
+		Name:    "create_outbox_collection",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(outboxCollection).Indexes().CreateMany(ctx, outboxIndexes)
+			return err
+		},
+		// Os eventos pendentes ficam na coleção: desfazer a migração só tira os índices.
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db.Collection(outboxCollection), outboxIndexes)
+		},
+	},
+	{
+		// FindBySourceIDs e FindByExternalID não enxergam a lixeira, então os índices únicos também não a incluem.
+		Version: 14,
+		Name:    "exclude_trash_from_source_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if _, err := collection.Indexes().CreateMany(ctx, liveSourceIndexes()); err != nil {
+				return err
+			}
+			return dropIndexes(ctx, collection, append(externalIDIndexes(), sourceIDIndex))
+		},
+		// Desfazer falha com chave duplicada se um filme fora da lixeira já reutilizou um id de um filme removido.
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection("movies")
+			if _, err := collection.Indexes().CreateMany(ctx, append(externalIDIndexes(), sourceIDIndex)); err != nil {
+				return err
+			}
+			return dropIndexes(ctx, collection, liveSourceIndexes())
+		},
+	},
+}
+
+// deletedAtIndex cobre a listagem da lixeira e o expurgo. É parcial porque quase todo o catálogo está fora da lixeira.
+var deletedAtIndex = mongo.IndexModel{
+	Keys: bson.D{{Key: "deleted_at", Value: 1}},
+	Options: options.Index().
+		SetName("deleted_at").
+		SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$exists": true}}),
+}
+
+// outboxIndexes cobrem a busca dos eventos pendentes, na ordem em que o relay os publica, e removem os já
+// publicados depois de outboxRetention.
+var outboxIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().
+			SetName("outbox_pending").
+			SetPartialFilterExpression(bson.M{"next_attempt_at": bson.M{"$exists": true}}),
+	},
+	{
+		Keys:    bson.D{{Key: "sent_at", Value: 1}},
+		Options: options.Index().SetName("outbox_sent_ttl").SetExpireAfterSeconds(int32(outboxRetention.Seconds())),
+	},
+}
+
+// revisionIndex identifica cada revisão pelo filme e pela versão, e cobre a listagem do histórico da mais recente para a mais antiga.
+var revisionIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "movie_id", Value: 1}, {Key: "version", Value: -1}},
+	Options: options.Index().SetName("movie_id_version_unique").SetUnique(true),
+}
+
+// sourceIDIndex impede dois filmes com o mesmo id de origem do seed, inclusive os da lixeira. Foi trocado
+// pelo de liveSourceIndexes.
+var sourceIDIndex = mongo.IndexModel{
+	Keys: bson.D{{Key: "source_id", Value: 1}},
+	Options: options.Index().
+		SetName("source_id_unique").
+		SetUnique(true).
+		SetPartialFilterExpression(bson.M{"source_id": bson.M{"$exists": true}}),
+}
+
+// externalIDIndexes impedem dois filmes com o mesmo id no mesmo provedor, um índice por provedor. Incluem os
+// filmes da lixeira, como sourceIDIndex, e foram trocados pelos de liveSourceIndexes.
+func externalIDIndexes() []mongo.IndexModel {
+	indexes := make([]mongo.IndexModel, len(domain.ExternalIDProviders))
+	for i, provider := range domain.ExternalIDProviders {
+		field := "external_ids." + provider
+		indexes[i] = mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}},
+			Options: options.Index().
+				SetName("external_ids_" + provider + "_unique").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{field: bson.M{"$exists": true}}),
+		}
+	}
+	return indexes
+}
+
+// liveSourceIndexes impedem dois filmes fora da lixeira com o mesmo id de origem do seed ou o mesmo id no mesmo
+// provedor. Um filme removido não segura os seus ids; restaurá-lo falha com chave duplicada se outro filme os
+// tomou nesse meio-tempo.
+func liveSourceIndexes() []mongo.IndexModel {
+	fields := []string{"source_id"}
+	for _, provider := range domain.ExternalIDProviders {
+		fields = append(fields, "external_ids."+provider)
+	}
+	indexes := make([]mongo.IndexModel, len(fields))
+	for i, field := range fields {
+		indexes[i] = mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}},
+			Options: options.Index().
+				SetName(strings.ReplaceAll(field, ".", "_") + "_live_unique").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{field: bson.M{"$exists": true}, "deleted_at": nil}),
+		}
+	}
+	return indexes
+}
+
+// backfillIMDbIDs grava o id do IMDb dos filmes carregados pelo seed que ainda não têm ids externos.
+func backfillIMDbIDs(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Find(ctx,
+		bson.M{"source_id": bson.M{"$regex": "^[1-9][0-9]*$"}, "external_ids": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"source_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		writes = writes[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID       interface{} `bson:"_id"`
+			SourceID string      `bson:"source_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		_, imdbID := domain.NormalizeExternalID(domain.ProviderIMDb, doc.SourceID)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"external_ids": bson.M{domain.ProviderIMDb: imdbID}}}))
+
+		if len(writes) >= 1000 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// titleKeyIndex impede dois filmes com o mesmo título normalizado e ano.
+var titleKeyIndex = mongo.IndexModel{
+	Keys: bson.D{{Key: "title_key", Value: 1}, {Key: "year", Value: 1}},
+	Options: options.Index().
+		SetName("title_key_year_unique").
+		SetUnique(true).
+		SetPartialFilterExpression(bson.M{"title_key": bson.M{"$exists": true}}),
+}
+
+// backfillTitleKeys calcula title_key para toda a coleção, na ordem de _id, deixando sem chave as duplicatas.
+func backfillTitleKeys(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().
+		SetProjection(bson.M{"title": 1, "year": 1, "title_key": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	seen := map[string]bool{}
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		writes = writes[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID       interface{} `bson:"_id"`
+			Title    string      `bson:"title"`
+			Year     interface{} `bson:"year"`
+			TitleKey *string     `bson:"title_key"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		key := domain.TitleKey(doc.Title)
+		group := fmt.Sprintf("%s\x00%v", key, doc.Year)
+		switch {
+		case seen[group] && doc.TitleKey != nil:
+			writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": doc.ID}).SetUpdate(bson.M{"$unset": bson.M{"title_key": ""}}))
+		case !seen[group] && (doc.TitleKey == nil || *doc.TitleKey != key):
+			writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": doc.ID}).SetUpdate(bson.M{"$set": bson.M{"title_key": key}}))
+		}
+		seen[group] = true
+
+		if len(writes) >= 1000 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// backfillNormalizedTitles tira dos títulos o ano entre parênteses que confere com o do filme, como faz
+// domain.Movie.NormalizeTitle, e grava sort_title em toda a coleção. Um filme cuja chave sem o sufixo já é
+// de outro filme fica sem title_key, como as demais duplicatas.
+func backfillNormalizedTitles(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().
+		SetProjection(bson.M{"title": 1, "year": 1, "title_key": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	type normalized struct {
+		id    interface{}
+		title string
+	}
+	var pending []normalized
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+			// Só colisões no índice único de title_key são esperadas: esses filmes são regravados sem a chave.
+			for _, writeErr := range bulkErr.WriteErrors {
+				if !mongo.IsDuplicateKeyError(writeErr) {
+					return err
+				}
+				doc := pending[writeErr.Index]
+				if _, err := collection.UpdateOne(ctx, bson.M{"_id": doc.id}, bson.M{
+					"$set":   bson.M{"title": doc.title, "sort_title": domain.SortTitle(doc.title)},
+					"$unset": bson.M{"title_key": ""},
+				}); err != nil {
+					return err
+				}
+			}
+			err = nil
+		}
+		writes, pending = writes[:0], pending[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID       interface{} `bson:"_id"`
+			Title    string      `bson:"title"`
+			Year     interface{} `bson:"year"`
+			TitleKey *string     `bson:"title_key"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		// O ano é comparado como texto porque documentos antigos podem ter ficado com ele em string (ver a migração 3).
+		title := doc.Title
+		if stripped, year := domain.SplitTitleYear(doc.Title); year != 0 && fmt.Sprint(doc.Year) == strconv.Itoa(year) {
+			title = stripped
+		}
+		set := bson.M{"sort_title": domain.SortTitle(title)}
+		if title != doc.Title {
+			set["title"] = title
+			if doc.TitleKey != nil {
+				set["title_key"] = domain.TitleKey(title)
+			}
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": doc.ID}).SetUpdate(bson.M{"$set": set}))
+		pending = append(pending, normalized{id: doc.ID, title: title})
+
+		if len(writes) >= 1000 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// titleSortIndex atendia à ordenação por título antes de sort_title (ver a migração 11).
+var titleSortIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}},
+	Options: options.Index().SetName("title_id"),
+}
+
+// sortTitleIndex atende à ordenação por título, que ignora os artigos iniciais.
+var sortTitleIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "sort_title", Value: 1}, {Key: "_id", Value: 1}},
+	Options: options.Index().SetName("sort_title_id"),
+}
+
+// sortIndexes atendem às ordenações da listagem, sempre desempatadas por _id.
+var sortIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "year", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("year_id")},
+	titleSortIndex,
+	{Keys: bson.D{{Key: "runtime_minutes", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("runtime_minutes_id")},
+	{Keys: bson.D{{Key: "release_date", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("release_date_id")},
+}
+
+// movieSchema espelha domain.Movie. Os campos opcionais podem faltar, mas, se presentes, precisam ter o tipo certo.
+var movieSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"title", "year"},
+	"properties": bson.M{
+		"title":             bson.M{"bsonType": "string", "minLength": 1},
+		"year":              bson.M{"bsonType": bson.A{"int", "long"}},
+		"genres":            stringArraySchema,
+		"directors":         stringArraySchema,
+		"cast":              stringArraySchema,
+		"runtime_minutes":   bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 0},
+		"synopsis":          bson.M{"bsonType": "string"},
+		"original_language": bson.M{"bsonType": "string"},
+		"release_date":      bson.M{"bsonType": "date"},
+	},
+}
+
+var stringArraySchema = bson.M{"bsonType": "array", "items": bson.M{"bsonType": "string"}}
+
+// setValidator troca o validador da coleção, criando-a se ainda não existir. O nível "moderate" não
+// bloqueia atualizações de documentos antigos que já estavam fora do esquema.
+func setValidator(ctx context.Context, db *mongo.Database, collection string, validator bson.M) error {
+	if err := ensureCollection(ctx, db, collection); err != nil {
+		return err
+	}
+	return db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}).Err()
+}
+
+func ensureCollection(ctx context.Context, db *mongo.Database, collection string) error {
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": collection})
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		return nil
+	}
+	err = db.CreateCollection(ctx, collection)
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Name == "NamespaceExists" {
+		return nil
+	}
+	return err
+}
+
+// dropIndexes remove os índices pelo nome, ignorando os que já não existem.
+func dropIndexes(ctx context.Context, collection *mongo.Collection, indexes []mongo.IndexModel) error {
+	for _, index := range indexes {
+		_, err := collection.Indexes().DropOne(ctx, *index.Options.Name)
+		var commandErr mongo.CommandError
+		if err != nil && !(errors.As(err, &commandErr) && (commandErr.Name == "IndexNotFound" || commandErr.Name == "NamespaceNotFound")) {
+			return err
+		}
+	}
+	return nil
+}
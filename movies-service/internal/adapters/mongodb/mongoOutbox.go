@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// outboxCollection é a caixa de saída dos eventos, gravada na mesma transação da escrita no filme.
+const outboxCollection = "outbox"
+
+// outboxRetention é por quanto tempo um evento já publicado fica na coleção, para consulta, antes de o índice TTL removê-lo.
+const outboxRetention = 7 * 24 * time.Hour
+
+// outboxDocument é um evento na caixa de saída. NextAttemptAt só existe enquanto o evento está pendente;
+// SentAt, depois que ele foi publicado.
+type outboxDocument struct {
+	ID            string            `bson:"_id"`
+	Event         domain.MovieEvent `bson:"event"`
+	Attempts      int               `bson:"attempts"`
+	LastError     string            `bson:"last_error,omitempty"`
+	NextAttemptAt *time.Time        `bson:"next_attempt_at,omitempty"`
+	SentAt        *time.Time        `bson:"sent_at,omitempty"`
+}
+
+// mongoOutbox é a implementação de `ports.EventOutbox` sobre a coleção outbox.
+type mongoOutbox struct {
+	collection *mongo.Collection
+}
+
+// NewMongoOutbox é o construtor do mongoOutbox. Os índices são criados pelas migrações.
+func NewMongoOutbox(db *mongo.Database) ports.EventOutbox {
+	return &mongoOutbox{collection: db.Collection(outboxCollection)}
+}
+
+// Publish grava o evento como pendente. Com o contexto de ports.Transactor, a gravação entra na transação.
+func (o *mongoOutbox) Publish(ctx context.Context, event domain.MovieEvent) error {
+	next := event.OccurredAt
+	_, err := o.collection.InsertOne(ctx, outboxDocument{ID: event.ID, Event: event, NextAttemptAt: &next})
+	return err
+}
+
+// Claim reserva o evento com FindOneAndUpdate: a busca e o adiamento da próxima tentativa são uma só operação no
+// documento, então duas réplicas nunca reservam o mesmo evento ao mesmo tempo.
+func (o *mongoOutbox) Claim(ctx context.Context, now, leaseUntil time.Time) (*domain.OutboxEntry, error) {
+	var document outboxDocument
+	err := o.collection.FindOneAndUpdate(ctx,
+		bson.M{"next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_attempt_at": leaseUntil.UTC()}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}),
+	).Decode(&document)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OutboxEntry{Event: document.Event, Attempts: document.Attempts, LastError: document.LastError}, nil
+}
+
+func (o *mongoOutbox) MarkSent(ctx context.Context, eventID string, sentAt time.Time) error {
+	_, err := o.collection.UpdateOne(ctx,
+		bson.M{"_id": eventID},
+		bson.M{"$set": bson.M{"sent_at": sentAt.UTC()}, "$unset": bson.M{"next_attempt_at": "", "last_error": ""}},
+	)
+	return err
+}
+
+func (o *mongoOutbox) Reschedule(ctx context.Context, eventID string, attempts int, next time.Time, lastErr string) error {
+	_, err := o.collection.UpdateOne(ctx,
+		bson.M{"_id": eventID, "next_attempt_at": bson.M{"$exists": true}},
+		bson.M{"$set": bson.M{"attempts": attempts, "next_attempt_at": next.UTC(), "last_error": lastErr}},
+	)
+	return err
+}
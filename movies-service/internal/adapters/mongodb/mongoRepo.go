@@ -4,6 +4,7 @@
 		"context"
 		"errors"
 		"log"
+		"time"
 
 		"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 		"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
@@ -15,12 +16,33 @@
 
 	// Permitem que as camadas superiores (serviço, gRPC) possam tratar os erros de forma específica.
 	var (
-		ErrInvalidIDFormat = errors.New("Formato de ID de filme inválido")
-		ErrMovieNotFound   = errors.New("Filme não encontrado")
+		ErrInvalidIDFormat = domain.ErrInvalidIDFormat
+		ErrMovieNotFound   = domain.ErrMovieNotFound
 		ErrFetchingMovies  = errors.New("Erro ao buscar filmes")
 		ErrDecodingMovies  = errors.New("Erro ao decodificar filmes")
 	)
 
+	// duplicateKeyCode é o código de erro do MongoDB para violação de índice único.
+	const duplicateKeyCode = 11000
+
+	// notDeleted seleciona os filmes fora da lixeira.
+	var notDeleted = bson.M{"$exists": false}
+
+	// streamBatchSize é quantos documentos o cursor de Stream busca por vez no servidor.
+	const streamBatchSize = 500
+
+
+	// movieDocument é o formato gravado na coleção: o filme mais a chave de duplicidade (ver domain.TitleKey),
+	// que forma com o ano um índice único, e o título usado na ordenação (ver domain.SortTitle).
+	type movieDocument struct {
+		domain.Movie `bson:",inline"`
+		TitleKey     string `bson:"title_key"`
+		SortTitle    string `bson:"sort_title"`
+	}
+
+	func newMovieDocument(movie domain.Movie) movieDocument {
+		return movieDocument{Movie: movie, TitleKey: domain.TitleKey(movie.Title), SortTitle: domain.SortTitle(movie.Title)}
+	}
 
 	// mongoRepository é a implementação da interface `ports.MovieRepository`.
 	type mongoRepository struct {
@@ -41,7 +63,7 @@
 		}
 
 		var movie domain.Movie
-		err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&movie)
+		err = r.collection.FindOne(ctx, bson.M{"_id": objectID, "deleted_at": notDeleted}).Decode(&movie)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
 				return nil, ErrMovieNotFound
@@ -51,13 +73,53 @@
 		return &movie, nil
 	}
 
-	func (r *mongoRepository) GetAll(ctx context.Context, limit, offset int64) ([]domain.Movie, error) {
+	// GetAll lista os filmes na ordenação pedida, desempatando por _id. Com page token, a página continua
+	// depois do último filme entregue (paginação por chave), o que evita o custo do skip e não repete
+	// nem pula filmes quando o catálogo muda.
+	func (r *mongoRepository) GetAll(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) {
+		filter := buildFilter(query.Filter)
+		if query.Deleted {
+			filter["deleted_at"] = bson.M{"$exists": true}
+		} else {
+			filter["deleted_at"] = notDeleted
+		}
+
+		var totalCount *int64
+		if query.IncludeTotal {
+			count, err := r.collection.CountDocuments(ctx, filter)
+			if err != nil {
+				log.Printf("MongoDB CountDocuments error: %v", err)
+				return nil, ErrFetchingMovies
+			}
+			totalCount = &count
+		}
 
 		findOptions := options.Find()
-		findOptions.SetLimit(limit)
-		findOptions.SetSkip(offset)
+		findOptions.SetSort(sortSpec(query.OrderBy))
+		if query.Limit > 0 {
+			// Um item a mais indica se existe próxima página.
+			findOptions.SetLimit(query.Limit + 1)
+		}
 
-		cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+		if query.PageToken != "" {
+			cursor, err := domain.DecodePageToken(query.PageToken)
+			if err != nil {
+				return nil, err
+			}
+			values, err := query.OrderBy.CursorValues(cursor)
+			if err != nil {
+				return nil, err
+			}
+			lastID, err := primitive.ObjectIDFromHex(cursor.ID)
+			if err != nil {
+				return nil, domain.ErrInvalidPageToken
+			}
+			filter = bson.M{"$and": bson.A{filter, keysetFilter(query.OrderBy, values, lastID)}}
+		} else {
+			findOptions.SetSkip(query.Offset)
+		}
+
+		cursor, err := r.collection.Find(ctx, filter, findOptions)
 		if err != nil {
 			log.Printf("MongoDB Find error: %v", err)
 			return nil, ErrFetchingMovies
@@ -70,16 +132,25 @@
 			return nil, ErrDecodingMovies
 		}
 
-		if movies == nil {
-			return []domain.Movie{}, nil
+		page := &domain.MoviePage{Movies: movies, TotalCount: totalCount}
+		if query.Limit > 0 && int64(len(movies)) > query.Limit {
+			page.Movies = movies[:query.Limit]
+			page.NextPageToken = domain.EncodePageToken(query.OrderBy.CursorFor(page.Movies[query.Limit-1]))
+		}
+		if page.Movies == nil {
+			page.Movies = []domain.Movie{}
 		}
 
-		return movies, nil
+		return page, nil
 	}
 
 	func (r *mongoRepository) Save(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
 		if movie.ID == "" {
-			res, err := r.collection.InsertOne(ctx, movie)
+			movie.Version = 1
+			res, err := r.collection.InsertOne(ctx, newMovieDocument(movie))
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, domain.ErrMovieAlreadyExists
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -92,25 +163,351 @@
 			return nil, ErrInvalidIDFormat
 		}
 
-		_, err = r.collection.ReplaceOne(ctx, bson.M{"_id": objectID}, movie)
+		// O _id do documento é um ObjectID; o ID em string não pode ir no documento de substituição.
+		// A substituição só acontece se ninguém gravou o filme depois que ele foi lido (mesma versão).
+		replacement := movie
+		replacement.ID = ""
+		replacement.DeletedAt = nil
+		replacement.Version = movie.Version + 1
+		res, err := r.collection.ReplaceOne(ctx,
+			bson.M{"_id": objectID, "deleted_at": notDeleted, "version": movie.Version},
+			newMovieDocument(replacement),
+		)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrMovieAlreadyExists
+		}
 		if err != nil {
 			return nil, err
 		}
+		if res.MatchedCount == 0 {
+			exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID, "deleted_at": notDeleted})
+			if err != nil {
+				return nil, err
+			}
+			if exists > 0 {
+				return nil, domain.ErrVersionConflict
+			}
+			return nil, ErrMovieNotFound
+		}
+		movie.Version = replacement.Version
 		return &movie, nil
 	}
 
-	func (r *mongoRepository) Delete(ctx context.Context, id string) error {
+	func (r *mongoRepository) Delete(ctx context.Context, id string, expectedVersion int64) (*domain.Movie, error) {
 		objectID, err := primitive.ObjectIDFromHex(id)
 		if err != nil {
-			return ErrInvalidIDFormat
+			return nil, ErrInvalidIDFormat
 		}
 
-		res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+		// Com a versão esperada no filtro, uma escrita entre a leitura do cliente e a remoção faz a remoção não casar.
+		filter := bson.M{"_id": objectID, "deleted_at": notDeleted}
+		if expectedVersion > 0 {
+			filter["version"] = expectedVersion
+		}
+		// Sem title_key, o filme removido sai do índice único e não impede que o mesmo título e ano seja cadastrado de novo.
+		var movie domain.Movie
+		err = r.collection.FindOneAndUpdate(ctx,
+			filter,
+			bson.M{"$set": bson.M{"deleted_at": time.Now().UTC()}, "$unset": bson.M{"title_key": ""}, "$inc": bson.M{"version": 1}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&movie)
+		if err == mongo.ErrNoDocuments {
+			if expectedVersion > 0 {
+				exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID, "deleted_at": notDeleted})
+				if err != nil {
+					return nil, err
+				}
+				if exists > 0 {
+					return nil, domain.ErrVersionMismatch
+				}
+			}
+			return nil, ErrMovieNotFound
+		}
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if res.DeletedCount == 0 {
-			return ErrMovieNotFound
+		return &movie, nil
+	}
+
+	func (r *mongoRepository) Restore(ctx context.Context, id string) (*domain.Movie, error) {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, ErrInvalidIDFormat
+		}
+
+		var movie domain.Movie
+		err = r.collection.FindOne(ctx, bson.M{"_id": objectID, "deleted_at": bson.M{"$exists": true}}).Decode(&movie)
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrMovieNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": objectID, "deleted_at": bson.M{"$exists": true}},
+			bson.M{"$set": bson.M{"title_key": domain.TitleKey(movie.Title)}, "$unset": bson.M{"deleted_at": ""}, "$inc": bson.M{"version": 1}},
+		)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrMovieAlreadyExists
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.MatchedCount == 0 {
+			return nil, ErrMovieNotFound
+		}
+		movie.DeletedAt = nil
+		movie.Version++
+		return &movie, nil
+	}
+
+	// Purge lê os filmes a expurgar e remove exatamente esses. Numa transação (ver mongoTransactor), a leitura e a
+	// remoção veem o mesmo estado; fora dela, um filme restaurado entre as duas não é removido, mas é devolvido.
+	func (r *mongoRepository) Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.Movie, error) {
+		filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+		cursor, err := r.collection.Find(ctx, filter,
+			options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}}).SetLimit(int64(limit)))
+		if err != nil {
+			return nil, err
+		}
+		purged := []domain.Movie{}
+		if err := cursor.All(ctx, &purged); err != nil {
+			return nil, err
+		}
+		if len(purged) == 0 {
+			return purged, nil
+		}
+
+		ids := make([]primitive.ObjectID, 0, len(purged))
+		for _, movie := range purged {
+			objectID, err := primitive.ObjectIDFromHex(movie.ID)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, objectID)
+		}
+		if _, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "deleted_at": bson.M{"$lt": deletedBefore}}); err != nil {
+			return nil, err
+		}
+		return purged, nil
+	}
+
+	// GetMany devolve os filmes encontrados entre os IDs informados, em uma única consulta.
+	func (r *mongoRepository) GetMany(ctx context.Context, ids []string) ([]domain.Movie, error) {
+		objectIDs := make([]primitive.ObjectID, 0, len(ids))
+		for _, id := range ids {
+			if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+				objectIDs = append(objectIDs, objectID)
+			}
+		}
+		movies := []domain.Movie{}
+		if len(objectIDs) == 0 {
+			return movies, nil
+		}
+
+		cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}, "deleted_at": notDeleted})
+		if err != nil {
+			log.Printf("MongoDB Find error: %v", err)
+			return nil, ErrFetchingMovies
+		}
+		defer cursor.Close(ctx)
+
+		if err = cursor.All(ctx, &movies); err != nil {
+			log.Printf("MongoDB All error: %v", err)
+			return nil, ErrDecodingMovies
+		}
+		return movies, nil
+	}
+
+	// FindBySourceIDs busca os filmes pelo SourceID, inclusive os da lixeira, em uma única consulta. Cada lado do $or
+	// tem seu índice: o único de source_id, que só contém os filmes fora da lixeira, e o de deleted_at.
+	func (r *mongoRepository) FindBySourceIDs(ctx context.Context, sourceIDs []string) ([]domain.Movie, error) {
+		movies := []domain.Movie{}
+		if len(sourceIDs) == 0 {
+			return movies, nil
+		}
+
+		inSources := bson.M{"$in": sourceIDs}
+		cursor, err := r.collection.Find(ctx, bson.M{"$or": bson.A{
+			bson.M{"source_id": inSources, "deleted_at": nil},
+			bson.M{"source_id": inSources, "deleted_at": bson.M{"$exists": true}},
+		}})
+		if err != nil {
+			log.Printf("MongoDB Find error: %v", err)
+			return nil, ErrFetchingMovies
+		}
+		defer cursor.Close(ctx)
+
+		if err = cursor.All(ctx, &movies); err != nil {
+			log.Printf("MongoDB All error: %v", err)
+			return nil, ErrDecodingMovies
+		}
+		return movies, nil
+	}
+
+	// InsertMany grava novos filmes com um único InsertMany não ordenado: um filme repetido no título e ano, no
+	// SourceID ou em um id externo (inclusive dentro do próprio lote) recebe domain.ErrMovieAlreadyExists sem impedir
+	// a gravação dos demais.
+	func (r *mongoRepository) InsertMany(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+		results := make([]domain.BatchResult, len(movies))
+		if len(movies) == 0 {
+			return results, nil
+		}
+		docs := make([]interface{}, len(movies))
+		for i, movie := range movies {
+			movie.ID = ""
+			movie.Version = 1
+			docs[i] = newMovieDocument(movie)
+		}
+
+		// O driver gera os _id antes de enviar, então InsertedIDs traz o ID de cada documento, gravado ou não.
+		res, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+			for _, writeErr := range bulkErr.WriteErrors {
+				if writeErr.Code == duplicateKeyCode {
+					results[writeErr.Index].Err = domain.ErrMovieAlreadyExists
+				} else {
+					results[writeErr.Index].Err = writeErr
+				}
+			}
+		} else if err != nil {
+			return nil, err
+		}
+
+		for i, movie := range movies {
+			if results[i].Err != nil {
+				continue
+			}
+			movie.ID = res.InsertedIDs[i].(primitive.ObjectID).Hex()
+			movie.Version = 1
+			results[i].Movie = &movie
+		}
+		return results, nil
+	}
+
+	// LinkSources grava o SourceID e os ids externos de todos os filmes com um único BulkWrite. O BulkWrite só informa
+	// os erros por filme; quem não casou com o filtro (versão ou SourceID diferentes) é descoberto relendo o lote.
+	func (r *mongoRepository) LinkSources(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+		results := make([]domain.BatchResult, len(movies))
+		if len(movies) == 0 {
+			return results, nil
+		}
+		models := make([]mongo.WriteModel, len(movies))
+		ids := make([]string, len(movies))
+		for i, link := range movies {
+			objectID, err := primitive.ObjectIDFromHex(link.ID)
+			if err != nil {
+				return nil, ErrInvalidIDFormat
+			}
+			set := bson.M{"source_id": link.SourceID}
+			update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+			if len(link.ExternalIDs) > 0 {
+				set["external_ids"] = link.ExternalIDs
+			} else {
+				update["$unset"] = bson.M{"external_ids": ""}
+			}
+			models[i] = mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": objectID, "deleted_at": notDeleted, "source_id": bson.M{"$exists": false}, "version": link.Version}).
+				SetUpdate(update)
+			ids[i] = link.ID
+		}
+
+		_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+			for _, writeErr := range bulkErr.WriteErrors {
+				if writeErr.Code == duplicateKeyCode {
+					results[writeErr.Index].Err = domain.ErrMovieAlreadyExists
+				} else {
+					results[writeErr.Index].Err = writeErr
+				}
+			}
+		} else if err != nil {
+			return nil, err
+		}
+
+		current, err := r.GetMany(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]domain.Movie, len(current))
+		for _, movie := range current {
+			byID[movie.ID] = movie
+		}
+		for i, link := range movies {
+			if results[i].Err != nil {
+				continue
+			}
+			movie, ok := byID[link.ID]
+			if !ok || movie.SourceID != link.SourceID || movie.Version != link.Version+1 {
+				results[i].Err = domain.ErrVersionConflict
+				continue
+			}
+			results[i].Movie = &movie
+		}
+		return results, nil
+	}
+
+	// FindByTitle usa o índice único de title_key e ano, que só contém os filmes fora da lixeira.
+	func (r *mongoRepository) FindByTitle(ctx context.Context, title string, year int) (*domain.Movie, error) {
+		var movie domain.Movie
+		err := r.collection.FindOne(ctx, bson.M{"title_key": domain.TitleKey(title), "year": year, "deleted_at": notDeleted}).Decode(&movie)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, ErrMovieNotFound
+			}
+			return nil, err
+		}
+		return &movie, nil
+	}
+
+	// FindByExternalID usa o índice único do provedor (ver liveSourceIndexes). O filtro de deleted_at é o mesmo do
+	// índice parcial, para que a consulta possa usá-lo.
+	func (r *mongoRepository) FindByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error) {
+		var movie domain.Movie
+		err := r.collection.FindOne(ctx, bson.M{"external_ids." + provider: id, "deleted_at": nil}).Decode(&movie)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, ErrMovieNotFound
+			}
+			return nil, err
+		}
+		return &movie, nil
+	}
+
+	// Stream percorre o cursor do MongoDB documento a documento, com os mesmos filtros e a mesma ordenação de GetAll.
+	// Só um lote do cursor fica em memória por vez.
+	func (r *mongoRepository) Stream(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error {
+		filter := buildFilter(query.Filter)
+		if query.Deleted {
+			filter["deleted_at"] = bson.M{"$exists": true}
+		} else {
+			filter["deleted_at"] = notDeleted
+		}
+
+		cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sortSpec(query.OrderBy)).SetBatchSize(streamBatchSize))
+		if err != nil {
+			log.Printf("MongoDB Find error: %v", err)
+			return ErrFetchingMovies
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var movie domain.Movie
+			if err := cursor.Decode(&movie); err != nil {
+				log.Printf("MongoDB Decode error: %v", err)
+				return ErrDecodingMovies
+			}
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			log.Printf("MongoDB cursor error: %v", err)
+			return ErrFetchingMovies
 		}
 		return nil
-	}
\ No newline at end of file
+	}
+
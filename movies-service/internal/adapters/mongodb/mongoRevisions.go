@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// revisionsCollection guarda o histórico de alterações dos filmes. Fica separada de movies para que o histórico
+// sobreviva ao expurgo da lixeira.
+const revisionsCollection = "movie_revisions"
+
+// mongoRevisionStore é a implementação de `ports.MovieRevisionStore` sobre a coleção movie_revisions.
+type mongoRevisionStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRevisionStore é o construtor do mongoRevisionStore. O índice único por filme e versão é criado pelas migrações.
+func NewMongoRevisionStore(db *mongo.Database) ports.MovieRevisionStore {
+	return &mongoRevisionStore{collection: db.Collection(revisionsCollection)}
+}
+
+func (s *mongoRevisionStore) Append(ctx context.Context, revision domain.MovieRevision) error {
+	_, err := s.collection.InsertOne(ctx, revision)
+	return err
+}
+
+func (s *mongoRevisionStore) List(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error) {
+	if _, err := primitive.ObjectIDFromHex(movieID); err != nil {
+		return nil, ErrInvalidIDFormat
+	}
+
+	filter := bson.M{"movie_id": movieID}
+	if beforeVersion > 0 {
+		filter["version"] = bson.M{"$lt": beforeVersion}
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := make([]domain.MovieRevision, 0)
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (s *mongoRevisionStore) Get(ctx context.Context, movieID string, version int64) (*domain.MovieRevision, error) {
+	if _, err := primitive.ObjectIDFromHex(movieID); err != nil {
+		return nil, ErrInvalidIDFormat
+	}
+
+	var revision domain.MovieRevision
+	err := s.collection.FindOne(ctx, bson.M{"movie_id": movieID, "version": version}).Decode(&revision)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// textIndexName é o nome do índice de texto da coleção de filmes. O MongoDB aceita um único índice de texto por coleção.
+const textIndexName = "movies_text"
+
+// mongoSearcher é a implementação de `ports.MovieSearcher` sobre o índice de texto do MongoDB.
+type mongoSearcher struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSearcher é o construtor do mongoSearcher. O índice de texto é criado pelas migrações (ver Migrator).
+func NewMongoSearcher(db *mongo.Database) ports.MovieSearcher {
+	return &mongoSearcher{collection: db.Collection("movies")}
+}
+
+// ensureTextIndex cria o índice de texto. O título pesa mais que a sinopse, e o idioma "none"
+// desliga stemming e stop words, já que o catálogo mistura títulos em vários idiomas.
+func ensureTextIndex(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "synopsis", Value: "text"}},
+		Options: options.Index().
+			SetName(textIndexName).
+			SetWeights(bson.D{{Key: "title", Value: 10}, {Key: "synopsis", Value: 2}}).
+			SetDefaultLanguage("none"),
+	})
+	return err
+}
+
+func (s *mongoSearcher) Search(ctx context.Context, query string, limit int64) ([]domain.SearchResult, error) {
+	score := bson.M{"$meta": "textScore"}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := s.collection.Find(ctx, bson.M{"$text": bson.M{"$search": query}, "deleted_at": notDeleted}, findOptions)
+	if err != nil {
+		log.Printf("MongoDB text search error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		domain.Movie `bson:",inline"`
+		Score        float64 `bson:"score"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		log.Printf("MongoDB All error: %v", err)
+		return nil, ErrDecodingMovies
+	}
+
+	results := make([]domain.SearchResult, len(docs))
+	for i, doc := range docs {
+		results[i] = domain.SearchResult{Movie: doc.Movie, Score: doc.Score}
+	}
+	return results, nil
+}
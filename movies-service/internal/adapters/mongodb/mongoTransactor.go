@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrTransactionsUnsupported indica um MongoDB sem replica set, que não aceita transações.
+var ErrTransactionsUnsupported = errors.New("o MongoDB precisa ser um replica set (mongod --replSet) para usar transações")
+
+// mongoTransactor é a implementação de `ports.Transactor` com as sessões do driver. O contexto passado a fn
+// carrega a sessão, então toda operação feita com ele, em qualquer coleção, entra na transação.
+type mongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewMongoTransactor é o construtor do mongoTransactor. Confere se o servidor aceita transações, para que o erro
+// apareça na inicialização e não na primeira escrita.
+func NewMongoTransactor(ctx context.Context, db *mongo.Database) (ports.Transactor, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return nil, err
+	}
+	// setName só existe nos membros de um replica set; "isdbgrid" identifica o mongos de um cluster shardeado.
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return nil, ErrTransactionsUnsupported
+	}
+	return &mongoTransactor{client: db.Client()}, nil
+}
+
+// WithinTransaction usa Session.WithTransaction, que repete fn quando o servidor marca o erro como transitório
+// (ex.: conflito de escrita com outra transação).
+func (t *mongoTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
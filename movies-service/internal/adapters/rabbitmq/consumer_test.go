@@ -0,0 +1,146 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// movieWriterMock é o serviço de filmes visto pelo consumidor.
+type movieWriterMock struct {
+	mock.Mock
+}
+
+func (m *movieWriterMock) CreateMovie(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	args := m.Called(ctx, movie)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Movie), args.Error(1)
+}
+
+func (m *movieWriterMock) UpdateMovie(ctx context.Context, movie domain.Movie, fields []string) (*domain.Movie, error) {
+	args := m.Called(ctx, movie, fields)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Movie), args.Error(1)
+}
+
+func (m *movieWriterMock) DeleteMovie(ctx context.Context, id string, expectedVersion int64) error {
+	args := m.Called(ctx, id, expectedVersion)
+	return args.Error(0)
+}
+
+func (m *movieWriterMock) BulkCreateMovies(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, movies)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
+
+func newTestConsumer(writer MovieWriter) *Consumer {
+	return &Consumer{
+		service:       writer,
+		rkCreated:     "movie.created",
+		rkUpdated:     "movie.updated",
+		rkDeleted:     "movie.deleted",
+		rkBulkCreated: "movie.bulk_created",
+	}
+}
+
+func TestConsumer_Handle(t *testing.T) {
+	bacurau := domain.Movie{Title: "Bacurau", Year: 2019}
+	tests := []struct {
+		name      string
+		rk        string
+		body      string
+		setup     func(*movieWriterMock)
+		err       string
+		permanent bool
+	}{
+		{
+			name: "criação aplicada",
+			rk:   "movie.created",
+			body: `{"action":"create","actor":"ana","data":{"title":"Bacurau","year":2019}}`,
+			setup: func(m *movieWriterMock) {
+				m.On("CreateMovie", mock.MatchedBy(func(ctx context.Context) bool {
+					return domain.ActorFromContext(ctx) == "ana"
+				}), bacurau).Return(&bacurau, nil).Once()
+			},
+		},
+		{
+			name:      "envelope que não é JSON",
+			rk:        "movie.created",
+			body:      `{"action":`,
+			err:       "mensagem malformada",
+			permanent: true,
+		},
+		{
+			name:      "dados com tipo errado",
+			rk:        "movie.updated",
+			body:      `{"action":"update","data":{"id":"a","year":"dois mil"}}`,
+			err:       "mensagem malformada",
+			permanent: true,
+		},
+		{
+			name:      "data de lançamento inválida",
+			rk:        "movie.created",
+			body:      `{"data":{"title":"Bacurau","year":2019,"release_date":"ontem"}}`,
+			err:       domain.ErrInvalidReleaseDate.Error(),
+			permanent: true,
+		},
+		{
+			name: "filme repetido",
+			rk:   "movie.created",
+			body: `{"data":{"title":"Bacurau","year":2019}}`,
+			setup: func(m *movieWriterMock) {
+				m.On("CreateMovie", mock.Anything, bacurau).Return(nil, domain.ErrMovieAlreadyExists).Once()
+			},
+			err:       domain.ErrMovieAlreadyExists.Error(),
+			permanent: true,
+		},
+		{
+			name: "deleção condicionada à versão",
+			rk:   "movie.deleted",
+			body: `{"action":"delete","data":{"id":"a","expected_version":3}}`,
+			setup: func(m *movieWriterMock) {
+				m.On("DeleteMovie", mock.Anything, "a", int64(3)).Return(domain.ErrVersionMismatch).Once()
+			},
+			err:       domain.ErrVersionMismatch.Error(),
+			permanent: true,
+		},
+		{
+			name: "banco fora do ar",
+			rk:   "movie.created",
+			body: `{"data":{"title":"Bacurau","year":2019}}`,
+			setup: func(m *movieWriterMock) {
+				m.On("CreateMovie", mock.Anything, bacurau).Return(nil, errors.New("conexão recusada")).Once()
+			},
+			err: "conexão recusada",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := new(movieWriterMock)
+			if tt.setup != nil {
+				tt.setup(writer)
+			}
+
+			err := newTestConsumer(writer).handle(tt.rk, []byte(tt.body))
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Equal(t, tt.permanent, isPermanent(err))
+			}
+			writer.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sqldb
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"strconv"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// Dialect isola o que muda entre bancos SQL: o driver, a sintaxe dos parâmetros e as migrações.
+// Hoje só há SQLite; um dialeto PostgreSQL precisa apenas de outra implementação e do seu diretório de migrações.
+type Dialect interface {
+	// Name identifica o dialeto e o subdiretório de migrações.
+	Name() string
+	// DriverName é o nome registrado no database/sql.
+	DriverName() string
+	// Placeholder devolve o marcador do n-ésimo parâmetro da consulta (começando em 1).
+	Placeholder(n int) string
+	// Migrations devolve os arquivos .sql versionados do dialeto.
+	Migrations() (fs.FS, error)
+	// IsUniqueViolation indica se o erro é a violação de um índice único.
+	IsUniqueViolation(err error) bool
+}
+
+// SQLite é o dialeto do SQLite, usando o driver em Go puro modernc.org/sqlite (sem CGO).
+var SQLite Dialect = sqliteDialect{}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string       { return "sqlite" }
+func (sqliteDialect) DriverName() string { return "sqlite" }
+
+func (sqliteDialect) Placeholder(n int) string { return "?" + strconv.Itoa(n) }
+
+func (d sqliteDialect) Migrations() (fs.FS, error) {
+	return fs.Sub(migrationFiles, "migrations/"+d.Name())
+}
+
+func (sqliteDialect) IsUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
@@ -0,0 +1,19 @@
+-- Tabela principal do catálogo. Listas (gêneros, diretores, elenco) são gravadas como arrays JSON.
+CREATE TABLE movies (
+    id                TEXT PRIMARY KEY,
+    title             TEXT NOT NULL,
+    -- Título em minúsculas e sem acentos, usado no filtro por trecho do título.
+    title_folded      TEXT NOT NULL,
+    year              INTEGER NOT NULL,
+    genres            TEXT,
+    directors         TEXT,
+    cast_members      TEXT,
+    runtime_minutes   INTEGER,
+    synopsis          TEXT,
+    original_language TEXT,
+    -- Data no formato AAAA-MM-DD, que ordena corretamente como texto.
+    release_date      TEXT
+);
+
+CREATE INDEX idx_movies_year ON movies (year, id);
+CREATE INDEX idx_movies_title ON movies (title, id);
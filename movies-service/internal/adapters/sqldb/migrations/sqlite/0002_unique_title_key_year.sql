@@ -0,0 +1,10 @@
+-- Chave de duplicidade: título normalizado (sem acentos, minúsculo, espaços colapsados) mais o ano.
+-- O catálogo original já tem filmes diferentes com o mesmo título e ano; só o mais antigo de cada grupo
+-- recebe title_key, e o índice parcial ignora os demais.
+ALTER TABLE movies ADD COLUMN title_key TEXT;
+
+UPDATE movies
+SET title_key = trim(replace(replace(title_folded, '  ', ' '), '  ', ' '))
+WHERE id IN (SELECT MIN(id) FROM movies GROUP BY title_folded, year);
+
+CREATE UNIQUE INDEX movies_title_key_year ON movies (title_key, year) WHERE title_key IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Remoção lógica: filmes com deleted_at preenchido estão na lixeira até serem expurgados.
+-- O momento da remoção é gravado em UTC com largura fixa, para que a comparação de texto siga a ordem cronológica.
+ALTER TABLE movies ADD COLUMN deleted_at TEXT;
+
+CREATE INDEX movies_deleted_at ON movies (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Versão do filme para o controle de concorrência otimista: começa em 1 e é incrementada a cada escrita.
+ALTER TABLE movies ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
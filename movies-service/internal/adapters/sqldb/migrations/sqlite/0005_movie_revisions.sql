@@ -0,0 +1,14 @@
+-- Histórico de alterações: uma linha por escrita, identificada pelo filme e pela versão que a escrita produziu.
+-- Não há chave estrangeira para movies, para que o histórico sobreviva ao expurgo da lixeira.
+-- changes e snapshot são JSON (lista de domain.FieldChange e o filme como ficou).
+CREATE TABLE movie_revisions (
+    movie_id    TEXT    NOT NULL,
+    version     INTEGER NOT NULL,
+    action      TEXT    NOT NULL,
+    actor       TEXT    NOT NULL,
+    recorded_at TEXT    NOT NULL,
+    changes     TEXT    NOT NULL,
+    snapshot    TEXT    NOT NULL,
+    reverted_to INTEGER,
+    PRIMARY KEY (movie_id, version)
+);
@@ -0,0 +1,5 @@
+-- Id do filme no arquivo de seed, para que o arquivo possa ser carregado de novo sem duplicar filmes.
+-- O índice inclui os filmes da lixeira: o seed não recria um filme removido.
+ALTER TABLE movies ADD COLUMN source_id TEXT;
+
+CREATE UNIQUE INDEX movies_source_id ON movies (source_id) WHERE source_id IS NOT NULL;
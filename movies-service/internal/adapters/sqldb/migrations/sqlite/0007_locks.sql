@@ -0,0 +1,7 @@
+-- Travas compartilhadas entre as instâncias do serviço (ex.: o seed). Uma trava vencida pode ser tomada
+-- por outra instância; expires_at usa o mesmo formato de largura fixa de deleted_at.
+CREATE TABLE locks (
+    name       TEXT PRIMARY KEY,
+    owner      TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
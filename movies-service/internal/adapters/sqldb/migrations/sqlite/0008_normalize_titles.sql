@@ -0,0 +1,50 @@
+-- Títulos do seed trazem o ano no fim ("La sortie des usines Lumière (1895)"). O sufixo sai do título, e das
+-- colunas derivadas dele, quando confere com o ano do filme, como faz domain.Movie.NormalizeTitle.
+-- Um filme cuja chave sem o sufixo já é de outro filme fica sem title_key, como as demais duplicatas.
+UPDATE movies
+SET title        = rtrim(substr(title, 1, length(title) - 6)),
+    title_folded = rtrim(substr(title_folded, 1, length(title_folded) - 6)),
+    title_key    = CASE
+        WHEN title_key IS NULL THEN NULL
+        WHEN EXISTS (
+            SELECT 1 FROM movies other
+            WHERE other.title_key = rtrim(substr(movies.title_key, 1, length(movies.title_key) - 6))
+              AND other.year = movies.year
+        ) THEN NULL
+        ELSE rtrim(substr(title_key, 1, length(title_key) - 6))
+    END
+WHERE title GLOB '*([0-9][0-9][0-9][0-9])'
+  AND substr(title, -5, 4) = CAST(year AS TEXT)
+  AND rtrim(substr(title, 1, length(title) - 6)) <> '';
+
+-- Título usado na ordenação: o de title_key sem o artigo inicial (ver domain.SortTitle).
+ALTER TABLE movies ADD COLUMN sort_title TEXT;
+
+UPDATE movies SET sort_title = trim(replace(replace(title_folded, '  ', ' '), '  ', ' '));
+
+UPDATE movies
+SET sort_title = CASE
+    WHEN sort_title LIKE 'l''_%' THEN ltrim(substr(sort_title, 3))
+    WHEN sort_title LIKE 'the _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'uma _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'les _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'une _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'los _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'las _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'una _%' THEN substr(sort_title, 5)
+    WHEN sort_title LIKE 'an _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'os _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'um _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'la _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'le _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'un _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'el _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'il _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'lo _%' THEN substr(sort_title, 4)
+    WHEN sort_title LIKE 'a _%' THEN substr(sort_title, 3)
+    WHEN sort_title LIKE 'o _%' THEN substr(sort_title, 3)
+    ELSE sort_title
+END;
+
+DROP INDEX idx_movies_title;
+CREATE INDEX idx_movies_sort_title ON movies (sort_title, id);
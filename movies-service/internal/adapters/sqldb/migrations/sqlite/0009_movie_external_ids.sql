@@ -0,0 +1,18 @@
+-- Ids do filme em outros catálogos, gravados como um objeto JSON por provedor ({"imdb": "tt0000010"}).
+-- Cada provedor tem seu índice único, que inclui os filmes da lixeira, como o de source_id.
+ALTER TABLE movies ADD COLUMN external_ids TEXT;
+
+CREATE UNIQUE INDEX movies_external_imdb ON movies (json_extract(external_ids, '$.imdb'))
+    WHERE json_extract(external_ids, '$.imdb') IS NOT NULL;
+CREATE UNIQUE INDEX movies_external_tmdb ON movies (json_extract(external_ids, '$.tmdb'))
+    WHERE json_extract(external_ids, '$.tmdb') IS NOT NULL;
+CREATE UNIQUE INDEX movies_external_movielens ON movies (json_extract(external_ids, '$.movielens'))
+    WHERE json_extract(external_ids, '$.movielens') IS NOT NULL;
+
+-- O id de data/movies.json, gravado em source_id pelo seed, é o do título no IMDb sem o prefixo "tt".
+UPDATE movies
+SET external_ids = json_object('imdb', printf('tt%07d', CAST(source_id AS INTEGER)))
+WHERE external_ids IS NULL
+  AND source_id NOT GLOB '*[^0-9]*'
+  AND CAST(CAST(source_id AS INTEGER) AS TEXT) = source_id
+  AND CAST(source_id AS INTEGER) > 0;
@@ -0,0 +1,15 @@
+-- FindBySourceIDs e FindByExternalID não enxergam a lixeira, então os índices únicos também não a incluem:
+-- um filme removido não segura os seus ids, e restaurá-lo falha se outro filme os tomou nesse meio-tempo.
+DROP INDEX movies_source_id;
+DROP INDEX movies_external_imdb;
+DROP INDEX movies_external_tmdb;
+DROP INDEX movies_external_movielens;
+
+CREATE UNIQUE INDEX movies_source_id ON movies (source_id)
+    WHERE source_id IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX movies_external_imdb ON movies (json_extract(external_ids, '$.imdb'))
+    WHERE json_extract(external_ids, '$.imdb') IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX movies_external_tmdb ON movies (json_extract(external_ids, '$.tmdb'))
+    WHERE json_extract(external_ids, '$.tmdb') IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX movies_external_movielens ON movies (json_extract(external_ids, '$.movielens'))
+    WHERE json_extract(external_ids, '$.movielens') IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,47 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SQLLocker guarda as travas na tabela locks. Implementa `ports.Locker`.
+type SQLLocker struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewSQLLocker é o construtor do SQLLocker. Espera um banco já migrado (ver Open).
+func NewSQLLocker(db *sql.DB, dialect Dialect) *SQLLocker {
+	return &SQLLocker{db: db, dialect: dialect}
+}
+
+// TryLock grava a trava em um único comando: cria a linha ou, se ela já existe, só a toma quando está vencida.
+func (l *SQLLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(ctx context.Context) error, error) {
+	owner := primitive.NewObjectID().Hex()
+	now := time.Now().UTC()
+	statement := fmt.Sprintf(`INSERT INTO locks (name, owner, expires_at) VALUES (%s, %s, %s)
+		ON CONFLICT (name) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at
+		WHERE locks.expires_at <= %s`,
+		l.dialect.Placeholder(1), l.dialect.Placeholder(2), l.dialect.Placeholder(3), l.dialect.Placeholder(4))
+	res, err := l.db.ExecContext(ctx, statement, name, owner, now.Add(ttl).Format(deletedAtLayout), now.Format(deletedAtLayout))
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, domain.ErrLockHeld
+	}
+
+	unlock := func(ctx context.Context) error {
+		_, err := l.db.ExecContext(ctx, "DELETE FROM locks WHERE name = "+l.dialect.Placeholder(1)+" AND owner = "+l.dialect.Placeholder(2), name, owner)
+		return err
+	}
+	return unlock, nil
+}
@@ -0,0 +1,721 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Erros próprios do adaptador SQL. Os erros de domínio (ID inválido, filme não encontrado) são os mesmos dos outros adaptadores.
+var (
+	ErrFetchingMovies = errors.New("Erro ao buscar filmes")
+	ErrDecodingMovies = errors.New("Erro ao decodificar filmes")
+)
+
+const movieColumns = "id, title, year, genres, directors, cast_members, runtime_minutes, synopsis, original_language, release_date, deleted_at, version, source_id, external_ids"
+
+// deletedAtLayout grava o momento da remoção em UTC com largura fixa, comparável como texto.
+const deletedAtLayout = "2006-01-02T15:04:05.000000Z"
+
+// sortColumns mapeia os campos ordenáveis do domínio para as colunas da tabela.
+var sortColumns = map[string]string{
+	"title":           "sort_title",
+	"year":            "year",
+	"runtime_minutes": "runtime_minutes",
+	"release_date":    "release_date",
+}
+
+// SQLRepository é a implementação de `ports.MovieRepository` sobre um banco SQL.
+// Os IDs continuam no formato ObjectID (24 caracteres hexadecimais), então gRPC e REST não percebem a troca de banco.
+type SQLRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// Open conecta ao banco, confere a conexão e aplica as migrações pendentes.
+func Open(ctx context.Context, dialect Dialect, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(dialect.DriverName(), dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := Migrate(ctx, db, dialect); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// NewSQLRepository é o construtor do SQLRepository. Espera um banco já migrado (ver Open).
+func NewSQLRepository(db *sql.DB, dialect Dialect) *SQLRepository {
+	return &SQLRepository{db: db, dialect: dialect}
+}
+
+func (r *SQLRepository) Get(ctx context.Context, id string) (*domain.Movie, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+movieColumns+" FROM movies WHERE id = "+r.dialect.Placeholder(1)+" AND deleted_at IS NULL", id)
+	movie, err := scanMovie(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrMovieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+// GetAll segue a mesma semântica do adaptador MongoDB: filtros no banco, ordenação com desempate
+// por id e paginação por chave quando há page token.
+func (r *SQLRepository) GetAll(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) {
+	page := &domain.MoviePage{Movies: []domain.Movie{}}
+
+	if query.IncludeTotal {
+		b := r.newBuilder()
+		conditions := b.filterConditions(query.Filter, query.Deleted)
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM movies"+where(conditions), b.args...).Scan(&total); err != nil {
+			log.Printf("SQL count error: %v", err)
+			return nil, ErrFetchingMovies
+		}
+		page.TotalCount = &total
+	}
+
+	b := r.newBuilder()
+	conditions := b.filterConditions(query.Filter, query.Deleted)
+	if query.PageToken != "" {
+		cursor, err := domain.DecodePageToken(query.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		values, err := query.OrderBy.CursorValues(cursor)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, b.keysetCondition(query.OrderBy, values, cursor.ID))
+	}
+
+	statement := "SELECT " + movieColumns + " FROM movies" + where(conditions) + orderBy(query.OrderBy)
+	if query.Limit > 0 {
+		// Um item a mais indica se existe próxima página.
+		statement += " LIMIT " + b.arg(query.Limit+1)
+	}
+	if query.PageToken == "" && query.Offset > 0 {
+		if query.Limit <= 0 {
+			statement += " LIMIT -1"
+		}
+		statement += " OFFSET " + b.arg(query.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, statement, b.args...)
+	if err != nil {
+		log.Printf("SQL query error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			log.Printf("SQL scan error: %v", err)
+			return nil, ErrDecodingMovies
+		}
+		page.Movies = append(page.Movies, *movie)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("SQL rows error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+
+	if query.Limit > 0 && int64(len(page.Movies)) > query.Limit {
+		page.Movies = page.Movies[:query.Limit]
+		page.NextPageToken = domain.EncodePageToken(query.OrderBy.CursorFor(page.Movies[query.Limit-1]))
+	}
+	return page, nil
+}
+
+func (r *SQLRepository) Save(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	if movie.ID == "" {
+		movie.ID = primitive.NewObjectID().Hex()
+		movie.Version = 1
+		if _, err := r.insert(ctx, r.db, movie, false); err != nil {
+			return nil, r.writeError(err)
+		}
+		return &movie, nil
+	}
+
+	if _, err := primitive.ObjectIDFromHex(movie.ID); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	b := r.newBuilder()
+	values := movieValues(movie)
+	statement := fmt.Sprintf(`UPDATE movies SET title = %s, title_folded = %s, title_key = %s, sort_title = %s, year = %s, genres = %s,
+		directors = %s, cast_members = %s, runtime_minutes = %s, synopsis = %s, original_language = %s, release_date = %s,
+		source_id = %s, external_ids = %s, version = version + 1
+		WHERE id = %s AND deleted_at IS NULL AND version = %s`,
+		b.arg(values[1]), b.arg(domain.FoldText(movie.Title)), b.arg(domain.TitleKey(movie.Title)), b.arg(domain.SortTitle(movie.Title)), b.arg(values[2]),
+		b.arg(values[3]), b.arg(values[4]), b.arg(values[5]), b.arg(values[6]), b.arg(values[7]), b.arg(values[8]),
+		b.arg(values[9]), b.arg(values[12]), b.arg(values[13]), b.arg(movie.ID), b.arg(movie.Version))
+	res, err := r.db.ExecContext(ctx, statement, b.args...)
+	if err != nil {
+		return nil, r.writeError(err)
+	}
+	if affected, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		// Nada foi gravado: ou o filme não existe (ou está na lixeira), ou outra escrita mudou a versão.
+		var exists int
+		err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM movies WHERE id = "+r.dialect.Placeholder(1)+" AND deleted_at IS NULL", movie.ID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if exists > 0 {
+			return nil, domain.ErrVersionConflict
+		}
+		return nil, domain.ErrMovieNotFound
+	}
+	movie.Version++
+	return &movie, nil
+}
+
+// GetMany devolve os filmes encontrados entre os IDs informados, em uma única consulta.
+func (r *SQLRepository) GetMany(ctx context.Context, ids []string) ([]domain.Movie, error) {
+	b := r.newBuilder()
+	placeholders := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := primitive.ObjectIDFromHex(id); err == nil {
+			placeholders = append(placeholders, b.arg(id))
+		}
+	}
+	movies := []domain.Movie{}
+	if len(placeholders) == 0 {
+		return movies, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+movieColumns+" FROM movies WHERE id IN ("+strings.Join(placeholders, ", ")+") AND deleted_at IS NULL", b.args...)
+	if err != nil {
+		log.Printf("SQL query error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			log.Printf("SQL scan error: %v", err)
+			return nil, ErrDecodingMovies
+		}
+		movies = append(movies, *movie)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("SQL rows error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+	return movies, nil
+}
+
+// InsertMany grava novos filmes em uma única transação, gerando seus IDs. Os que já existem com o mesmo
+// título normalizado e ano, ou o mesmo SourceID (inclusive dentro do próprio lote), não são gravados e recebem
+// domain.ErrMovieAlreadyExists.
+func (r *SQLRepository) InsertMany(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results := make([]domain.BatchResult, len(movies))
+	for i, movie := range movies {
+		movie.ID = primitive.NewObjectID().Hex()
+		movie.Version = 1
+		res, err := r.insert(ctx, tx, movie, true)
+		if err != nil {
+			return nil, err
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if affected == 0 {
+			results[i].Err = domain.ErrMovieAlreadyExists
+			continue
+		}
+		results[i].Movie = &movie
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// LinkSources grava o SourceID e os ids externos de todos os filmes em uma única transação, um UPDATE por filme.
+// No SQLite, a violação de um índice único desfaz só o UPDATE que a causou, e os demais seguem.
+func (r *SQLRepository) LinkSources(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	statement := fmt.Sprintf(`UPDATE movies SET source_id = %s, external_ids = %s, version = version + 1
+		WHERE id = %s AND deleted_at IS NULL AND source_id IS NULL AND version = %s RETURNING %s`,
+		r.dialect.Placeholder(1), r.dialect.Placeholder(2), r.dialect.Placeholder(3), r.dialect.Placeholder(4), movieColumns)
+	results := make([]domain.BatchResult, len(movies))
+	for i, link := range movies {
+		movie, err := scanMovie(tx.QueryRowContext(ctx, statement, link.SourceID, nullJSONMap(link.ExternalIDs), link.ID, link.Version))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			results[i].Err = domain.ErrVersionConflict
+		case r.dialect.IsUniqueViolation(err):
+			results[i].Err = domain.ErrMovieAlreadyExists
+		case err != nil:
+			return nil, err
+		default:
+			results[i].Movie = movie
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindByTitle consulta o índice único de title_key e ano, que só contém os filmes fora da lixeira.
+func (r *SQLRepository) FindByTitle(ctx context.Context, title string, year int) (*domain.Movie, error) {
+	statement := "SELECT " + movieColumns + " FROM movies WHERE title_key = " + r.dialect.Placeholder(1) + " AND year = " + r.dialect.Placeholder(2)
+	movie, err := scanMovie(r.db.QueryRowContext(ctx, statement, domain.TitleKey(title), year))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrMovieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+// FindBySourceIDs busca os filmes pelo SourceID, inclusive os da lixeira, em uma única consulta. Cada parte do
+// UNION tem seu índice: o único de source_id, que só contém os filmes fora da lixeira, e o de deleted_at.
+func (r *SQLRepository) FindBySourceIDs(ctx context.Context, sourceIDs []string) ([]domain.Movie, error) {
+	movies := []domain.Movie{}
+	if len(sourceIDs) == 0 {
+		return movies, nil
+	}
+	b := r.newBuilder()
+	inSources := func() string {
+		placeholders := make([]string, len(sourceIDs))
+		for i, sourceID := range sourceIDs {
+			placeholders[i] = b.arg(sourceID)
+		}
+		return "source_id IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	statement := "SELECT " + movieColumns + " FROM movies WHERE " + inSources() + " AND deleted_at IS NULL" +
+		" UNION ALL SELECT " + movieColumns + " FROM movies WHERE " + inSources() + " AND deleted_at IS NOT NULL"
+	rows, err := r.db.QueryContext(ctx, statement, b.args...)
+	if err != nil {
+		log.Printf("SQL query error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			log.Printf("SQL scan error: %v", err)
+			return nil, ErrDecodingMovies
+		}
+		movies = append(movies, *movie)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("SQL rows error: %v", err)
+		return nil, ErrFetchingMovies
+	}
+	return movies, nil
+}
+
+// FindByExternalID consulta o índice único do provedor, com a mesma expressão usada na migração que o criou.
+func (r *SQLRepository) FindByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error) {
+	if !slices.Contains(domain.ExternalIDProviders, provider) {
+		return nil, domain.ErrMovieNotFound
+	}
+	statement := "SELECT " + movieColumns + " FROM movies WHERE json_extract(external_ids, '$." + provider + "') = " +
+		r.dialect.Placeholder(1) + " AND deleted_at IS NULL"
+	movie, err := scanMovie(r.db.QueryRowContext(ctx, statement, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrMovieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+// Stream lê os filmes linha a linha, com os mesmos filtros e a mesma ordenação de GetAll.
+func (r *SQLRepository) Stream(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error {
+	b := r.newBuilder()
+	conditions := b.filterConditions(query.Filter, query.Deleted)
+	rows, err := r.db.QueryContext(ctx, "SELECT "+movieColumns+" FROM movies"+where(conditions)+orderBy(query.OrderBy), b.args...)
+	if err != nil {
+		log.Printf("SQL query error: %v", err)
+		return ErrFetchingMovies
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			log.Printf("SQL scan error: %v", err)
+			return ErrDecodingMovies
+		}
+		if err := fn(*movie); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("SQL rows error: %v", err)
+		return ErrFetchingMovies
+	}
+	return nil
+}
+
+func (r *SQLRepository) Delete(ctx context.Context, id string, expectedVersion int64) (*domain.Movie, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	// Sem title_key, o filme removido sai do índice único e não impede que o mesmo título e ano seja cadastrado de novo.
+	b := r.newBuilder()
+	statement := fmt.Sprintf("UPDATE movies SET deleted_at = %s, title_key = NULL, version = version + 1 WHERE id = %s AND deleted_at IS NULL",
+		b.arg(time.Now().UTC().Format(deletedAtLayout)), b.arg(id))
+	if expectedVersion > 0 {
+		statement += " AND version = " + b.arg(expectedVersion)
+	}
+	movie, err := scanMovie(r.db.QueryRowContext(ctx, statement+" RETURNING "+movieColumns, b.args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		if expectedVersion > 0 {
+			var exists int
+			err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM movies WHERE id = "+r.dialect.Placeholder(1)+" AND deleted_at IS NULL", id).Scan(&exists)
+			if err != nil {
+				return nil, err
+			}
+			if exists > 0 {
+				return nil, domain.ErrVersionMismatch
+			}
+		}
+		return nil, domain.ErrMovieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+func (r *SQLRepository) Restore(ctx context.Context, id string) (*domain.Movie, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+movieColumns+" FROM movies WHERE id = "+r.dialect.Placeholder(1)+" AND deleted_at IS NOT NULL", id)
+	movie, err := scanMovie(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrMovieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statement := fmt.Sprintf("UPDATE movies SET deleted_at = NULL, title_key = %s, version = version + 1 WHERE id = %s AND deleted_at IS NOT NULL",
+		r.dialect.Placeholder(1), r.dialect.Placeholder(2))
+	res, err := r.db.ExecContext(ctx, statement, domain.TitleKey(movie.Title), id)
+	if err != nil {
+		return nil, r.writeError(err)
+	}
+	if affected, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, domain.ErrMovieNotFound
+	}
+	movie.DeletedAt = nil
+	movie.Version++
+	return movie, nil
+}
+
+// Purge devolve os filmes removidos com o RETURNING do próprio DELETE.
+func (r *SQLRepository) Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.Movie, error) {
+	b := r.newBuilder()
+	query := "DELETE FROM movies WHERE id IN (SELECT id FROM movies WHERE deleted_at < " + b.arg(deletedBefore.UTC().Format(deletedAtLayout)) +
+		" ORDER BY deleted_at LIMIT " + b.arg(limit) + ") RETURNING " + movieColumns
+	rows, err := r.db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	purged := []domain.Movie{}
+	for rows.Next() {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			return nil, err
+		}
+		purged = append(purged, *movie)
+	}
+	return purged, rows.Err()
+}
+
+// execer é a parte comum de *sql.DB e *sql.Tx usada nas escritas.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insert grava um filme novo. Com skipDuplicates, um filme repetido é ignorado em vez de gerar erro.
+func (r *SQLRepository) insert(ctx context.Context, db execer, movie domain.Movie, skipDuplicates bool) (sql.Result, error) {
+	b := r.newBuilder()
+	placeholders := make([]string, 0, 17)
+	for _, value := range movieValues(movie) {
+		placeholders = append(placeholders, b.arg(value))
+	}
+	placeholders = append(placeholders, b.arg(domain.FoldText(movie.Title)), b.arg(domain.TitleKey(movie.Title)), b.arg(domain.SortTitle(movie.Title)))
+
+	statement := "INSERT INTO movies (" + movieColumns + ", title_folded, title_key, sort_title) VALUES (" + strings.Join(placeholders, ", ") + ")"
+	if skipDuplicates {
+		statement += " ON CONFLICT DO NOTHING"
+	}
+	return db.ExecContext(ctx, statement, b.args...)
+}
+
+// writeError traduz a violação dos índices únicos (título e ano, SourceID, ids externos) para o erro de domínio.
+func (r *SQLRepository) writeError(err error) error {
+	if r.dialect.IsUniqueViolation(err) {
+		return domain.ErrMovieAlreadyExists
+	}
+	return err
+}
+
+// movieValues devolve os valores das colunas de movieColumns, na mesma ordem.
+// Campos opcionais vazios viram NULL, para ordenarem como ausentes, assim como no MongoDB.
+func movieValues(movie domain.Movie) []any {
+	return []any{
+		movie.ID,
+		movie.Title,
+		movie.Year,
+		nullJSON(movie.Genres),
+		nullJSON(movie.Directors),
+		nullJSON(movie.Cast),
+		nullInt(movie.RuntimeMinutes),
+		nullString(movie.Synopsis),
+		nullString(movie.OriginalLanguage),
+		nullDate(movie.ReleaseDate),
+		nullTimestamp(movie.DeletedAt),
+		movie.Version,
+		nullString(movie.SourceID),
+		nullJSONMap(movie.ExternalIDs),
+	}
+}
+
+// scanner é a parte comum de *sql.Row e *sql.Rows usada na leitura.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMovie(row scanner) (*domain.Movie, error) {
+	var (
+		movie                                  domain.Movie
+		genres, directors, cast                sql.NullString
+		synopsis, originalLanguage, releaseDay sql.NullString
+		runtime                                sql.NullInt64
+		deletedAt, sourceID, externalIDs       sql.NullString
+	)
+	if err := row.Scan(&movie.ID, &movie.Title, &movie.Year, &genres, &directors, &cast,
+		&runtime, &synopsis, &originalLanguage, &releaseDay, &deletedAt, &movie.Version, &sourceID, &externalIDs); err != nil {
+		return nil, err
+	}
+
+	for _, list := range []struct {
+		raw    sql.NullString
+		target *[]string
+	}{{genres, &movie.Genres}, {directors, &movie.Directors}, {cast, &movie.Cast}} {
+		if list.raw.Valid {
+			if err := json.Unmarshal([]byte(list.raw.String), list.target); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if externalIDs.Valid {
+		if err := json.Unmarshal([]byte(externalIDs.String), &movie.ExternalIDs); err != nil {
+			return nil, err
+		}
+	}
+	movie.RuntimeMinutes = int(runtime.Int64)
+	movie.Synopsis = synopsis.String
+	movie.OriginalLanguage = originalLanguage.String
+	movie.SourceID = sourceID.String
+	if releaseDay.Valid {
+		releaseDate, err := time.Parse(domain.ReleaseDateLayout, releaseDay.String)
+		if err != nil {
+			return nil, err
+		}
+		movie.ReleaseDate = &releaseDate
+	}
+	if deletedAt.Valid {
+		removedAt, err := time.Parse(deletedAtLayout, deletedAt.String)
+		if err != nil {
+			return nil, err
+		}
+		movie.DeletedAt = &removedAt
+	}
+	return &movie, nil
+}
+
+// queryBuilder acumula os argumentos de uma consulta e devolve o placeholder de cada um.
+type queryBuilder struct {
+	dialect Dialect
+	args    []any
+}
+
+func (r *SQLRepository) newBuilder() *queryBuilder {
+	return &queryBuilder{dialect: r.dialect}
+}
+
+func (b *queryBuilder) arg(value any) string {
+	b.args = append(b.args, value)
+	return b.dialect.Placeholder(len(b.args))
+}
+
+// filterConditions traduz o filtro do domínio para condições SQL, no catálogo ou na lixeira.
+func (b *queryBuilder) filterConditions(f domain.MovieFilter, deleted bool) []string {
+	conditions := []string{"deleted_at IS NULL"}
+	if deleted {
+		conditions = []string{"deleted_at IS NOT NULL"}
+	}
+	if title := strings.TrimSpace(f.Title); title != "" {
+		pattern := "%" + escapeLike(domain.FoldText(title)) + "%"
+		conditions = append(conditions, "title_folded LIKE "+b.arg(pattern)+` ESCAPE '\'`)
+	}
+	from, to := f.YearBounds()
+	if from > 0 {
+		conditions = append(conditions, "year >= "+b.arg(from))
+	}
+	if to > 0 {
+		conditions = append(conditions, "year <= "+b.arg(to))
+	}
+	return conditions
+}
+
+// keysetCondition seleciona as linhas que vêm depois do cursor, com a mesma lógica do adaptador MongoDB:
+// (c1 depois de v1) OU (c1 = v1 E c2 depois de v2) OU ... OU (todas iguais E id > último id).
+// NULL ordena antes de qualquer valor na ordem crescente e depois na decrescente (ver orderBy).
+func (b *queryBuilder) keysetCondition(order domain.SortOrder, values []any, lastID string) string {
+	var clauses, equal []string
+	for i, field := range order {
+		column := sortColumns[field.Field]
+		value := sqlSortValue(values[i])
+		var after string
+		switch {
+		case !field.Desc && value == nil:
+			after = column + " IS NOT NULL"
+		case !field.Desc:
+			after = column + " > " + b.arg(value)
+		case value != nil:
+			after = "(" + column + " < " + b.arg(value) + " OR " + column + " IS NULL)"
+		}
+		if after != "" {
+			clauses = append(clauses, "("+strings.Join(append(append([]string{}, equal...), after), " AND ")+")")
+		}
+		if value == nil {
+			equal = append(equal, column+" IS NULL")
+		} else {
+			equal = append(equal, column+" = "+b.arg(value))
+		}
+	}
+	clauses = append(clauses, "("+strings.Join(append(equal, "id > "+b.arg(lastID)), " AND ")+")")
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
+func orderBy(order domain.SortOrder) string {
+	parts := make([]string, 0, len(order)+1)
+	for _, field := range order {
+		if field.Desc {
+			parts = append(parts, sortColumns[field.Field]+" DESC NULLS LAST")
+		} else {
+			parts = append(parts, sortColumns[field.Field]+" ASC NULLS FIRST")
+		}
+	}
+	parts = append(parts, "id ASC")
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// sqlSortValue converte um valor de cursor para o formato gravado na coluna.
+func sqlSortValue(value any) any {
+	if date, ok := value.(time.Time); ok {
+		return date.Format(domain.ReleaseDateLayout)
+	}
+	return value
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func nullJSON(list []string) any {
+	if len(list) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(list)
+	return string(raw)
+}
+
+func nullJSONMap(values map[string]string) any {
+	if len(values) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(values)
+	return string(raw)
+}
+
+func nullInt(value int) any {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
+func nullString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func nullDate(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+	return value.Format(domain.ReleaseDateLayout)
+}
+
+func nullTimestamp(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+	return value.UTC().Format(deletedAtLayout)
+}
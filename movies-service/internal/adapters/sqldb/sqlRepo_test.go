@@ -0,0 +1,609 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestRepository(t *testing.T) *SQLRepository {
+	t.Helper()
+	db, err := Open(context.Background(), SQLite, "file:"+filepath.Join(t.TempDir(), "movies.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewSQLRepository(db, SQLite)
+}
+
+func insertMovies(t *testing.T, repo *SQLRepository, movies ...domain.Movie) {
+	t.Helper()
+	_, err := repo.InsertMany(context.Background(), movies)
+	require.NoError(t, err)
+}
+
+func titles(movies []domain.Movie) []string {
+	result := make([]string, 0, len(movies))
+	for _, movie := range movies {
+		result = append(result, movie.Title)
+	}
+	return result
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "movies.db")
+
+	db, err := Open(ctx, SQLite, dsn)
+	require.NoError(t, err)
+	require.NoError(t, Migrate(ctx, db, SQLite))
+
+	var applied int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+	migrations, err := loadMigrations(SQLite)
+	require.NoError(t, err)
+	assert.Equal(t, len(migrations), applied)
+	require.NoError(t, db.Close())
+
+	db, err = Open(ctx, SQLite, dsn)
+	require.NoError(t, err)
+	defer db.Close()
+}
+
+func TestMigrate_NormalizeTitles(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(SQLite.DriverName(), "file:"+filepath.Join(t.TempDir(), "movies.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	migrations, err := loadMigrations(SQLite)
+	require.NoError(t, err)
+
+	// Aplica as migrações anteriores à normalização e grava filmes como o seed antigo gravava.
+	_, err = db.ExecContext(ctx, `CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL, applied_at TEXT NOT NULL)`)
+	require.NoError(t, err)
+	var pending []migration
+	for _, m := range migrations {
+		if m.version < 8 {
+			require.NoError(t, applyMigration(ctx, db, SQLite, m))
+		} else {
+			pending = append(pending, m)
+		}
+	}
+	legacy := []struct {
+		id, title string
+		year      int
+	}{
+		{"1", "La sortie des usines Lumière (1895)", 1895},
+		{"2", "The Arrival of a Train (1896)", 1896},
+		{"3", "Le manoir du diable (1897)", 1896},
+		{"4", "Hamlet", 1921},
+		{"5", "Hamlet (1921)", 1921},
+	}
+	for _, movie := range legacy {
+		_, err := db.ExecContext(ctx, `INSERT INTO movies (id, title, title_folded, title_key, year) VALUES (?, ?, ?, ?, ?)`,
+			movie.id, movie.title, domain.FoldText(movie.title), domain.TitleKey(movie.title), movie.year)
+		require.NoError(t, err)
+	}
+	for _, m := range pending {
+		require.NoError(t, applyMigration(ctx, db, SQLite, m))
+	}
+
+	expected := []struct {
+		id, title string
+		key       sql.NullString
+		sortTitle string
+	}{
+		{"1", "La sortie des usines Lumière", sql.NullString{String: "la sortie des usines lumiere", Valid: true}, "sortie des usines lumiere"},
+		{"2", "The Arrival of a Train", sql.NullString{String: "the arrival of a train", Valid: true}, "arrival of a train"},
+		// O ano do título não confere com o do filme, então o sufixo fica.
+		{"3", "Le manoir du diable (1897)", sql.NullString{String: "le manoir du diable (1897)", Valid: true}, "manoir du diable (1897)"},
+		{"4", "Hamlet", sql.NullString{String: "hamlet", Valid: true}, "hamlet"},
+		// Sem o sufixo, a chave seria a do filme 4: fica sem title_key, como as demais duplicatas.
+		{"5", "Hamlet", sql.NullString{}, "hamlet"},
+	}
+	for _, want := range expected {
+		var title, sortTitle string
+		var key sql.NullString
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT title, title_key, sort_title FROM movies WHERE id = ?`, want.id).
+			Scan(&title, &key, &sortTitle))
+		assert.Equal(t, want.title, title, want.id)
+		assert.Equal(t, want.key, key, want.id)
+		assert.Equal(t, want.sortTitle, sortTitle, want.id)
+		assert.Equal(t, domain.SortTitle(title), sortTitle, want.id)
+	}
+}
+
+func TestSQLRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	release := time.Date(2019, 8, 29, 0, 0, 0, 0, time.UTC)
+	saved, err := repo.Save(ctx, domain.Movie{
+		Title:       "Bacurau",
+		Year:        2019,
+		Genres:      []string{"Drama", "Faroeste"},
+		Directors:   []string{"Kleber Mendonça Filho", "Juliano Dornelles"},
+		ReleaseDate: &release,
+	})
+	require.NoError(t, err)
+	assert.Len(t, saved.ID, 24)
+
+	found, err := repo.Get(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, found)
+
+	found.Synopsis = "Um povoado some do mapa."
+	found.Genres = nil
+	_, err = repo.Save(ctx, *found)
+	require.NoError(t, err)
+	updated, err := repo.Get(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Um povoado some do mapa.", updated.Synopsis)
+	assert.Empty(t, updated.Genres)
+
+	_, err = repo.Get(ctx, "id-invalido")
+	assert.ErrorIs(t, err, domain.ErrInvalidIDFormat)
+
+	_, err = repo.Save(ctx, domain.Movie{ID: "64b7f0c2a1b2c3d4e5f60718", Title: "Inexistente"})
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	// saved é a versão anterior à atualização: a remoção condicionada a ela não acontece.
+	_, err = repo.Delete(ctx, saved.ID, saved.Version)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	deleted, err := repo.Delete(ctx, saved.ID, updated.Version)
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+	assert.Equal(t, saved.Title, deleted.Title)
+	assert.Equal(t, updated.Version+1, deleted.Version)
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Get(ctx, saved.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
+func TestSQLRepository_GetAllPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+	insertMovies(t, repo,
+		domain.Movie{Title: "Le manoir du diable", Year: 1896},
+		domain.Movie{Title: "La sortie des usines Lumière", Year: 1895},
+		domain.Movie{Title: "The Arrival of a Train", Year: 1896},
+		domain.Movie{Title: "Bacurau", Year: 2019},
+	)
+	order, err := domain.ParseOrderBy("-year,title")
+	require.NoError(t, err)
+	query := domain.MovieQuery{Filter: domain.MovieFilter{Decade: 1890}, OrderBy: order, Limit: 2, IncludeTotal: true}
+
+	first, err := repo.GetAll(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), *first.TotalCount)
+	// Os artigos iniciais não contam na ordenação: "The Arrival..." vem antes de "Le manoir...".
+	assert.Equal(t, []string{"The Arrival of a Train", "Le manoir du diable"}, titles(first.Movies))
+	require.NotEmpty(t, first.NextPageToken)
+
+	// Um filme criado entre as páginas não desloca a página seguinte.
+	_, err = repo.Save(ctx, domain.Movie{Title: "A Abertura", Year: 1897})
+	require.NoError(t, err)
+
+	query.PageToken = first.NextPageToken
+	second, err := repo.GetAll(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"La sortie des usines Lumière"}, titles(second.Movies))
+	assert.Empty(t, second.NextPageToken)
+
+	query.OrderBy = nil
+	_, err = repo.GetAll(ctx, query)
+	assert.ErrorIs(t, err, domain.ErrInvalidPageToken)
+}
+
+func TestSQLRepository_GetAllNullsAndTitle(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+	insertMovies(t, repo,
+		domain.Movie{Title: "Cidade de Deus", Year: 2002, RuntimeMinutes: 130},
+		domain.Movie{Title: "Central do Brasil", Year: 1998},
+		domain.Movie{Title: "Deus e o Diabo na Terra do Sol", Year: 1964, RuntimeMinutes: 120},
+		domain.Movie{Title: "100% Lobo", Year: 2020, RuntimeMinutes: 96},
+	)
+
+	page, err := repo.GetAll(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Title: "DEÚS"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Cidade de Deus", "Deus e o Diabo na Terra do Sol"}, titles(page.Movies))
+
+	page, err = repo.GetAll(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Title: "0%"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"100% Lobo"}, titles(page.Movies))
+
+	// Percorre uma página por vez para exercitar o cursor em valores nulos nas duas direções.
+	for _, tc := range []struct {
+		orderBy  string
+		expected []string
+	}{
+		{"runtime_minutes", []string{"Central do Brasil", "100% Lobo", "Deus e o Diabo na Terra do Sol", "Cidade de Deus"}},
+		{"-runtime_minutes", []string{"Cidade de Deus", "Deus e o Diabo na Terra do Sol", "100% Lobo", "Central do Brasil"}},
+	} {
+		order, err := domain.ParseOrderBy(tc.orderBy)
+		require.NoError(t, err)
+		query := domain.MovieQuery{OrderBy: order, Limit: 1}
+		var got []string
+		for {
+			page, err := repo.GetAll(ctx, query)
+			require.NoError(t, err)
+			got = append(got, titles(page.Movies)...)
+			if page.NextPageToken == "" {
+				break
+			}
+			query.PageToken = page.NextPageToken
+		}
+		assert.Equal(t, tc.expected, got, tc.orderBy)
+	}
+}
+
+func TestSQLRepository_Duplicates(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{
+		{Title: "Central do Brasil", Year: 1998},
+		{Title: "central  do BRASIL", Year: 1998},
+		{Title: "Central do Brasil", Year: 2024},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrMovieAlreadyExists)
+	assert.Nil(t, results[1].Movie)
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, int64(1), results[2].Movie.Version)
+
+	_, err = repo.Save(ctx, domain.Movie{Title: "Céntral do Brasil ", Year: 1998})
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	page, err := repo.GetAll(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Year: 2024}})
+	require.NoError(t, err)
+	require.Len(t, page.Movies, 1)
+	remake := page.Movies[0]
+	remake.Year = 1998
+	_, err = repo.Save(ctx, remake)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	remake.Title = "Central do Brasil (remake)"
+	_, err = repo.Save(ctx, remake)
+	assert.NoError(t, err)
+}
+
+func TestSQLRepository_GetMany(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{{Title: "Bacurau", Year: 2019}, {Title: "Aquarius", Year: 2016}})
+	require.NoError(t, err)
+	bacurau, aquarius := results[0].Movie, results[1].Movie
+	_, err = repo.Delete(ctx, aquarius.ID, 0)
+	require.NoError(t, err)
+
+	movies, err := repo.GetMany(ctx, []string{bacurau.ID, aquarius.ID, "000000000000000000000000", "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bacurau"}, titles(movies))
+
+	movies, err = repo.GetMany(ctx, []string{"abc"})
+	require.NoError(t, err)
+	assert.Empty(t, movies)
+}
+
+func TestSQLRepository_Stream(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{
+		{Title: "Bacurau", Year: 2019},
+		{Title: "Aquarius", Year: 2016},
+		{Title: "Central do Brasil", Year: 1998},
+	})
+	require.NoError(t, err)
+	_, err = repo.Delete(ctx, results[1].Movie.ID, 0)
+	require.NoError(t, err)
+
+	var streamed []domain.Movie
+	collect := func(movie domain.Movie) error {
+		streamed = append(streamed, movie)
+		return nil
+	}
+	order, _ := domain.ParseOrderBy("title")
+	require.NoError(t, repo.Stream(ctx, domain.MovieQuery{OrderBy: order, Limit: 1}, collect))
+	assert.Equal(t, []string{"Bacurau", "Central do Brasil"}, titles(streamed))
+
+	streamed = nil
+	require.NoError(t, repo.Stream(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Decade: 2010}, Deleted: true}, collect))
+	assert.Equal(t, []string{"Aquarius"}, titles(streamed))
+
+	stop := errors.New("parar")
+	calls := 0
+	err = repo.Stream(ctx, domain.MovieQuery{}, func(domain.Movie) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSQLRepository_FindByTitle(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	saved, err := repo.Save(ctx, domain.Movie{Title: "Lumière", Year: 1895})
+	require.NoError(t, err)
+
+	found, err := repo.FindByTitle(ctx, "  lumiere ", 1895)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, found.ID)
+
+	_, err = repo.FindByTitle(ctx, "Lumière", 1896)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	require.NoError(t, err)
+	_, err = repo.FindByTitle(ctx, "Lumière", 1895)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
+func TestSQLRepository_SourceIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{
+		{Title: "Bacurau", Year: 2019, SourceID: "1"},
+		{Title: "Aquarius", Year: 2016, SourceID: "1"},
+		{Title: "O Som ao Redor", Year: 2012, SourceID: "2"},
+		{Title: "Tatuagem", Year: 2013},
+	})
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrMovieAlreadyExists)
+
+	found, err := repo.FindBySourceIDs(ctx, []string{"1", "2", "3"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Bacurau", "O Som ao Redor"}, titles(found))
+
+	// O SourceID passa a valer para um filme existente, e o removido continua sendo encontrado, mas libera o seu.
+	linked := *results[3].Movie
+	linked.SourceID = "3"
+	_, err = repo.Save(ctx, linked)
+	require.NoError(t, err)
+	_, err = repo.Delete(ctx, results[2].Movie.ID, 0)
+	require.NoError(t, err)
+	found, err = repo.FindBySourceIDs(ctx, []string{"2", "3"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"O Som ao Redor", "Tatuagem"}, titles(found))
+	_, err = repo.Save(ctx, domain.Movie{Title: "O Som ao Redor", Year: 2012, SourceID: "2"})
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, results[2].Movie.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+}
+
+func TestSQLRepository_LinkSources(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{
+		{Title: "Bacurau", Year: 2019},
+		{Title: "Aquarius", Year: 2016, SourceID: "2"},
+		{Title: "Tatuagem", Year: 2013},
+	})
+	require.NoError(t, err)
+	bacurau, aquarius, tatuagem := *results[0].Movie, *results[1].Movie, *results[2].Movie
+
+	bacurau.SourceID, bacurau.ExternalIDs = "1", map[string]string{"tmdb": "473074"}
+	aquarius.SourceID = "3"
+	tatuagem.SourceID = "2"
+	stale := bacurau
+	stale.Version--
+	results, err = repo.LinkSources(ctx, []domain.Movie{bacurau, aquarius, tatuagem, stale})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, bacurau.Version+1, results[0].Movie.Version)
+	assert.Equal(t, "1", results[0].Movie.SourceID)
+	assert.ErrorIs(t, results[1].Err, domain.ErrVersionConflict, "o filme já tem um SourceID")
+	assert.ErrorIs(t, results[2].Err, domain.ErrMovieAlreadyExists)
+	assert.ErrorIs(t, results[3].Err, domain.ErrVersionConflict)
+
+	found, err := repo.FindByExternalID(ctx, "tmdb", "473074")
+	require.NoError(t, err)
+	assert.Equal(t, "Bacurau", found.Title)
+	assert.Equal(t, "1", found.SourceID)
+}
+
+func TestSQLRepository_ExternalIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	results, err := repo.InsertMany(ctx, []domain.Movie{
+		{Title: "Bacurau", Year: 2019, ExternalIDs: map[string]string{"imdb": "tt2762506", "tmdb": "453278"}},
+		{Title: "Aquarius", Year: 2016, ExternalIDs: map[string]string{"imdb": "tt2762506"}},
+		{Title: "Tatuagem", Year: 2013, ExternalIDs: map[string]string{"movielens": "453278"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrMovieAlreadyExists)
+	require.NoError(t, results[2].Err, "o mesmo id em outro provedor não conflita")
+
+	found, err := repo.FindByExternalID(ctx, "tmdb", "453278")
+	require.NoError(t, err)
+	assert.Equal(t, "Bacurau", found.Title)
+	assert.Equal(t, map[string]string{"imdb": "tt2762506", "tmdb": "453278"}, found.ExternalIDs)
+	_, err = repo.FindByExternalID(ctx, "imdb", "tt0000010")
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	// O filme na lixeira some da busca e libera seus ids; restaurá-lo falha se outro filme os tomou.
+	_, err = repo.Delete(ctx, found.ID, 0)
+	require.NoError(t, err)
+	_, err = repo.FindByExternalID(ctx, "imdb", "tt2762506")
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Save(ctx, domain.Movie{Title: "O Som ao Redor", Year: 2012, ExternalIDs: map[string]string{"imdb": "tt2762506"}})
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, found.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+}
+
+func TestSQLRepository_SoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+	saved, err := repo.Save(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	require.NoError(t, err)
+	insertMovies(t, repo, domain.Movie{Title: "Aquarius", Year: 2016})
+
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	require.NoError(t, err)
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Get(ctx, saved.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	_, err = repo.Save(ctx, *saved)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	page, err := repo.GetAll(ctx, domain.MovieQuery{IncludeTotal: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Aquarius"}, titles(page.Movies))
+	assert.Equal(t, int64(1), *page.TotalCount)
+	trash, err := repo.GetAll(ctx, domain.MovieQuery{Deleted: true})
+	require.NoError(t, err)
+	require.Len(t, trash.Movies, 1)
+	assert.WithinDuration(t, time.Now(), *trash.Movies[0].DeletedAt, time.Minute)
+
+	restored, err := repo.Restore(ctx, saved.ID)
+	require.NoError(t, err)
+	saved.Version = 3 // criação, remoção e restauração
+	assert.Equal(t, saved, restored)
+	_, err = repo.Restore(ctx, saved.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	// Um filme igual criado enquanto o original estava na lixeira impede a restauração.
+	_, err = repo.Delete(ctx, saved.ID, 0)
+	require.NoError(t, err)
+	duplicate, err := repo.Save(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, saved.ID)
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	// O expurgo respeita o limite e remove primeiro os que estão na lixeira há mais tempo.
+	_, err = repo.Delete(ctx, duplicate.ID, 0)
+	require.NoError(t, err)
+	purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+	purged, err = repo.Purge(ctx, time.Now().Add(time.Second), 1)
+	require.NoError(t, err)
+	if assert.Len(t, purged, 1) {
+		assert.Equal(t, saved.ID, purged[0].ID)
+		assert.Equal(t, "Bacurau", purged[0].Title)
+		assert.NotNil(t, purged[0].DeletedAt)
+	}
+	purged, err = repo.Purge(ctx, time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	if assert.Len(t, purged, 1) {
+		assert.Equal(t, duplicate.ID, purged[0].ID)
+	}
+}
+
+func TestSQLRepository_Versions(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	saved, err := repo.Save(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), saved.Version)
+
+	first, second := *saved, *saved
+	first.Synopsis = "Primeira escrita"
+	updated, err := repo.Save(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+
+	// A segunda escrita partiu da versão 1, que já não é a persistida.
+	second.Synopsis = "Escrita concorrente"
+	_, err = repo.Save(ctx, second)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	found, err := repo.Get(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Primeira escrita", found.Synopsis)
+	assert.Equal(t, int64(2), found.Version)
+}
+
+func TestSQLLocker(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, SQLite, "file:"+filepath.Join(t.TempDir(), "movies.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	locker := NewSQLLocker(db, SQLite)
+
+	unlock, err := locker.TryLock(ctx, "seed", time.Minute)
+	require.NoError(t, err)
+	_, err = locker.TryLock(ctx, "seed", time.Minute)
+	assert.ErrorIs(t, err, domain.ErrLockHeld)
+	_, err = locker.TryLock(ctx, "outra", time.Minute)
+	assert.NoError(t, err)
+
+	require.NoError(t, unlock(ctx))
+	expired, err := locker.TryLock(ctx, "seed", -time.Second)
+	require.NoError(t, err)
+	_, err = locker.TryLock(ctx, "seed", time.Minute)
+	require.NoError(t, err, "a trava vencida pode ser tomada")
+	require.NoError(t, expired(ctx))
+	_, err = locker.TryLock(ctx, "seed", time.Minute)
+	assert.ErrorIs(t, err, domain.ErrLockHeld, "unlock não libera a trava tomada por outro")
+}
+
+func TestSQLRevisionStore(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, SQLite, "file:"+filepath.Join(t.TempDir(), "movies.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewSQLRevisionStore(db, SQLite)
+	movieID := "64b7f0c2a1b2c3d4e5f60718"
+
+	release := time.Date(2019, 8, 29, 0, 0, 0, 0, time.UTC)
+	first := domain.MovieRevision{
+		MovieID:   movieID,
+		Version:   1,
+		Action:    domain.RevisionCreate,
+		Actor:     "maria",
+		Timestamp: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Changes:   []domain.FieldChange{{Field: "title", Old: nil, New: "Bacurau"}},
+		Snapshot:  domain.Movie{ID: movieID, Title: "Bacurau", Year: 2019, ReleaseDate: &release, Version: 1},
+	}
+	require.NoError(t, store.Append(ctx, first))
+	require.NoError(t, store.Append(ctx, domain.MovieRevision{MovieID: movieID, Version: 2, Action: domain.RevisionUpdate, Actor: "joao", Timestamp: time.Now()}))
+	require.NoError(t, store.Append(ctx, domain.MovieRevision{MovieID: movieID, Version: 3, Action: domain.RevisionRevert, Actor: "maria", Timestamp: time.Now(), RevertedTo: 1}))
+	assert.Error(t, store.Append(ctx, first), "a versão identifica a revisão")
+
+	revision, err := store.Get(ctx, movieID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, first.Timestamp, revision.Timestamp)
+	assert.Equal(t, first.Snapshot, revision.Snapshot)
+	assert.Equal(t, "Bacurau", revision.Changes[0].New)
+	assert.Nil(t, revision.Changes[0].Old)
+
+	revisions, err := store.List(ctx, movieID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, int64(3), revisions[0].Version)
+	assert.Equal(t, int64(1), revisions[0].RevertedTo)
+	assert.Equal(t, int64(2), revisions[1].Version)
+
+	revisions, err = store.List(ctx, movieID, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, domain.RevisionCreate, revisions[0].Action)
+
+	_, err = store.Get(ctx, movieID, 4)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	_, err = store.List(ctx, "id-invalido", 0, 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidIDFormat)
+}
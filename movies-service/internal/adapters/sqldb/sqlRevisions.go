@@ -0,0 +1,122 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const revisionColumns = "movie_id, version, action, actor, recorded_at, changes, snapshot, reverted_to"
+
+// SQLRevisionStore guarda o histórico de alterações na tabela movie_revisions. Implementa `ports.MovieRevisionStore`.
+type SQLRevisionStore struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewSQLRevisionStore é o construtor do SQLRevisionStore. Espera um banco já migrado (ver Open).
+func NewSQLRevisionStore(db *sql.DB, dialect Dialect) *SQLRevisionStore {
+	return &SQLRevisionStore{db: db, dialect: dialect}
+}
+
+func (s *SQLRevisionStore) Append(ctx context.Context, revision domain.MovieRevision) error {
+	changes, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return err
+	}
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return err
+	}
+	var revertedTo sql.NullInt64
+	if revision.RevertedTo > 0 {
+		revertedTo = sql.NullInt64{Int64: revision.RevertedTo, Valid: true}
+	}
+
+	statement := fmt.Sprintf("INSERT INTO movie_revisions (%s) VALUES (%s, %s, %s, %s, %s, %s, %s, %s)", revisionColumns,
+		s.dialect.Placeholder(1), s.dialect.Placeholder(2), s.dialect.Placeholder(3), s.dialect.Placeholder(4),
+		s.dialect.Placeholder(5), s.dialect.Placeholder(6), s.dialect.Placeholder(7), s.dialect.Placeholder(8))
+	_, err = s.db.ExecContext(ctx, statement, revision.MovieID, revision.Version, string(revision.Action), revision.Actor,
+		revision.Timestamp.UTC().Format(time.RFC3339Nano), string(changes), string(snapshot), revertedTo)
+	return err
+}
+
+func (s *SQLRevisionStore) List(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error) {
+	if _, err := primitive.ObjectIDFromHex(movieID); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	qb := &queryBuilder{dialect: s.dialect}
+	statement := "SELECT " + revisionColumns + " FROM movie_revisions WHERE movie_id = " + qb.arg(movieID)
+	if beforeVersion > 0 {
+		statement += " AND version < " + qb.arg(beforeVersion)
+	}
+	statement += " ORDER BY version DESC"
+	if limit > 0 {
+		statement += " LIMIT " + qb.arg(limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, statement, qb.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]domain.MovieRevision, 0)
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	return revisions, rows.Err()
+}
+
+func (s *SQLRevisionStore) Get(ctx context.Context, movieID string, version int64) (*domain.MovieRevision, error) {
+	if _, err := primitive.ObjectIDFromHex(movieID); err != nil {
+		return nil, domain.ErrInvalidIDFormat
+	}
+
+	statement := fmt.Sprintf("SELECT %s FROM movie_revisions WHERE movie_id = %s AND version = %s",
+		revisionColumns, s.dialect.Placeholder(1), s.dialect.Placeholder(2))
+	revision, err := scanRevision(s.db.QueryRowContext(ctx, statement, movieID, version))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRevisionNotFound
+	}
+	return revision, err
+}
+
+func scanRevision(row scanner) (*domain.MovieRevision, error) {
+	var (
+		revision           domain.MovieRevision
+		action, recordedAt string
+		changes, snapshot  string
+		revertedTo         sql.NullInt64
+	)
+	if err := row.Scan(&revision.MovieID, &revision.Version, &action, &revision.Actor, &recordedAt,
+		&changes, &snapshot, &revertedTo); err != nil {
+		return nil, err
+	}
+
+	revision.Action = domain.RevisionAction(action)
+	revision.RevertedTo = revertedTo.Int64
+	timestamp, err := time.Parse(time.RFC3339Nano, recordedAt)
+	if err != nil {
+		return nil, err
+	}
+	revision.Timestamp = timestamp
+	if err := json.Unmarshal([]byte(changes), &revision.Changes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(snapshot), &revision.Snapshot); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// AnonymousActor é o autor registrado no histórico quando a escrita não informa quem a fez.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor anota no contexto quem está fazendo a operação, para o histórico de alterações.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, strings.TrimSpace(actor))
+}
+
+// ActorFromContext devolve o autor anotado com WithActor, ou AnonymousActor.
+func ActorFromContext(ctx context.Context) string {
+	if actor, _ := ctx.Value(actorKey{}).(string); actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
@@ -0,0 +1,11 @@
+package domain
+
+// MaxBatchSize é o número máximo de itens de uma operação em lote (leitura, criação ou remoção).
+const MaxBatchSize = 500
+
+// BatchResult é o resultado de um item de uma operação em lote, na mesma posição do item pedido:
+// o filme, quando o item deu certo, ou o erro que só ele teve. Um lote não é tudo ou nada.
+type BatchResult struct {
+	Movie *Movie
+	Err   error
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error é um erro do catálogo do domínio. Code é estável e serve a máquinas (clientes, métricas, alertas);
+// Message é o texto em português para pessoas e pode mudar. Os adaptadores de entrada (gRPC, consumidor
+// de mensagens) tratam os erros pelo catálogo, sem depender de um banco específico.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Catálogo de erros. Os códigos fazem parte do contrato da API e não devem ser renomeados.
+var (
+	ErrMissingID          = newError("MISSING_ID", "O ID do filme é obrigatório")
+	ErrInvalidIDFormat    = newError("INVALID_ID", "Formato de ID de filme inválido")
+	ErrMovieNotFound      = newError("MOVIE_NOT_FOUND", "Filme não encontrado")
+	ErrMovieAlreadyExists = newError("MOVIE_ALREADY_EXISTS", "Já existe um filme com esse título e ano ou identificador externo")
+	ErrVersionMismatch    = newError("VERSION_MISMATCH", "A versão do filme não é a esperada")
+	ErrVersionConflict    = newError("VERSION_CONFLICT", "O filme foi alterado por outra operação")
+	ErrInvalidVersion     = newError("INVALID_VERSION", "A versão deve ser maior que zero")
+	ErrInvalidMovie       = newError("INVALID_MOVIE", "Filme inválido")
+	ErrInvalidReleaseDate = newError("INVALID_RELEASE_DATE", "A data de lançamento deve estar no formato AAAA-MM-DD")
+	ErrInvalidUpdateMask  = newError("INVALID_UPDATE_MASK", "Máscara de atualização inválida")
+	ErrInvalidFilter      = newError("INVALID_FILTER", "Filtro de listagem inválido")
+	ErrInvalidPageToken   = newError("INVALID_PAGE_TOKEN", "Token de página inválido")
+	ErrInvalidOrderBy     = newError("INVALID_ORDER_BY", "Ordenação inválida")
+	ErrEmptySearchQuery   = newError("EMPTY_SEARCH_QUERY", "Termo de busca não pode ser vazio")
+	ErrSearchUnavailable  = newError("SEARCH_UNAVAILABLE", "Busca textual não disponível")
+	ErrRevisionNotFound   = newError("REVISION_NOT_FOUND", "Revisão não encontrada")
+	ErrHistoryUnavailable = newError("HISTORY_UNAVAILABLE", "Histórico de alterações não disponível")
+	ErrBatchTooLarge      = newError("BATCH_TOO_LARGE", fmt.Sprintf("O lote excede o limite de %d itens", MaxBatchSize))
+	ErrInvalidImportMode  = newError("INVALID_IMPORT_MODE", "Modo de importação inválido")
+	ErrInvalidExternalID  = newError("INVALID_EXTERNAL_ID", "Identificador externo inválido")
+	ErrInternal           = newError("INTERNAL", "Um erro interno ocorreu")
+)
+
+// ErrLockHeld indica que a trava pedida está com outra instância do serviço. Fica fora do catálogo
+// porque não chega aos clientes.
+var ErrLockHeld = errors.New("a trava está com outra instância")
+
+// AsError devolve o erro do catálogo contido em err. Erros fora do catálogo (falhas de banco, de rede etc.)
+// viram ErrInternal, para que detalhes de infraestrutura não cheguem ao cliente.
+func AsError(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return ErrInternal
+}
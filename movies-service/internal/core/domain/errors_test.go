@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsError(t *testing.T) {
+	assert.Equal(t, ErrMovieNotFound, AsError(ErrMovieNotFound))
+	assert.Equal(t, ErrInvalidFilter, AsError(fmt.Errorf("%w: anos não podem ser negativos", ErrInvalidFilter)))
+	assert.Equal(t, ErrInvalidMovie, AsError(&ValidationError{Violations: []FieldViolation{{Field: "title"}}}))
+	assert.Equal(t, ErrInternal, AsError(errors.New("conexão recusada")))
+}
+
+func TestErrorCodesAreUnique(t *testing.T) {
+	catalog := []*Error{
+		ErrMissingID, ErrInvalidIDFormat, ErrMovieNotFound, ErrMovieAlreadyExists, ErrVersionMismatch,
+		ErrVersionConflict, ErrInvalidVersion, ErrInvalidMovie, ErrInvalidReleaseDate, ErrInvalidUpdateMask,
+		ErrInvalidFilter, ErrInvalidPageToken, ErrInvalidOrderBy, ErrEmptySearchQuery, ErrSearchUnavailable,
+		ErrRevisionNotFound, ErrHistoryUnavailable, ErrBatchTooLarge, ErrInvalidImportMode, ErrInternal,
+	}
+	seen := map[string]bool{}
+	for _, err := range catalog {
+		assert.NotEmpty(t, err.Code)
+		assert.False(t, seen[err.Code], "código repetido: %s", err.Code)
+		seen[err.Code] = true
+	}
+}
+
+func TestErrBatchTooLarge_Message(t *testing.T) {
+	assert.Equal(t, fmt.Sprintf("O lote excede o limite de %d itens", MaxBatchSize), ErrBatchTooLarge.Error())
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MovieEventType é o tipo de um evento de fato, publicado depois que a escrita foi aplicada. O sufixo é a versão
+// do formato do evento; uma mudança incompatível ganha um tipo novo.
+type MovieEventType string
+
+const (
+	EventMovieCreated  MovieEventType = "movie.created.v1"
+	EventMovieUpdated  MovieEventType = "movie.updated.v1"
+	EventMovieDeleted  MovieEventType = "movie.deleted.v1"
+	EventMovieRestored MovieEventType = "movie.restored.v1"
+	EventMoviePurged   MovieEventType = "movie.purged.v1"
+)
+
+// revisionEvents é o evento publicado por cada operação do histórico. Uma reversão é uma alteração como as outras.
+var revisionEvents = map[RevisionAction]MovieEventType{
+	RevisionCreate:  EventMovieCreated,
+	RevisionUpdate:  EventMovieUpdated,
+	RevisionRevert:  EventMovieUpdated,
+	RevisionDelete:  EventMovieDeleted,
+	RevisionRestore: EventMovieRestored,
+}
+
+// MovieEvent anuncia uma escrita já aplicada em um filme. Movie é o filme inteiro como ficou, com o ID e a versão
+// gravados. ID identifica o evento pelo filme e pela versão, para que quem o recebe descarte entregas repetidas.
+type MovieEvent struct {
+	ID         string         `json:"id" bson:"id"`
+	Type       MovieEventType `json:"type" bson:"type"`
+	OccurredAt time.Time      `json:"occurred_at" bson:"occurred_at"`
+	Actor      string         `json:"actor,omitempty" bson:"actor,omitempty"`
+	Movie      Movie          `json:"movie" bson:"movie"`
+}
+
+// OutboxEntry é um evento da caixa de saída que ainda não foi publicado, com as tentativas que já falharam.
+type OutboxEntry struct {
+	Event     MovieEvent
+	Attempts  int
+	LastError string
+}
+
+// NewPurgeEvent anuncia que o filme saiu de vez da lixeira. Movie é o filme como estava nela; como o expurgo não
+// gera versão nova, o ID do evento traz "purged" no lugar da versão.
+func NewPurgeEvent(movie Movie, actor string, at time.Time) MovieEvent {
+	return MovieEvent{
+		ID:         movie.ID + ":purged",
+		Type:       EventMoviePurged,
+		OccurredAt: at,
+		Actor:      actor,
+		Movie:      movie,
+	}
+}
+
+// NewRevisionEvent descreve como evento a escrita registrada na revisão.
+func NewRevisionEvent(revision MovieRevision) MovieEvent {
+	return MovieEvent{
+		ID:         fmt.Sprintf("%s:%d", revision.Snapshot.ID, revision.Snapshot.Version),
+		Type:       revisionEvents[revision.Action],
+		OccurredAt: revision.Timestamp,
+		Actor:      revision.Actor,
+		Movie:      revision.Snapshot,
+	}
+}
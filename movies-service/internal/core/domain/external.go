@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Provedores de identificadores externos aceitos em Movie.ExternalIDs.
+const (
+	ProviderIMDb      = "imdb"
+	ProviderTMDb      = "tmdb"
+	ProviderMovieLens = "movielens"
+)
+
+// ExternalIDProviders são os provedores aceitos, na ordem em que são exibidos. Cada um tem seu índice único no banco.
+var ExternalIDProviders = []string{ProviderIMDb, ProviderTMDb, ProviderMovieLens}
+
+// imdbIDPattern é o formato dos ids de título do IMDb, como "tt0000010".
+var imdbIDPattern = regexp.MustCompile(`^tt\d{7,}$`)
+
+// NormalizeExternalID padroniza um identificador externo: provedor em minúsculas e id sem espaços. Um id do
+// IMDb só com dígitos (como os de data/movies.json) ganha o prefixo "tt" e os zeros à esquerda.
+func NormalizeExternalID(provider, id string) (string, string) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	id = strings.TrimSpace(id)
+	if provider == ProviderIMDb {
+		id = strings.ToLower(id)
+		if n, err := strconv.ParseUint(id, 10, 64); err == nil && n > 0 {
+			id = fmt.Sprintf("tt%07d", n)
+		}
+	}
+	return provider, id
+}
+
+// validateExternalID devolve o problema de um identificador já normalizado, ou vazio se ele for válido.
+func validateExternalID(provider, id string) string {
+	switch provider {
+	case ProviderIMDb:
+		if !imdbIDPattern.MatchString(id) {
+			return fmt.Sprintf("O id do IMDb %q deve ter o formato tt0000000", id)
+		}
+	case ProviderTMDb, ProviderMovieLens:
+		if n, err := strconv.ParseUint(id, 10, 64); err != nil || n == 0 {
+			return fmt.Sprintf("O id do %s %q deve ser um número inteiro positivo", provider, id)
+		}
+	default:
+		return fmt.Sprintf("Provedor %q desconhecido (aceitos: %s)", provider, strings.Join(ExternalIDProviders, ", "))
+	}
+	return ""
+}
+
+// ParseExternalID normaliza e confere o provedor e o id de uma busca por identificador externo.
+func ParseExternalID(provider, id string) (string, string, error) {
+	provider, id = NormalizeExternalID(provider, id)
+	if id == "" {
+		return "", "", fmt.Errorf("%w: o id é obrigatório", ErrInvalidExternalID)
+	}
+	if problem := validateExternalID(provider, id); problem != "" {
+		return "", "", fmt.Errorf("%w: %s", ErrInvalidExternalID, problem)
+	}
+	return provider, id, nil
+}
+
+// normalizeExternalIDs padroniza as chaves e os ids do mapa, descartando os ids vazios. Um mapa vazio vira nil.
+func normalizeExternalIDs(ids map[string]string) map[string]string {
+	normalized := make(map[string]string, len(ids))
+	for provider, id := range ids {
+		if provider, id = NormalizeExternalID(provider, id); id != "" {
+			normalized[provider] = id
+		}
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// externalIDViolations confere cada identificador do mapa, na ordem dos provedores.
+func externalIDViolations(ids map[string]string) []FieldViolation {
+	providers := make([]string, 0, len(ids))
+	for provider := range ids {
+		providers = append(providers, provider)
+	}
+	slices.SortFunc(providers, func(a, b string) int {
+		if rank := providerRank(a) - providerRank(b); rank != 0 {
+			return rank
+		}
+		return strings.Compare(a, b)
+	})
+
+	var violations []FieldViolation
+	for _, provider := range providers {
+		if problem := validateExternalID(provider, ids[provider]); problem != "" {
+			violations = append(violations, FieldViolation{Field: "external_ids." + provider, Description: problem})
+		}
+	}
+	return violations
+}
+
+// providerRank é a posição do provedor em ExternalIDProviders; os desconhecidos vêm depois.
+func providerRank(provider string) int {
+	if i := slices.Index(ExternalIDProviders, provider); i >= 0 {
+		return i
+	}
+	return len(ExternalIDProviders)
+}
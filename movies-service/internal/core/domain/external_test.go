@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExternalID(t *testing.T) {
+	testCases := []struct {
+		name             string
+		provider         string
+		id               string
+		expectedProvider string
+		expectedID       string
+		expectedErr      error
+	}{
+		{name: "IMDb", provider: "imdb", id: "tt0000010", expectedProvider: "imdb", expectedID: "tt0000010"},
+		{name: "IMDb só com dígitos", provider: "IMDb", id: " 10 ", expectedProvider: "imdb", expectedID: "tt0000010"},
+		{name: "TMDb", provider: "tmdb", id: "775", expectedProvider: "tmdb", expectedID: "775"},
+		{name: "IMDb inválido", provider: "imdb", id: "nm0000010", expectedErr: ErrInvalidExternalID},
+		{name: "TMDb inválido", provider: "tmdb", id: "tt0000010", expectedErr: ErrInvalidExternalID},
+		{name: "Provedor desconhecido", provider: "letterboxd", id: "10", expectedErr: ErrInvalidExternalID},
+		{name: "Id vazio", provider: "movielens", id: " ", expectedErr: ErrInvalidExternalID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, id, err := ParseExternalID(tc.provider, tc.id)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedProvider, provider)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func TestMovieExternalIDs(t *testing.T) {
+	movie := Movie{Title: "Bacurau", ExternalIDs: map[string]string{"IMDb": "2762506", "tmdb": " 453278 ", "movielens": ""}}
+	movie.Normalize()
+	assert.Equal(t, map[string]string{"imdb": "tt2762506", "tmdb": "453278"}, movie.ExternalIDs)
+	assert.NoError(t, movie.Validate("external_ids"))
+
+	movie.ExternalIDs = map[string]string{"letterboxd": "bacurau", "tmdb": "abc"}
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(movie.Validate("external_ids"), &validationErr)) {
+		assert.Equal(t, []FieldViolation{
+			{Field: "external_ids.tmdb", Description: `O id do tmdb "abc" deve ser um número inteiro positivo`},
+			{Field: "external_ids.letterboxd", Description: `Provedor "letterboxd" desconhecido (aceitos: imdb, tmdb, movielens)`},
+		}, validationErr.Violations)
+	}
+
+	movie.ExternalIDs = map[string]string{" ": ""}
+	movie.Normalize()
+	assert.Nil(t, movie.ExternalIDs)
+}
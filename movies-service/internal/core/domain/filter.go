@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MovieFilter reúne os critérios de busca aceitos na listagem de filmes. Campos zerados não filtram.
+type MovieFilter struct {
+	Title    string // trecho do título, sem diferenciar maiúsculas nem acentos
+	Year     int    // ano exato
+	YearFrom int    // ano inicial, inclusivo
+	YearTo   int    // ano final, inclusivo
+	Decade   int    // ano inicial da década, ex.: 1990 para 1990–1999
+}
+
+// Validate rejeita combinações que não fazem sentido, como década fora do formato ou intervalo invertido.
+func (f MovieFilter) Validate() error {
+	if f.Year < 0 || f.YearFrom < 0 || f.YearTo < 0 || f.Decade < 0 {
+		return fmt.Errorf("%w: anos não podem ser negativos", ErrInvalidFilter)
+	}
+	if f.Decade%10 != 0 {
+		return fmt.Errorf("%w: década deve ser informada pelo ano inicial, ex.: 1990", ErrInvalidFilter)
+	}
+	if f.YearFrom > 0 && f.YearTo > 0 && f.YearFrom > f.YearTo {
+		return fmt.Errorf("%w: year_from não pode ser maior que year_to", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// YearBounds combina ano exato, intervalo e década em um único intervalo inclusivo.
+// Zero indica que o limite está aberto; from > to indica que nenhum filme atende ao filtro.
+func (f MovieFilter) YearBounds() (from, to int) {
+	from, to = f.YearFrom, f.YearTo
+	narrow := func(lo, hi int) {
+		if lo > from {
+			from = lo
+		}
+		if to == 0 || hi < to {
+			to = hi
+		}
+	}
+	if f.Decade > 0 {
+		narrow(f.Decade, f.Decade+9)
+	}
+	if f.Year > 0 {
+		narrow(f.Year, f.Year)
+	}
+	return from, to
+}
+
+// Matches indica se o filme atende ao filtro, com a mesma semântica da consulta feita no banco.
+// Útil para adaptadores que filtram em memória.
+func (f MovieFilter) Matches(m Movie) bool {
+	if title := strings.TrimSpace(f.Title); title != "" && !strings.Contains(FoldText(m.Title), FoldText(title)) {
+		return false
+	}
+	from, to := f.YearBounds()
+	if from > 0 && m.Year < from {
+		return false
+	}
+	if to > 0 && m.Year > to {
+		return false
+	}
+	return true
+}
+
+// FoldText normaliza um texto para comparações: minúsculas e sem acentos ("Lumière" vira "lumiere").
+func FoldText(s string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+	return strings.ToLower(folded)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovieFilter_YearBounds(t *testing.T) {
+	testCases := []struct {
+		name         string
+		filter       MovieFilter
+		expectedFrom int
+		expectedTo   int
+	}{
+		{name: "Sem filtro", filter: MovieFilter{}, expectedFrom: 0, expectedTo: 0},
+		{name: "Ano exato", filter: MovieFilter{Year: 1895}, expectedFrom: 1895, expectedTo: 1895},
+		{name: "Década", filter: MovieFilter{Decade: 1990}, expectedFrom: 1990, expectedTo: 1999},
+		{name: "Intervalo aberto", filter: MovieFilter{YearFrom: 2000}, expectedFrom: 2000, expectedTo: 0},
+		{name: "Década e intervalo", filter: MovieFilter{Decade: 1990, YearFrom: 1995, YearTo: 2005}, expectedFrom: 1995, expectedTo: 1999},
+		{name: "Ano fora da década", filter: MovieFilter{Decade: 1990, Year: 2001}, expectedFrom: 2001, expectedTo: 1999},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := tc.filter.YearBounds()
+			assert.Equal(t, tc.expectedFrom, from)
+			assert.Equal(t, tc.expectedTo, to)
+		})
+	}
+}
+
+func TestFoldText(t *testing.T) {
+	assert.Equal(t, "la sortie des usines lumiere", FoldText("La sortie des usines Lumière"))
+	assert.Equal(t, "sao joao", FoldText("São João"))
+}
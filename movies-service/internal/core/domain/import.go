@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImportMode diz o que a importação faz com cada linha válida.
+type ImportMode string
+
+const (
+	// ImportInsert só cria filmes; a linha de um filme que já existe (mesmo título e ano) é recusada.
+	ImportInsert ImportMode = "insert"
+	// ImportUpsert cria o filme ou substitui os campos editáveis do filme com o mesmo título e ano.
+	ImportUpsert ImportMode = "upsert"
+	// ImportDryRun faz as mesmas verificações de ImportInsert sem gravar nada.
+	ImportDryRun ImportMode = "dry-run"
+)
+
+// ParseImportMode interpreta o modo pedido pelo cliente. Vazio é ImportInsert.
+func ParseImportMode(value string) (ImportMode, error) {
+	switch mode := ImportMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return ImportInsert, nil
+	case ImportInsert, ImportUpsert, ImportDryRun:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q (aceitos: insert, upsert, dry-run)", ErrInvalidImportMode, value)
+	}
+}
+
+// ImportAction é o que a importação fez com uma linha aceita.
+type ImportAction string
+
+const (
+	ImportCreated   ImportAction = "created"
+	ImportUpdated   ImportAction = "updated"
+	ImportUnchanged ImportAction = "unchanged"
+	// ImportValid marca, no dry-run, a linha que seria criada.
+	ImportValid ImportAction = "valid"
+)
+
+// ImportRow é uma linha do arquivo importado. Line é a linha no arquivo de origem, repetida no relatório.
+// Err vem preenchido quando o adaptador não conseguiu converter a linha em filme; a linha é então recusada com ele.
+type ImportRow struct {
+	Line  int64
+	Movie Movie
+	Err   error
+}
+
+// ImportRowResult é o resultado de uma linha: a ação e o filme, quando aceita, ou o motivo da recusa.
+type ImportRowResult struct {
+	Line   int64
+	Action ImportAction
+	Movie  *Movie
+	Err    error
+}
+
+// ImportReport resume a importação, com o resultado de cada linha na ordem em que foram lidas.
+type ImportReport struct {
+	Mode     ImportMode
+	Accepted int64
+	Rejected int64
+	Rows     []ImportRowResult
+}
+
+// Add acrescenta o resultado de uma linha e atualiza os totais.
+func (r *ImportReport) Add(result ImportRowResult) {
+	if result.Err != nil {
+		r.Rejected++
+	} else {
+		r.Accepted++
+	}
+	r.Rows = append(r.Rows, result)
+}
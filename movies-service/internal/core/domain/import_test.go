@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportMode(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected ImportMode
+	}{
+		{value: "", expected: ImportInsert},
+		{value: "insert", expected: ImportInsert},
+		{value: " Upsert ", expected: ImportUpsert},
+		{value: "dry-run", expected: ImportDryRun},
+	}
+	for _, tc := range testCases {
+		mode, err := ParseImportMode(tc.value)
+		assert.NoError(t, err, tc.value)
+		assert.Equal(t, tc.expected, mode)
+	}
+
+	_, err := ParseImportMode("replace")
+	assert.ErrorIs(t, err, ErrInvalidImportMode)
+}
+
+func TestImportReport_Add(t *testing.T) {
+	var report ImportReport
+	report.Add(ImportRowResult{Line: 2, Action: ImportCreated, Movie: &Movie{Title: "Bacurau"}})
+	report.Add(ImportRowResult{Line: 3, Err: errors.New("linha ilegível")})
+	report.Add(ImportRowResult{Line: 4, Action: ImportUnchanged, Movie: &Movie{Title: "Aquarius"}})
+
+	assert.Equal(t, int64(2), report.Accepted)
+	assert.Equal(t, int64(1), report.Rejected)
+	assert.Len(t, report.Rows, 3)
+}
@@ -1,8 +1,110 @@
 package domain
 
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReleaseDateLayout é o formato usado para trafegar a data de lançamento (ISO 8601, só a data).
+const ReleaseDateLayout = "2006-01-02"
+
 // Movie representa a entidade principal da nossa aplicação.
+// Os campos opcionais usam omitempty para que documentos antigos, que só têm título e ano, continuem válidos.
 type Movie struct {
-	ID    string `json:"id" bson:"_id,omitempty"`
-	Title string `json:"title" bson:"title"`
-	Year  int    `json:"year" bson:"year"`
-}
\ No newline at end of file
+	ID               string     `json:"id" bson:"_id,omitempty"`
+	Title            string     `json:"title" bson:"title"`
+	Year             int        `json:"year" bson:"year"`
+	Genres           []string   `json:"genres,omitempty" bson:"genres,omitempty"`
+	Directors        []string   `json:"directors,omitempty" bson:"directors,omitempty"`
+	Cast             []string   `json:"cast,omitempty" bson:"cast,omitempty"`
+	RuntimeMinutes   int        `json:"runtime_minutes,omitempty" bson:"runtime_minutes,omitempty"`
+	Synopsis         string     `json:"synopsis,omitempty" bson:"synopsis,omitempty"`
+	OriginalLanguage string     `json:"original_language,omitempty" bson:"original_language,omitempty"`
+	ReleaseDate      *time.Time `json:"release_date,omitempty" bson:"release_date,omitempty"`
+	// DeletedAt marca o filme como removido (na lixeira). Filmes removidos não aparecem nas leituras normais.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	// Version começa em 1 e é incrementada pelo repositório a cada escrita. Save só grava se a versão
+	// persistida for igual à do filme recebido (controle de concorrência otimista).
+	Version int64 `json:"version" bson:"version"`
+	// SourceID é o id do filme no arquivo de seed. É único entre os filmes fora da lixeira e permite recarregar
+	// o arquivo sem duplicar filmes; filmes criados pela API não têm.
+	SourceID string `json:"source_id,omitempty" bson:"source_id,omitempty"`
+	// ExternalIDs são os ids do filme em outros catálogos, por provedor (ver ExternalIDProviders). Cada id é
+	// único entre os filmes do mesmo provedor fora da lixeira.
+	ExternalIDs map[string]string `json:"external_ids,omitempty" bson:"external_ids,omitempty"`
+}
+
+// Clone devolve uma cópia do filme que não compartilha listas, mapa nem datas com o original.
+func (m Movie) Clone() Movie {
+	m.Genres = slices.Clone(m.Genres)
+	m.Directors = slices.Clone(m.Directors)
+	m.Cast = slices.Clone(m.Cast)
+	m.ExternalIDs = maps.Clone(m.ExternalIDs)
+	if m.ReleaseDate != nil {
+		releaseDate := *m.ReleaseDate
+		m.ReleaseDate = &releaseDate
+	}
+	if m.DeletedAt != nil {
+		deletedAt := *m.DeletedAt
+		m.DeletedAt = &deletedAt
+	}
+	return m
+}
+
+// TitleKey é a forma normalizada do título usada, junto com o ano, para identificar filmes duplicados:
+// sem acentos, sem diferença entre maiúsculas e minúsculas e com os espaços colapsados.
+func TitleKey(title string) string {
+	return FoldText(strings.Join(strings.Fields(title), " "))
+}
+
+// UpdatableFields são os caminhos aceitos em uma máscara de atualização, com os mesmos nomes usados no JSON e no Protobuf.
+var UpdatableFields = []string{
+	"title",
+	"year",
+	"genres",
+	"directors",
+	"cast",
+	"runtime_minutes",
+	"synopsis",
+	"original_language",
+	"release_date",
+	"external_ids",
+}
+
+// ApplyUpdate copia de src para m apenas os campos listados em paths.
+// Uma máscara vazia substitui todos os campos editáveis. O ID nunca é alterado.
+func (m *Movie) ApplyUpdate(src Movie, paths []string) error {
+	if len(paths) == 0 {
+		paths = UpdatableFields
+	}
+	for _, path := range paths {
+		switch path {
+		case "title":
+			m.Title = src.Title
+		case "year":
+			m.Year = src.Year
+		case "genres":
+			m.Genres = src.Genres
+		case "directors":
+			m.Directors = src.Directors
+		case "cast":
+			m.Cast = src.Cast
+		case "runtime_minutes":
+			m.RuntimeMinutes = src.RuntimeMinutes
+		case "synopsis":
+			m.Synopsis = src.Synopsis
+		case "original_language":
+			m.OriginalLanguage = src.OriginalLanguage
+		case "release_date":
+			m.ReleaseDate = src.ReleaseDate
+		case "external_ids":
+			m.ExternalIDs = src.ExternalIDs
+		default:
+			return fmt.Errorf("%w: campo %q não pode ser atualizado", ErrInvalidUpdateMask, path)
+		}
+	}
+	return nil
+}
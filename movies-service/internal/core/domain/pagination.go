@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// MovieQuery descreve uma página da listagem de filmes.
+// Com PageToken, a página começa logo após o último filme da página anterior e Offset é ignorado.
+// IncludeTotal pede a contagem de todos os filmes que atendem ao filtro, o que custa uma consulta a mais.
+// Deleted troca o catálogo pela lixeira: só os filmes removidos entram na listagem.
+type MovieQuery struct {
+	Filter       MovieFilter
+	OrderBy      SortOrder
+	Limit        int64
+	Offset       int64
+	PageToken    string
+	IncludeTotal bool
+	Deleted      bool
+}
+
+// MoviePage é o resultado de uma listagem. NextPageToken fica vazio na última página
+// e TotalCount só é preenchido quando a consulta pediu IncludeTotal.
+type MoviePage struct {
+	Movies        []Movie
+	NextPageToken string
+	TotalCount    *int64
+}
+
+// PageCursor é o conteúdo de um page token: a ordenação usada, as chaves de ordenação e o ID
+// do último filme entregue. O ID desempata filmes com as mesmas chaves, então a posição no catálogo é sempre única.
+type PageCursor struct {
+	OrderBy string `json:"o,omitempty"`
+	Values  []any  `json:"v,omitempty"`
+	ID      string `json:"id"`
+}
+
+// EncodePageToken serializa o cursor em um token opaco, seguro para uso em URLs.
+func EncodePageToken(cursor PageCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodePageToken faz o caminho inverso de EncodePageToken.
+func DecodePageToken(token string) (PageCursor, error) {
+	var cursor PageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return PageCursor{}, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" {
+		return PageCursor{}, ErrInvalidPageToken
+	}
+	return cursor, nil
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	cursor := PageCursor{Values: []any{"Bacurau", float64(2019)}, ID: "64b7f0c2a1b2c3d4e5f60718"}
+
+	decoded, err := DecodePageToken(EncodePageToken(cursor))
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestDecodePageToken_Invalid(t *testing.T) {
+	for _, token := range []string{"não-é-base64", "bnVsbA", "e30"} {
+		_, err := DecodePageToken(token)
+		assert.ErrorIs(t, err, ErrInvalidPageToken, token)
+	}
+}
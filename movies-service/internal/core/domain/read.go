@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+type freshReadKey struct{}
+
+// WithFreshRead anota no contexto que a leitura precisa da versão atual do filme, e não de uma cópia em cache,
+// como a conferência de um If-Match antes de uma escrita.
+func WithFreshRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadKey{}, true)
+}
+
+// FreshReadFromContext diz se o contexto foi anotado com WithFreshRead.
+func FreshReadFromContext(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshReadKey{}).(bool)
+	return fresh
+}
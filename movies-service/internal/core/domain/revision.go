@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// RevisionAction é a operação que gerou uma revisão no histórico de um filme.
+type RevisionAction string
+
+const (
+	RevisionCreate  RevisionAction = "create"
+	RevisionUpdate  RevisionAction = "update"
+	RevisionDelete  RevisionAction = "delete"
+	RevisionRestore RevisionAction = "restore"
+	RevisionRevert  RevisionAction = "revert"
+)
+
+// FieldChange é a alteração de um campo em uma escrita. Old e New são nil quando o campo estava ou ficou vazio.
+type FieldChange struct {
+	Field string `json:"field" bson:"field"`
+	Old   any    `json:"old" bson:"old"`
+	New   any    `json:"new" bson:"new"`
+}
+
+// MovieRevision registra uma escrita em um filme: quem fez, quando e o que mudou.
+// Version é a versão que a escrita produziu e identifica a revisão; Snapshot é o filme como ficou,
+// usado para revertê-lo. Em reversões, RevertedTo é a versão restaurada.
+type MovieRevision struct {
+	MovieID    string         `json:"movie_id" bson:"movie_id"`
+	Version    int64          `json:"version" bson:"version"`
+	Action     RevisionAction `json:"action" bson:"action"`
+	Actor      string         `json:"actor" bson:"actor"`
+	Timestamp  time.Time      `json:"timestamp" bson:"timestamp"`
+	Changes    []FieldChange  `json:"changes" bson:"changes"`
+	Snapshot   Movie          `json:"snapshot" bson:"snapshot"`
+	RevertedTo int64          `json:"reverted_to,omitempty" bson:"reverted_to,omitempty"`
+}
+
+// DeletedField é o nome usado em FieldChange para a entrada e a saída do filme da lixeira.
+const DeletedField = "deleted"
+
+// Diff lista os campos editáveis que mudaram de before para after e, se for o caso, a entrada ou saída da lixeira.
+func Diff(before, after Movie) []FieldChange {
+	changes := make([]FieldChange, 0)
+	for _, field := range UpdatableFields {
+		oldValue, newValue := before.fieldValue(field), after.fieldValue(field)
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	if wasDeleted, isDeleted := before.DeletedAt != nil, after.DeletedAt != nil; wasDeleted != isDeleted {
+		changes = append(changes, FieldChange{Field: DeletedField, Old: wasDeleted, New: isDeleted})
+	}
+	return changes
+}
+
+// fieldValue devolve o valor de um campo editável como é exibido no histórico, ou nil se estiver vazio.
+func (m Movie) fieldValue(field string) any {
+	switch field {
+	case "title":
+		return emptyToNil(m.Title)
+	case "year":
+		return emptyToNil(m.Year)
+	case "genres":
+		return listValue(m.Genres)
+	case "directors":
+		return listValue(m.Directors)
+	case "cast":
+		return listValue(m.Cast)
+	case "runtime_minutes":
+		return emptyToNil(m.RuntimeMinutes)
+	case "synopsis":
+		return emptyToNil(m.Synopsis)
+	case "original_language":
+		return emptyToNil(m.OriginalLanguage)
+	case "release_date":
+		if m.ReleaseDate == nil {
+			return nil
+		}
+		return m.ReleaseDate.Format(ReleaseDateLayout)
+	case "external_ids":
+		if len(m.ExternalIDs) == 0 {
+			return nil
+		}
+		return maps.Clone(m.ExternalIDs)
+	}
+	return nil
+}
+
+func emptyToNil[T comparable](value T) any {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return value
+}
+
+func listValue(values []string) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return slices.Clone(values)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	releaseDate := time.Date(1972, 3, 24, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Now()
+
+	testCases := []struct {
+		name     string
+		before   Movie
+		after    Movie
+		expected []FieldChange
+	}{
+		{
+			name:  "Criação lista só os campos preenchidos",
+			after: Movie{Title: "The Godfather", Year: 1972, Genres: []string{"Crime"}, ReleaseDate: &releaseDate},
+			expected: []FieldChange{
+				{Field: "title", Old: nil, New: "The Godfather"},
+				{Field: "year", Old: nil, New: 1972},
+				{Field: "genres", Old: nil, New: []string{"Crime"}},
+				{Field: "release_date", Old: nil, New: "1972-03-24"},
+			},
+		},
+		{
+			name:     "Lista vazia equivale a ausente",
+			before:   Movie{Title: "Alien", Genres: []string{}},
+			after:    Movie{Title: "Alien"},
+			expected: []FieldChange{},
+		},
+		{
+			name:   "Alteração guarda o valor antigo",
+			before: Movie{Title: "Alien", Year: 1979, RuntimeMinutes: 117},
+			after:  Movie{Title: "Alien", Year: 1979},
+			expected: []FieldChange{
+				{Field: "runtime_minutes", Old: 117, New: nil},
+			},
+		},
+		{
+			name:     "Remoção",
+			before:   Movie{Title: "Alien"},
+			after:    Movie{Title: "Alien", DeletedAt: &deletedAt},
+			expected: []FieldChange{{Field: DeletedField, Old: false, New: true}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Diff(tc.before, tc.after))
+		})
+	}
+}
+
+func TestActorFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, AnonymousActor, ActorFromContext(ctx))
+	assert.Equal(t, AnonymousActor, ActorFromContext(WithActor(ctx, "  ")))
+	assert.Equal(t, "maria", ActorFromContext(WithActor(ctx, " maria ")))
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Marcadores usados para destacar os termos encontrados nos trechos de uma busca.
+const (
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// snippetRadius é quantos caracteres de contexto ficam em volta do primeiro termo encontrado na sinopse.
+const snippetRadius = 60
+
+// SearchResult é um filme encontrado pela busca textual, com sua relevância e os trechos que casaram.
+type SearchResult struct {
+	Movie      Movie
+	Score      float64
+	Highlights []SearchHighlight
+}
+
+// SearchHighlight é um trecho de um campo do filme com os termos da busca destacados.
+type SearchHighlight struct {
+	Field   string
+	Snippet string
+}
+
+// SearchTerms separa a consulta em termos, descartando pontuação.
+func SearchTerms(query string) []string {
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// HighlightText destaca em text as ocorrências dos termos, sem diferenciar maiúsculas nem acentos.
+// Com radius > 0, devolve só a vizinhança da primeira ocorrência. ok é falso quando nenhum termo aparece.
+func HighlightText(text string, terms []string, radius int) (snippet string, ok bool) {
+	original := []rune(text)
+	folded := make([]rune, len(original))
+	for i, r := range original {
+		folded[i] = foldRune(r)
+	}
+
+	marked := make([]bool, len(original))
+	first := -1
+	for _, term := range terms {
+		needle := []rune(FoldText(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(folded); i++ {
+			if string(folded[i:i+len(needle)]) != string(needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return "", false
+	}
+
+	from, to := 0, len(original)
+	if radius > 0 {
+		from, to = max(first-radius, 0), min(first+radius, len(original))
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	for i := from; i < to; i++ {
+		if marked[i] && (i == from || !marked[i-1]) {
+			b.WriteString(HighlightStart)
+		}
+		b.WriteRune(original[i])
+		if marked[i] && (i == to-1 || !marked[i+1]) {
+			b.WriteString(HighlightEnd)
+		}
+	}
+	if to < len(original) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// Highlight monta os destaques de título e sinopse de um filme para os termos informados.
+func (m Movie) Highlight(terms []string) []SearchHighlight {
+	var highlights []SearchHighlight
+	if snippet, ok := HighlightText(m.Title, terms, 0); ok {
+		highlights = append(highlights, SearchHighlight{Field: "title", Snippet: snippet})
+	}
+	if snippet, ok := HighlightText(m.Synopsis, terms, snippetRadius); ok {
+		highlights = append(highlights, SearchHighlight{Field: "synopsis", Snippet: snippet})
+	}
+	return highlights
+}
+
+// foldRune é a versão de FoldText para um único caractere, preservando a posição de cada letra no texto.
+func foldRune(r rune) rune {
+	decomposed := []rune(norm.NFD.String(string(r)))
+	return unicode.ToLower(decomposed[0])
+}
@@ -0,0 +1,23 @@
+package domain
+
+// SeedReport resume uma carga do arquivo de seed, em que cada filme é identificado pelo SourceID.
+type SeedReport struct {
+	Mode    ImportMode
+	Created int
+	// Updated conta, no upsert, os filmes que já estavam no catálogo e receberam os campos do arquivo.
+	Updated int
+	// Linked conta os filmes que já estavam no catálogo sem SourceID, com o mesmo título e ano, e passaram a tê-lo.
+	// No upsert eles também recebem os campos do arquivo.
+	Linked    int
+	Unchanged int
+	// Skipped conta os filmes novos que não foram gravados porque o título e o ano já são de outro filme do
+	// arquivo, ou porque o filme com esse SourceID está na lixeira.
+	Skipped int
+	// Rejected traz os filmes recusados; Line é a posição do filme no arquivo, a partir de 1.
+	Rejected []ImportRowResult
+}
+
+// Reject acrescenta um filme recusado ao relatório.
+func (r *SeedReport) Reject(line int64, movie Movie, err error) {
+	r.Rejected = append(r.Rejected, ImportRowResult{Line: line, Movie: &movie, Err: err})
+}
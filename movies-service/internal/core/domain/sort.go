@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SortableFields são os campos aceitos em order_by. O ID entra sempre como último critério, para desempate.
+var SortableFields = []string{"title", "year", "runtime_minutes", "release_date"}
+
+// SortField é um critério de ordenação.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// SortOrder é a ordenação completa de uma listagem, do critério mais importante para o menos importante.
+type SortOrder []SortField
+
+// ParseOrderBy interpreta a sintaxe "-year,title": campos separados por vírgula,
+// com "-" na frente para ordem decrescente. Uma string vazia mantém a ordem por ID.
+func ParseOrderBy(value string) (SortOrder, error) {
+	var order SortOrder
+	if strings.TrimSpace(value) == "" {
+		return order, nil
+	}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !slices.Contains(SortableFields, field.Field) {
+			return nil, fmt.Errorf("%w: campo %q não pode ser usado na ordenação (aceitos: %s)",
+				ErrInvalidOrderBy, field.Field, strings.Join(SortableFields, ", "))
+		}
+		if seen[field.Field] {
+			return nil, fmt.Errorf("%w: campo %q repetido", ErrInvalidOrderBy, field.Field)
+		}
+		seen[field.Field] = true
+		order = append(order, field)
+	}
+	return order, nil
+}
+
+// String devolve a forma canônica aceita por ParseOrderBy.
+func (o SortOrder) String() string {
+	parts := make([]string, len(o))
+	for i, field := range o {
+		parts[i] = field.Field
+		if field.Desc {
+			parts[i] = "-" + parts[i]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// SortValue devolve o valor de um campo ordenável do filme. Campos opcionais vazios viram nil,
+// já que não são gravados no banco e por isso ordenam como ausentes. O título ordena pelo SortTitle.
+func (m Movie) SortValue(field string) any {
+	switch field {
+	case "title":
+		return SortTitle(m.Title)
+	case "year":
+		return m.Year
+	case "runtime_minutes":
+		if m.RuntimeMinutes == 0 {
+			return nil
+		}
+		return m.RuntimeMinutes
+	case "release_date":
+		if m.ReleaseDate == nil {
+			return nil
+		}
+		return *m.ReleaseDate
+	}
+	return nil
+}
+
+// CursorFor monta o cursor que aponta para logo depois do filme m na ordenação informada.
+func (o SortOrder) CursorFor(m Movie) PageCursor {
+	cursor := PageCursor{OrderBy: o.String(), ID: m.ID}
+	for _, field := range o {
+		cursor.Values = append(cursor.Values, m.SortValue(field.Field))
+	}
+	return cursor
+}
+
+// CursorValues valida um cursor contra a ordenação da consulta e devolve os valores
+// com os tipos do domínio (o JSON do token transforma números em float64 e datas em texto).
+func (o SortOrder) CursorValues(cursor PageCursor) ([]any, error) {
+	if cursor.OrderBy != o.String() || len(cursor.Values) != len(o) {
+		return nil, fmt.Errorf("%w: o token foi gerado para outra ordenação", ErrInvalidPageToken)
+	}
+	values := make([]any, len(o))
+	for i, field := range o {
+		raw := cursor.Values[i]
+		if raw == nil {
+			values[i] = nil
+			continue
+		}
+		switch field.Field {
+		case "title":
+			value, ok := raw.(string)
+			if !ok {
+				return nil, ErrInvalidPageToken
+			}
+			values[i] = value
+		case "year", "runtime_minutes":
+			value, ok := raw.(float64)
+			if !ok {
+				return nil, ErrInvalidPageToken
+			}
+			values[i] = int(value)
+		case "release_date":
+			text, ok := raw.(string)
+			if !ok {
+				return nil, ErrInvalidPageToken
+			}
+			value, err := time.Parse(time.RFC3339Nano, text)
+			if err != nil {
+				return nil, ErrInvalidPageToken
+			}
+			values[i] = value
+		}
+	}
+	return values, nil
+}
+
+// Compare ordena dois filmes segundo a ordenação, desempatando pelo ID. Valores ausentes vêm antes
+// de qualquer valor na ordem crescente (e depois, na decrescente), como no MongoDB.
+func (o SortOrder) Compare(a, b Movie) int {
+	return o.CompareToCursor(a, o.CursorFor(b))
+}
+
+// CompareToCursor compara um filme com a posição apontada por um cursor já validado:
+// um resultado positivo indica que o filme vem depois do cursor.
+func (o SortOrder) CompareToCursor(m Movie, cursor PageCursor) int {
+	for i, field := range o {
+		c := compareSortValues(m.SortValue(field.Field), cursor.Values[i])
+		if field.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(m.ID, cursor.ID)
+}
+
+// compareSortValues compara dois valores de um mesmo campo ordenável; nil é menor que qualquer valor.
+func compareSortValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int:
+		return cmp.Compare(a, b.(int))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expected      SortOrder
+		expectedError error
+	}{
+		{name: "Vazio mantém a ordem por ID", input: "", expected: nil},
+		{name: "Vários campos", input: "-year, title", expected: SortOrder{{Field: "year", Desc: true}, {Field: "title"}}},
+		{name: "Campo não permitido", input: "synopsis", expectedError: ErrInvalidOrderBy},
+		{name: "Campo repetido", input: "year,-year", expectedError: ErrInvalidOrderBy},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			order, err := ParseOrderBy(tc.input)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, order)
+		})
+	}
+}
+
+func TestSortOrder_CursorRoundTrip(t *testing.T) {
+	order := SortOrder{{Field: "release_date", Desc: true}, {Field: "year"}, {Field: "runtime_minutes"}}
+	releaseDate := time.Date(2019, time.August, 29, 0, 0, 0, 0, time.UTC)
+	movie := Movie{ID: "64b7f0c2a1b2c3d4e5f60718", Title: "Bacurau", Year: 2019, ReleaseDate: &releaseDate}
+
+	cursor, err := DecodePageToken(EncodePageToken(order.CursorFor(movie)))
+	assert.NoError(t, err)
+
+	values, err := order.CursorValues(cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{releaseDate, 2019, nil}, values)
+
+	_, err = SortOrder{{Field: "title"}}.CursorValues(cursor)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// titleYearSuffix casa com o ano entre parênteses no fim do título, como em "La sortie des usines Lumière (1895)".
+var titleYearSuffix = regexp.MustCompile(`\s*\((\d{4})\)$`)
+
+// sortArticles são os artigos que SortTitle ignora no começo do título. "l'" é tratado à parte, por vir colado à palavra.
+var sortArticles = map[string]bool{
+	"the": true, "a": true, "an": true,
+	"o": true, "os": true, "um": true, "uma": true,
+	"la": true, "le": true, "les": true, "un": true, "une": true,
+	"el": true, "los": true, "las": true, "una": true,
+	"il": true, "lo": true,
+}
+
+// SplitTitleYear separa o ano entre parênteses do fim do título. Sem o sufixo, ou se o título for só o ano,
+// devolve o título como veio e zero.
+func SplitTitleYear(title string) (string, int) {
+	match := titleYearSuffix.FindStringSubmatchIndex(title)
+	if match == nil || strings.TrimSpace(title[:match[0]]) == "" {
+		return title, 0
+	}
+	year, _ := strconv.Atoi(title[match[2]:match[3]])
+	return strings.TrimSpace(title[:match[0]]), year
+}
+
+// NormalizeTitle tira do título o ano entre parênteses quando ele confere com o ano do filme, que é preenchido
+// com o do título quando está vazio. Um ano diferente fica no título e é recusado por Validate.
+func (m *Movie) NormalizeTitle() {
+	title, year := SplitTitleYear(m.Title)
+	if year == 0 {
+		return
+	}
+	if m.Year == 0 {
+		m.Year = year
+	}
+	if year == m.Year {
+		m.Title = title
+	}
+}
+
+// SortTitle é a forma do título usada na ordenação: a de TitleKey sem o artigo inicial, para que
+// "The Arrival of a Train" fique entre os títulos com "a" e "L'Atalante" entre os com "a".
+func SortTitle(title string) string {
+	key := TitleKey(title)
+	if rest, ok := strings.CutPrefix(key, "l'"); ok && strings.TrimSpace(rest) != "" {
+		return strings.TrimSpace(rest)
+	}
+	if article, rest, ok := strings.Cut(key, " "); ok && sortArticles[article] {
+		return rest
+	}
+	return key
+}
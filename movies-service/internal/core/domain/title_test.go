@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTitleYear(t *testing.T) {
+	testCases := []struct {
+		title         string
+		expectedTitle string
+		expectedYear  int
+	}{
+		{title: "La sortie des usines Lumière (1895)", expectedTitle: "La sortie des usines Lumière", expectedYear: 1895},
+		{title: "Bacurau", expectedTitle: "Bacurau", expectedYear: 0},
+		{title: "Blade Runner 2049", expectedTitle: "Blade Runner 2049", expectedYear: 0},
+		{title: "(1895)", expectedTitle: "(1895)", expectedYear: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			title, year := SplitTitleYear(tc.title)
+			assert.Equal(t, tc.expectedTitle, title)
+			assert.Equal(t, tc.expectedYear, year)
+		})
+	}
+}
+
+func TestMovieNormalizeTitle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		movie    Movie
+		expected Movie
+	}{
+		{name: "Ano confere", movie: Movie{Title: "Bacurau (2019)", Year: 2019}, expected: Movie{Title: "Bacurau", Year: 2019}},
+		{name: "Ano vazio", movie: Movie{Title: "Bacurau (2019)"}, expected: Movie{Title: "Bacurau", Year: 2019}},
+		{name: "Ano diferente", movie: Movie{Title: "Bacurau (2018)", Year: 2019}, expected: Movie{Title: "Bacurau (2018)", Year: 2019}},
+		{name: "Sem sufixo", movie: Movie{Title: "Bacurau", Year: 2019}, expected: Movie{Title: "Bacurau", Year: 2019}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.movie.NormalizeTitle()
+			assert.Equal(t, tc.expected, tc.movie)
+		})
+	}
+}
+
+func TestMovieValidate_TitleYearMismatch(t *testing.T) {
+	movie := Movie{Title: "Bacurau (2018)", Year: 2019}
+	movie.Normalize()
+
+	err := movie.Validate()
+	assert.ErrorIs(t, err, ErrInvalidMovie)
+	assert.Contains(t, err.Error(), "title: O ano no fim do título (2018) não confere com o ano do filme (2019)")
+}
+
+func TestSortTitle(t *testing.T) {
+	assert.Equal(t, "arrival of a train", SortTitle("The Arrival of a Train"))
+	assert.Equal(t, "sortie des usines lumiere", SortTitle("La sortie des usines Lumière"))
+	assert.Equal(t, "atalante", SortTitle("L'Atalante"))
+	assert.Equal(t, "auto da compadecida", SortTitle("O Auto da Compadecida"))
+	assert.Equal(t, "bacurau", SortTitle("Bacurau"))
+	assert.Equal(t, "the", SortTitle("The"))
+	assert.Equal(t, "theremin", SortTitle("Theremin"))
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Regras de validação dos filmes. 1888 é o ano do filme mais antigo preservado (Roundhay Garden Scene).
+const (
+	MinMovieYear   = 1888
+	MaxTitleLength = 200
+)
+
+// FieldViolation é um problema em um campo do filme, com o nome do campo como no JSON e no Protobuf.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError reúne as violações encontradas em um filme. Embrulha ErrInvalidMovie, então
+// errors.Is(err, ErrInvalidMovie) é verdadeiro, e errors.As permite chegar às violações de cada campo.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		descriptions[i] = violation.Field + ": " + violation.Description
+	}
+	return ErrInvalidMovie.Error() + ": " + strings.Join(descriptions, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidMovie
+}
+
+// Normalize remove os espaços nas pontas dos textos do filme, o ano repetido no fim do título (ver NormalizeTitle)
+// e os itens vazios das listas, e padroniza os identificadores externos (ver NormalizeExternalID).
+func (m *Movie) Normalize() {
+	m.Title = strings.TrimSpace(m.Title)
+	m.NormalizeTitle()
+	m.Synopsis = strings.TrimSpace(m.Synopsis)
+	m.OriginalLanguage = strings.TrimSpace(m.OriginalLanguage)
+	for _, list := range []*[]string{&m.Genres, &m.Directors, &m.Cast} {
+		if *list == nil {
+			continue
+		}
+		items := make([]string, 0, len(*list))
+		for _, item := range *list {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*list = items
+	}
+	m.ExternalIDs = normalizeExternalIDs(m.ExternalIDs)
+}
+
+// Validate confere as regras dos campos informados, ou de todos os campos editáveis se nenhum for informado.
+// Espera um filme já normalizado. Devolve um *ValidationError com todas as violações encontradas.
+func (m Movie) Validate(fields ...string) error {
+	checks := func(field string) bool {
+		return len(fields) == 0 || slices.Contains(fields, field)
+	}
+
+	var violations []FieldViolation
+	if checks("title") {
+		switch length := utf8.RuneCountInString(m.Title); {
+		case length == 0:
+			violations = append(violations, FieldViolation{Field: "title", Description: "O título é obrigatório"})
+		case length > MaxTitleLength:
+			violations = append(violations, FieldViolation{Field: "title", Description: fmt.Sprintf("O título deve ter no máximo %d caracteres", MaxTitleLength)})
+		}
+		if _, year := SplitTitleYear(m.Title); year != 0 && year != m.Year {
+			violations = append(violations, FieldViolation{Field: "title", Description: fmt.Sprintf("O ano no fim do título (%d) não confere com o ano do filme (%d)", year, m.Year)})
+		}
+	}
+	if checks("year") {
+		if maxYear := time.Now().Year() + 1; m.Year < MinMovieYear || m.Year > maxYear {
+			violations = append(violations, FieldViolation{Field: "year", Description: fmt.Sprintf("O ano deve estar entre %d e %d", MinMovieYear, maxYear)})
+		}
+	}
+	if checks("runtime_minutes") && m.RuntimeMinutes < 0 {
+		violations = append(violations, FieldViolation{Field: "runtime_minutes", Description: "A duração não pode ser negativa"})
+	}
+	if checks("external_ids") {
+		violations = append(violations, externalIDViolations(m.ExternalIDs)...)
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovieValidate_OnlyInformedFields(t *testing.T) {
+	movie := Movie{Title: "", Year: 1500}
+
+	err := movie.Validate("title")
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []FieldViolation{{Field: "title", Description: "O título é obrigatório"}}, validationErr.Violations)
+	}
+	assert.ErrorIs(t, err, ErrInvalidMovie)
+	assert.Contains(t, err.Error(), "title: O título é obrigatório")
+
+	assert.NoError(t, movie.Validate("genres"))
+	assert.Len(t, movie.Validate().(*ValidationError).Violations, 2)
+}
+
+func TestMovieNormalize(t *testing.T) {
+	movie := Movie{Title: "  Bacurau \n", Genres: []string{" Drama", "  "}, OriginalLanguage: " pt "}
+	movie.Normalize()
+
+	assert.Equal(t, Movie{Title: "Bacurau", Genres: []string{"Drama"}, OriginalLanguage: "pt"}, movie)
+}
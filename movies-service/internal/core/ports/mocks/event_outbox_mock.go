@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventOutboxMock struct {
+	mock.Mock
+}
+
+func (m *EventOutboxMock) Publish(ctx context.Context, event domain.MovieEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *EventOutboxMock) Claim(ctx context.Context, now, leaseUntil time.Time) (*domain.OutboxEntry, error) {
+	args := m.Called(ctx, now, leaseUntil)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OutboxEntry), args.Error(1)
+}
+
+func (m *EventOutboxMock) MarkSent(ctx context.Context, eventID string, sentAt time.Time) error {
+	args := m.Called(ctx, eventID, sentAt)
+	return args.Error(0)
+}
+
+func (m *EventOutboxMock) Reschedule(ctx context.Context, eventID string, attempts int, next time.Time, lastErr string) error {
+	args := m.Called(ctx, eventID, attempts, next, lastErr)
+	return args.Error(0)
+}
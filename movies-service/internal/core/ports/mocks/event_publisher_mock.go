@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventPublisherMock struct {
+	mock.Mock
+}
+
+func (m *EventPublisherMock) Publish(ctx context.Context, event domain.MovieEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
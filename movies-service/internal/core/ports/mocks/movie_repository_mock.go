@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 	"github.com/stretchr/testify/mock"
@@ -21,12 +22,12 @@ func (m *MovieRepositoryMock) Get(ctx context.Context, id string) (*domain.Movie
 	
 }
 
-func (m *MovieRepositoryMock) GetAll(ctx context.Context, limit, offset int64) ([]domain.Movie, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MovieRepositoryMock) GetAll(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]domain.Movie), args.Error(1)
+	return args.Get(0).(*domain.MoviePage), args.Error(1)
 }
 
 func (m *MovieRepositoryMock) Save(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
@@ -37,7 +38,87 @@ func (m *MovieRepositoryMock) Save(ctx context.Context, movie domain.Movie) (*do
 	return args.Get(0).(*domain.Movie), args.Error(1)
 }
 
-func (m *MovieRepositoryMock) Delete(ctx context.Context, id string) error {
+func (m *MovieRepositoryMock) Delete(ctx context.Context, id string, expectedVersion int64) (*domain.Movie, error) {
+	args := m.Called(ctx, id, expectedVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Movie), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) Restore(ctx context.Context, id string) (*domain.Movie, error) {
 	args := m.Called(ctx, id)
-	return args.Error(0)
-}
\ No newline at end of file
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Movie), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.Movie, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Movie), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) GetMany(ctx context.Context, ids []string) ([]domain.Movie, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Movie), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) FindByTitle(ctx context.Context, title string, year int) (*domain.Movie, error) {
+	args := m.Called(ctx, title, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Movie), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) FindBySourceIDs(ctx context.Context, sourceIDs []string) ([]domain.Movie, error) {
+	args := m.Called(ctx, sourceIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Movie), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) FindByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error) {
+	args := m.Called(ctx, provider, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Movie), args.Error(1)
+}
+
+// Stream entrega a fn os filmes configurados no primeiro retorno e devolve o erro do segundo.
+func (m *MovieRepositoryMock) Stream(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error {
+	args := m.Called(ctx, query)
+	if movies, ok := args.Get(0).([]domain.Movie); ok {
+		for _, movie := range movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MovieRepositoryMock) InsertMany(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, movies)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
+
+func (m *MovieRepositoryMock) LinkSources(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, movies)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MovieRevisionStoreMock struct {
+	mock.Mock
+}
+
+func (m *MovieRevisionStoreMock) Append(ctx context.Context, revision domain.MovieRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+func (m *MovieRevisionStoreMock) List(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error) {
+	args := m.Called(ctx, movieID, beforeVersion, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.MovieRevision), args.Error(1)
+}
+
+func (m *MovieRevisionStoreMock) Get(ctx context.Context, movieID string, version int64) (*domain.MovieRevision, error) {
+	args := m.Called(ctx, movieID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MovieRevision), args.Error(1)
+}
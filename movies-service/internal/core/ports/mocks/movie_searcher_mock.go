@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MovieSearcherMock struct {
+	mock.Mock
+}
+
+func (m *MovieSearcherMock) Search(ctx context.Context, query string, limit int64) ([]domain.SearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SearchResult), args.Error(1)
+}
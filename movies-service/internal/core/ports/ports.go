@@ -2,21 +2,117 @@ package ports
 
 import (
 	"context"
+	"time"
+
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 )
 
-// MovieRepository é a "Porta de Saída" para a persistência de dados.
+// MovieRepository é a "Porta de Saída" para a persistência de dados. Toda escrita incrementa a versão do filme;
+// a remoção é lógica, e o filme na lixeira não ocupa título e ano, SourceID nem ids externos.
 type MovieRepository interface {
 	Get(ctx context.Context, id string) (*domain.Movie, error)
-    GetAll(ctx context.Context, limit, offset int64) ([]domain.Movie, error) 
+    GetAll(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) 
+	// Save cria o filme na versão 1 ou, para um filme existente, só grava se a versão persistida for
+	// movie.Version; senão devolve domain.ErrVersionConflict.
 	Save(ctx context.Context, movie domain.Movie) (*domain.Movie, error)
-	Delete(ctx context.Context, id string) error
+	// Delete leva o filme para a lixeira e o devolve como ficou. Com expectedVersion maior que zero, só o remove se
+	// a versão persistida for essa, na mesma operação; senão devolve domain.ErrVersionMismatch.
+	Delete(ctx context.Context, id string, expectedVersion int64) (*domain.Movie, error)
+	// Restore tira o filme da lixeira e o devolve como ficou, ou domain.ErrMovieAlreadyExists se outro filme tomou
+	// o título e o ano, o SourceID ou um id externo dele.
+	Restore(ctx context.Context, id string) (*domain.Movie, error)
+	// Purge remove de vez até limit filmes que foram para a lixeira antes de deletedBefore, os mais antigos
+	// primeiro, e os devolve como estavam.
+	Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.Movie, error)
+	// GetMany devolve, em qualquer ordem, os filmes encontrados; IDs inexistentes, na lixeira ou inválidos são ignorados.
+	GetMany(ctx context.Context, ids []string) ([]domain.Movie, error)
+	// InsertMany cria os filmes de uma vez e devolve um resultado por filme, na mesma ordem; um repetido recebe
+	// domain.ErrMovieAlreadyExists. O erro de retorno fica para falhas que impedem o lote inteiro.
+	InsertMany(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error)
+	// LinkSources grava o SourceID e os ExternalIDs de filmes que ainda não têm SourceID, se a versão persistida de
+	// cada um for movie.Version. Devolve um resultado por filme, na mesma ordem: o filme como ficou,
+	// domain.ErrVersionConflict se ele mudou, foi para a lixeira ou já tem SourceID, ou domain.ErrMovieAlreadyExists.
+	// Não grava revisão; o evento da alteração é publicado pelo serviço, na mesma transação quando há uma.
+	LinkSources(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error)
+	// Stream chama fn para cada filme da consulta, na ordem pedida, sem carregar o resultado inteiro em memória;
+	// só Filter, OrderBy e Deleted são usados. Um erro de fn interrompe a leitura e é devolvido.
+	Stream(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error
+	// FindByTitle devolve o filme fora da lixeira com o título normalizado (ver domain.TitleKey) e o ano, ou
+	// domain.ErrMovieNotFound.
+	FindByTitle(ctx context.Context, title string, year int) (*domain.Movie, error)
+	// FindBySourceIDs devolve, em qualquer ordem, os filmes com um dos SourceID, inclusive os da lixeira.
+	FindBySourceIDs(ctx context.Context, sourceIDs []string) ([]domain.Movie, error)
+	// FindByExternalID devolve o filme fora da lixeira com o id externo do provedor, ou domain.ErrMovieNotFound;
+	// provider e id chegam normalizados (ver domain.ParseExternalID).
+	FindByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error)
+}
+
+// MovieSearcher é a "Porta de Saída" para a busca textual. Fica separada do MovieRepository
+// para que cada adaptador ofereça a própria implementação (índice de texto, motor de busca externo etc.).
+type MovieSearcher interface {
+	Search(ctx context.Context, query string, limit int64) ([]domain.SearchResult, error)
+}
 
+// MovieRevisionStore é a "Porta de Saída" para o histórico de alterações dos filmes.
+// Cada revisão é identificada pelo filme e pela versão que a escrita produziu. List devolve as mais recentes
+// primeiro; com beforeVersion maior que zero, só as anteriores a essa versão.
+type MovieRevisionStore interface {
+	Append(ctx context.Context, revision domain.MovieRevision) error
+	List(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error)
+	Get(ctx context.Context, movieID string, version int64) (*domain.MovieRevision, error)
 }
+
+// EventPublisher é a "Porta de Saída" para os eventos de fato: cada um anuncia uma escrita já aplicada, para
+// outros sistemas. Publish devolve erro quando o evento não pôde ser enviado.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.MovieEvent) error
+}
+
+// EventOutbox é a "Porta de Saída" para a caixa de saída dos eventos (transactional outbox). Publish grava o evento
+// como pendente, na transação do contexto, para ser publicado depois. Claim reserva o pendente mais antigo cuja
+// próxima tentativa já chegou em now, adiando-a para leaseUntil numa única operação atômica, para que nenhuma outra
+// instância o pegue enquanto ele é publicado; sem pendentes, devolve nil. MarkSent tira o evento dos pendentes;
+// Reschedule registra a tentativa que falhou e adia a próxima para next.
+type EventOutbox interface {
+	EventPublisher
+	Claim(ctx context.Context, now, leaseUntil time.Time) (*domain.OutboxEntry, error)
+	MarkSent(ctx context.Context, eventID string, sentAt time.Time) error
+	Reschedule(ctx context.Context, eventID string, attempts int, next time.Time, lastErr string) error
+}
+
+// Transactor é a "Porta de Saída" para transações do banco. WithinTransaction executa fn com um contexto
+// transacional: as escritas feitas pelos adaptadores com esse contexto são confirmadas juntas se fn voltar sem erro
+// e descartadas se não. fn pode ser executada mais de uma vez quando o banco pede para repetir a transação.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Locker é a "Porta de Saída" para travas compartilhadas entre as instâncias do serviço.
+// TryLock obtém a trava name, ou devolve domain.ErrLockHeld se outra instância já a tem. A trava expira sozinha
+// depois de ttl, para que uma instância que caiu não a prenda para sempre; unlock a libera antes disso.
+type Locker interface {
+	TryLock(ctx context.Context, name string, ttl time.Duration) (unlock func(ctx context.Context) error, err error)
+}
+
 // MovieService é a "Porta de Entrada" para a lógica de negócio.
 type MovieService interface {
 	GetMovie(ctx context.Context, id string) (*domain.Movie, error)
-    ListMovies(ctx context.Context, limit, offset int64) ([]domain.Movie, error) 
-	CreateMovie(ctx context.Context, title string, year int) (*domain.Movie, error)
-	DeleteMovie(ctx context.Context, id string) error
+	GetMovieByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error)
+    ListMovies(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) 
+	CreateMovie(ctx context.Context, movie domain.Movie) (*domain.Movie, error)
+	UpdateMovie(ctx context.Context, movie domain.Movie, fields []string) (*domain.Movie, error)
+	ValidateMovie(ctx context.Context, movie domain.Movie, fields []string) (*domain.Movie, error)
+	DeleteMovie(ctx context.Context, id string, expectedVersion int64) error
+	SearchMovies(ctx context.Context, query string, limit int64) ([]domain.SearchResult, error)
+	ListDeletedMovies(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error)
+	RestoreMovie(ctx context.Context, id string) (*domain.Movie, error)
+	PurgeDeletedMovies(ctx context.Context, retention time.Duration) (int64, error)
+	ListMovieRevisions(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error)
+	RevertMovie(ctx context.Context, id string, version int64) (*domain.Movie, error)
+	BatchGetMovies(ctx context.Context, ids []string) ([]domain.BatchResult, error)
+	BulkCreateMovies(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error)
+	BulkDeleteMovies(ctx context.Context, ids []string) ([]domain.BatchResult, error)
+	ExportMovies(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error
+	ImportMovies(ctx context.Context, mode domain.ImportMode, next func() (domain.ImportRow, error)) (*domain.ImportReport, error)
+	SeedMovies(ctx context.Context, mode domain.ImportMode, movies []domain.Movie) (*domain.SeedReport, error)
 }
\ No newline at end of file
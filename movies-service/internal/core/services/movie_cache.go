@@ -0,0 +1,253 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+)
+
+// CacheStats são os contadores do cache de filmes desde a criação.
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// CachedMovieService é um decorator de `ports.MovieService` que guarda o resultado de GetMovie em um LRU com
+// validade por entrada. Buscas simultâneas pelo mesmo filme ausente do cache viram uma única leitura no banco.
+// As escritas feitas por ele (pela API ou pela fila) descartam as entradas afetadas; as feitas por fora, como
+// o subcomando seed ou outra réplica do serviço, aparecem depois do TTL. GetMovie pode, portanto, devolver uma
+// versão antiga do filme por até um TTL; quem precisa da versão atual pede uma leitura sem cache com
+// domain.WithFreshRead, e as escritas sempre conferem a versão no banco.
+// Toda escrita nova de `ports.MovieService` precisa ser sobrescrita aqui para invalidar o cache.
+type CachedMovieService struct {
+	ports.MovieService
+	cache  *movieCache
+	group  singleflight.Group
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCachedMovieService envolve o serviço com um cache de até size filmes, cada um válido por ttl.
+func NewCachedMovieService(next ports.MovieService, size int, ttl time.Duration) *CachedMovieService {
+	return &CachedMovieService{MovieService: next, cache: newMovieCache(size, ttl)}
+}
+
+// Stats devolve os acertos, as falhas e o número de filmes no cache.
+func (s *CachedMovieService) Stats() CacheStats {
+	return CacheStats{Hits: s.hits.Load(), Misses: s.misses.Load(), Entries: s.cache.len()}
+}
+
+func (s *CachedMovieService) GetMovie(ctx context.Context, id string) (*domain.Movie, error) {
+	if domain.FreshReadFromContext(ctx) {
+		return s.MovieService.GetMovie(ctx, id)
+	}
+	if movie, ok := s.cache.get(id); ok {
+		s.hits.Add(1)
+		return movie, nil
+	}
+	s.misses.Add(1)
+
+	// A leitura é compartilhada entre quem espera pelo mesmo id, então não é cancelada junto com quem a iniciou.
+	loadCtx := context.WithoutCancel(ctx)
+	result := s.group.DoChan(id, func() (any, error) {
+		generation := s.cache.currentGeneration()
+		movie, err := s.MovieService.GetMovie(loadCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.add(id, movie, generation)
+		return movie, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		// Quem esperou pela mesma leitura recebe o mesmo ponteiro, então cada um leva sua cópia.
+		movie := r.Val.(*domain.Movie).Clone()
+		return &movie, nil
+	}
+}
+
+func (s *CachedMovieService) CreateMovie(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	created, err := s.MovieService.CreateMovie(ctx, movie)
+	if created != nil {
+		s.invalidate(created.ID)
+	}
+	return created, err
+}
+
+func (s *CachedMovieService) UpdateMovie(ctx context.Context, movie domain.Movie, fields []string) (*domain.Movie, error) {
+	defer s.invalidate(movie.ID)
+	return s.MovieService.UpdateMovie(ctx, movie, fields)
+}
+
+func (s *CachedMovieService) DeleteMovie(ctx context.Context, id string, expectedVersion int64) error {
+	defer s.invalidate(id)
+	return s.MovieService.DeleteMovie(ctx, id, expectedVersion)
+}
+
+func (s *CachedMovieService) RestoreMovie(ctx context.Context, id string) (*domain.Movie, error) {
+	defer s.invalidate(id)
+	return s.MovieService.RestoreMovie(ctx, id)
+}
+
+func (s *CachedMovieService) RevertMovie(ctx context.Context, id string, version int64) (*domain.Movie, error) {
+	defer s.invalidate(id)
+	return s.MovieService.RevertMovie(ctx, id, version)
+}
+
+func (s *CachedMovieService) BulkCreateMovies(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	results, err := s.MovieService.BulkCreateMovies(ctx, movies)
+	for _, result := range results {
+		if result.Movie != nil {
+			s.invalidate(result.Movie.ID)
+		}
+	}
+	return results, err
+}
+
+func (s *CachedMovieService) BulkDeleteMovies(ctx context.Context, ids []string) ([]domain.BatchResult, error) {
+	defer s.invalidate(ids...)
+	return s.MovieService.BulkDeleteMovies(ctx, ids)
+}
+
+// ImportMovies e SeedMovies podem alterar qualquer filme do catálogo, então esvaziam o cache.
+func (s *CachedMovieService) ImportMovies(ctx context.Context, mode domain.ImportMode, next func() (domain.ImportRow, error)) (*domain.ImportReport, error) {
+	if mode != domain.ImportDryRun {
+		defer s.invalidateAll()
+	}
+	return s.MovieService.ImportMovies(ctx, mode, next)
+}
+
+func (s *CachedMovieService) SeedMovies(ctx context.Context, mode domain.ImportMode, movies []domain.Movie) (*domain.SeedReport, error) {
+	defer s.invalidateAll()
+	return s.MovieService.SeedMovies(ctx, mode, movies)
+}
+
+// invalidate descarta os filmes do cache e as leituras em andamento deles, que podem trazer o valor antigo.
+func (s *CachedMovieService) invalidate(ids ...string) {
+	s.cache.remove(ids...)
+	for _, id := range ids {
+		s.group.Forget(id)
+	}
+}
+
+func (s *CachedMovieService) invalidateAll() {
+	for _, id := range s.cache.clear() {
+		s.group.Forget(id)
+	}
+}
+
+// movieCache é um LRU de filmes por id, com validade por entrada.
+type movieCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	// generation muda a cada invalidação; uma leitura iniciada antes dela não é guardada.
+	generation uint64
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	id        string
+	movie     *domain.Movie
+	expiresAt time.Time
+}
+
+func newMovieCache(size int, ttl time.Duration) *movieCache {
+	return &movieCache{size: size, ttl: ttl, order: list.New(), entries: map[string]*list.Element{}, now: time.Now}
+}
+
+// get devolve uma cópia do filme, com as listas e o mapa de ids externos, para que quem a recebe possa alterá-la
+// sem mudar o cache.
+func (c *movieCache) get(id string) (*domain.Movie, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, id)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	movie := entry.movie.Clone()
+	return &movie, true
+}
+
+func (c *movieCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// add guarda uma cópia do filme lido na geração informada, descartando o menos usado se o cache estiver cheio.
+func (c *movieCache) add(id string, movie *domain.Movie, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation || c.size <= 0 {
+		return
+	}
+	stored := movie.Clone()
+	entry := &cacheEntry{id: id, movie: &stored, expiresAt: c.now().Add(c.ttl)}
+	if element, ok := c.entries[id]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[id] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).id)
+	}
+}
+
+func (c *movieCache) remove(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, id := range ids {
+		if element, ok := c.entries[id]; ok {
+			c.order.Remove(element)
+			delete(c.entries, id)
+		}
+	}
+}
+
+// clear esvazia o cache e devolve os ids que estavam nele.
+func (c *movieCache) clear() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	ids := make([]string, 0, len(c.entries))
+	for id := range c.entries {
+		ids = append(ids, id)
+	}
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+	return ids
+}
+
+func (c *movieCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedMovieService_GetMovie(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.MovieRepositoryMock)
+	bacurau := &domain.Movie{ID: "a", Title: "Bacurau", Year: 2019, Version: 1}
+	mockRepo.On("Get", mock.Anything, "a").Return(bacurau, nil).Once()
+	mockRepo.On("Get", mock.Anything, "b").Return(nil, domain.ErrMovieNotFound).Twice()
+	service := NewCachedMovieService(NewMovieService(mockRepo), 10, time.Minute)
+
+	movie, err := service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, bacurau, movie)
+	movie.Title = "Alterado por quem leu"
+
+	movie, err = service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bacurau", movie.Title, "o cache devolve cópias")
+
+	// Erros não são guardados.
+	for range 2 {
+		_, err = service.GetMovie(ctx, "b")
+		assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	}
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Entries: 1}, service.Stats())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedMovieService_DeepCopies(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.MovieRepositoryMock)
+	loaded := &domain.Movie{ID: "a", Title: "Bacurau", Genres: []string{"Drama"}, Cast: []string{"Sônia Braga"}, ExternalIDs: map[string]string{"imdb": "tt2762506"}}
+	mockRepo.On("Get", mock.Anything, "a").Return(loaded, nil).Once()
+	service := NewCachedMovieService(NewMovieService(mockRepo), 10, time.Minute)
+
+	// Nem o filme devolvido na falha nem o que o repositório leu compartilham listas ou o mapa com o cache.
+	movie, err := service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	movie.Genres[0] = "Alterado por quem leu"
+	movie.ExternalIDs["imdb"] = "tt0000000"
+	loaded.Cast[0] = "Alterado pelo repositório"
+
+	movie, err = service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	movie.Genres = append(movie.Genres[:0], "Alterado de novo")
+	delete(movie.ExternalIDs, "imdb")
+
+	movie, err = service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Drama"}, movie.Genres)
+	assert.Equal(t, []string{"Sônia Braga"}, movie.Cast)
+	assert.Equal(t, map[string]string{"imdb": "tt2762506"}, movie.ExternalIDs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedMovieService_FreshRead(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockRepo.On("Get", mock.Anything, "a").Return(&domain.Movie{ID: "a", Version: 1}, nil).Once()
+	mockRepo.On("Get", mock.Anything, "a").Return(&domain.Movie{ID: "a", Version: 2}, nil).Once()
+	service := NewCachedMovieService(NewMovieService(mockRepo), 10, time.Minute)
+
+	_, err := service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	// Outra réplica alterou o filme: só a leitura sem cache vê a versão nova.
+	movie, err := service.GetMovie(domain.WithFreshRead(ctx), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), movie.Version)
+	movie, err = service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), movie.Version)
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Entries: 1}, service.Stats())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedMovieService_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockRepo.On("Get", mock.Anything, "a").Return(&domain.Movie{ID: "a", Title: "Bacurau", Version: 1}, nil).Once()
+	mockRepo.On("Delete", mock.Anything, "a", int64(0)).Return(&domain.Movie{ID: "a", Title: "Bacurau", Version: 2}, nil).Once()
+	mockRepo.On("Get", mock.Anything, "a").Return(nil, domain.ErrMovieNotFound).Once()
+	service := NewCachedMovieService(NewMovieService(mockRepo), 10, time.Minute)
+
+	_, err := service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	assert.NoError(t, service.DeleteMovie(ctx, "a", 0))
+	_, err = service.GetMovie(ctx, "a")
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedMovieService_ExpirationAndEviction(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockRepo.On("Get", mock.Anything, "a").Return(&domain.Movie{ID: "a"}, nil).Twice()
+	mockRepo.On("Get", mock.Anything, "b").Return(&domain.Movie{ID: "b"}, nil).Once()
+	mockRepo.On("Get", mock.Anything, "c").Return(&domain.Movie{ID: "c"}, nil).Once()
+	service := NewCachedMovieService(NewMovieService(mockRepo), 2, time.Minute)
+	now := time.Now()
+	service.cache.now = func() time.Time { return now }
+
+	// Com espaço para dois filmes, "b" é o menos usado quando "c" entra.
+	for _, id := range []string{"a", "b", "a", "c", "a"} {
+		_, err := service.GetMovie(ctx, id)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 3, Entries: 2}, service.Stats())
+
+	now = now.Add(time.Minute)
+	_, err := service.GetMovie(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), service.Stats().Misses)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedMovieService_CoalescesMisses(t *testing.T) {
+	const callers = 5
+	release := make(chan time.Time)
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockRepo.On("Get", mock.Anything, "a").WaitUntil(release).Return(&domain.Movie{ID: "a", Title: "Bacurau"}, nil).Once()
+	service := NewCachedMovieService(NewMovieService(mockRepo), 10, time.Minute)
+
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			movie, err := service.GetMovie(context.Background(), "a")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "Bacurau", movie.Title)
+			}
+		}()
+	}
+	assert.Eventually(t, func() bool { return service.Stats().Misses == callers }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	mockRepo.AssertExpectations(t)
+}
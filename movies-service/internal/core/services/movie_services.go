@@ -2,39 +2,1000 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
 )
 
+// Limites de resultados da busca textual.
+const (
+	defaultSearchLimit int64 = 20
+	maxSearchLimit     int64 = 100
+)
+
+// Limites de revisões por página do histórico.
+const (
+	defaultHistoryLimit int64 = 20
+	maxHistoryLimit     int64 = 100
+)
+
 // movieService é a implementação concreta da interface `ports.MovieService`.
 type movieService struct {
-	repo ports.MovieRepository
+	repo      ports.MovieRepository
+	searcher  ports.MovieSearcher
+	revisions ports.MovieRevisionStore
+	events    ports.EventPublisher
+	tx        ports.Transactor
+}
+
+// Option configura dependências opcionais do serviço de filmes.
+type Option func(*movieService)
+
+// WithSearcher habilita a busca textual usando o adaptador informado.
+func WithSearcher(searcher ports.MovieSearcher) Option {
+	return func(s *movieService) {
+		s.searcher = searcher
+	}
+}
+
+// WithRevisionStore habilita o histórico de alterações: toda escrita passa a gravar uma revisão no store informado.
+func WithRevisionStore(store ports.MovieRevisionStore) Option {
+	return func(s *movieService) {
+		s.revisions = store
+	}
+}
+
+// WithEventPublisher habilita os eventos de fato: toda escrita que gera revisão publica também um domain.MovieEvent.
+func WithEventPublisher(publisher ports.EventPublisher) Option {
+	return func(s *movieService) {
+		s.events = publisher
+	}
+}
+
+// WithTransactor faz cada escrita, a revisão e o evento dela serem gravados na mesma transação. Com um
+// ports.EventOutbox em WithEventPublisher, o evento não se perde se o serviço cair logo depois da escrita.
+func WithTransactor(tx ports.Transactor) Option {
+	return func(s *movieService) {
+		s.tx = tx
+	}
 }
 
 // NewMovieService é o "construtor" para o nosso serviço de filmes.
-func NewMovieService(repo ports.MovieRepository) ports.MovieService {
-	return &movieService{repo: repo}
+func NewMovieService(repo ports.MovieRepository, opts ...Option) ports.MovieService {
+	s := &movieService{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *movieService) GetMovie(ctx context.Context, id string) (*domain.Movie, error) {
 	return s.repo.Get(ctx, id)
 }
 
-func (s *movieService) ListMovies(ctx context.Context, limit, offset int64) ([]domain.Movie, error) {
-	return s.repo.GetAll(ctx, limit, offset)
+// GetMovieByExternalID busca o filme pelo id que ele tem no catálogo de outro provedor (imdb, tmdb, movielens).
+func (s *movieService) GetMovieByExternalID(ctx context.Context, provider, id string) (*domain.Movie, error) {
+	provider, id, err := domain.ParseExternalID(provider, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByExternalID(ctx, provider, id)
 }
 
-func (s *movieService) CreateMovie(ctx context.Context, title string, year int) (*domain.Movie, error) {
-	movie := domain.Movie{
-		Title:    title,
-		Year:     year,
+func (s *movieService) ListMovies(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) {
+	if err := query.Filter.Validate(); err != nil {
+		return nil, err
 	}
-	return s.repo.Save(ctx, movie)
+	return s.repo.GetAll(ctx, query)
 }
 
-func (s *movieService) DeleteMovie(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+// CreateMovie valida e persiste um novo filme. O ID é sempre gerado pelo repositório.
+func (s *movieService) CreateMovie(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	movie, err := prepareCreate(movie)
+	if err != nil {
+		return nil, err
+	}
+	return s.create(ctx, movie)
+}
+
+// create grava um filme já preparado e registra a criação.
+func (s *movieService) create(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	var created *domain.Movie
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Save(ctx, movie); err != nil {
+			return err
+		}
+		return s.record(ctx, newRevision(ctx, domain.RevisionCreate, domain.Movie{}, *created))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateMovie altera apenas os campos listados em fields; os demais mantêm o valor persistido.
+// Um movie.Version diferente de zero é a versão esperada pelo cliente. A gravação é condicionada à versão lida,
+// então uma escrita concorrente entre a leitura e o Save resulta em domain.ErrVersionConflict.
+func (s *movieService) UpdateMovie(ctx context.Context, movie domain.Movie, fields []string) (*domain.Movie, error) {
+	before, after, err := s.prepareUpdate(ctx, movie, fields)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, domain.RevisionUpdate, *before, *after)
 }
 
+// save grava a alteração do filme de before para after e registra a revisão com a ação informada.
+func (s *movieService) save(ctx context.Context, action domain.RevisionAction, before, after domain.Movie) (*domain.Movie, error) {
+	var saved *domain.Movie
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if saved, err = s.repo.Save(ctx, after); err != nil {
+			return err
+		}
+		return s.record(ctx, newRevision(ctx, action, before, *saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// ValidateMovie confere o filme com as mesmas regras de CreateMovie (movie.ID vazio) ou de UpdateMovie,
+// sem gravar nada. Devolve o filme como ficaria, para quem precisa validar antes de uma escrita assíncrona.
+func (s *movieService) ValidateMovie(ctx context.Context, movie domain.Movie, fields []string) (*domain.Movie, error) {
+	if movie.ID == "" {
+		prepared, err := prepareCreate(movie)
+		if err != nil {
+			return nil, err
+		}
+		return &prepared, nil
+	}
+	_, after, err := s.prepareUpdate(ctx, movie, fields)
+	return after, err
+}
+
+// prepareCreate normaliza e valida um filme novo.
+func prepareCreate(movie domain.Movie) (domain.Movie, error) {
+	movie.ID = ""
+	movie.Normalize()
+	if err := movie.Validate(); err != nil {
+		return domain.Movie{}, err
+	}
+	return movie, nil
+}
+
+// prepareUpdate lê o filme, confere a versão esperada e aplica a alteração, validando só os campos alterados:
+// um filme antigo com dados fora das regras atuais continua editável nos demais campos.
+// Devolve o filme como estava e como ficará.
+func (s *movieService) prepareUpdate(ctx context.Context, movie domain.Movie, fields []string) (before, after *domain.Movie, err error) {
+	current, err := s.repo.Get(ctx, movie.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if movie.Version != 0 && movie.Version != current.Version {
+		return nil, nil, domain.ErrVersionMismatch
+	}
+	previous := *current
+	if err := current.ApplyUpdate(movie, fields); err != nil {
+		return nil, nil, err
+	}
+	current.Normalize()
+	if err := current.Validate(fields...); err != nil {
+		return nil, nil, err
+	}
+	return &previous, current, nil
+}
+
+// DeleteMovie move o filme para a lixeira; ele pode ser restaurado até ser expurgado.
+// Um expectedVersion diferente de zero é a versão esperada pelo cliente: a remoção é condicionada a ela no
+// repositório, então uma escrita concorrente resulta em domain.ErrVersionMismatch.
+func (s *movieService) DeleteMovie(ctx context.Context, id string, expectedVersion int64) error {
+	_, err := s.deleteMovie(ctx, id, expectedVersion)
+	return err
+}
+
+// deleteMovie remove o filme, grava a revisão e devolve o filme como ficou na lixeira.
+func (s *movieService) deleteMovie(ctx context.Context, id string, expectedVersion int64) (*domain.Movie, error) {
+	var deleted *domain.Movie
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if deleted, err = s.repo.Delete(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+		before := *deleted
+		before.DeletedAt = nil
+		return s.record(ctx, newRevision(ctx, domain.RevisionDelete, before, *deleted))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// BatchGetMovies busca vários filmes de uma vez. Cada ID recebe o seu resultado, na ordem pedida:
+// o filme ou domain.ErrMovieNotFound.
+func (s *movieService) BatchGetMovies(ctx context.Context, ids []string) ([]domain.BatchResult, error) {
+	if len(ids) > domain.MaxBatchSize {
+		return nil, domain.ErrBatchTooLarge
+	}
+	results := make([]domain.BatchResult, len(ids))
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	movies, err := s.repo.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]domain.Movie, len(movies))
+	for _, movie := range movies {
+		found[movie.ID] = movie
+	}
+	for i, id := range ids {
+		switch movie, ok := found[id]; {
+		case id == "":
+			results[i].Err = domain.ErrMissingID
+		case ok:
+			results[i].Movie = &movie
+		default:
+			results[i].Err = domain.ErrMovieNotFound
+		}
+	}
+	return results, nil
+}
+
+// BulkCreateMovies cria vários filmes de uma vez, com as mesmas regras de CreateMovie. Os filmes inválidos
+// ou repetidos recebem o próprio erro e não impedem a criação dos demais.
+func (s *movieService) BulkCreateMovies(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	if len(movies) > domain.MaxBatchSize {
+		return nil, domain.ErrBatchTooLarge
+	}
+	results := make([]domain.BatchResult, len(movies))
+	valid := make([]domain.Movie, 0, len(movies))
+	positions := make([]int, 0, len(movies))
+	for i, movie := range movies {
+		prepared, err := prepareCreate(movie)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		valid = append(valid, prepared)
+		positions = append(positions, i)
+	}
+	if len(valid) == 0 {
+		return results, nil
+	}
+	if s.tx == nil {
+		inserted, err := s.repo.InsertMany(ctx, valid)
+		if err != nil {
+			return nil, err
+		}
+		for j, result := range inserted {
+			results[positions[j]] = result
+			if result.Movie != nil {
+				// Sem transação, record não falha: a falha ao publicar só vai para o log.
+				_ = s.record(ctx, newRevision(ctx, domain.RevisionCreate, domain.Movie{}, *result.Movie))
+			}
+		}
+		return results, nil
+	}
+
+	// Numa transação, um filme repetido desfaz o lote inteiro. Nesse caso cada filme é gravado de novo na sua
+	// própria transação, para que o repetido receba o erro sem impedir os demais.
+	inserted, err := s.insertMany(ctx, valid)
+	switch {
+	case err == nil:
+		for j, result := range inserted {
+			results[positions[j]] = result
+		}
+	case errors.Is(err, domain.ErrMovieAlreadyExists):
+		for j, movie := range valid {
+			results[positions[j]].Movie, results[positions[j]].Err = s.create(ctx, movie)
+		}
+	default:
+		return nil, err
+	}
+	return results, nil
+}
+
+// insertMany grava o lote com InsertMany, as revisões e os eventos na mesma transação. Se algum filme for recusado,
+// devolve o erro dele e a transação é desfeita.
+func (s *movieService) insertMany(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	var inserted []domain.BatchResult
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if inserted, err = s.repo.InsertMany(ctx, movies); err != nil {
+			return err
+		}
+		for _, result := range inserted {
+			if result.Err != nil {
+				return result.Err
+			}
+			if err := s.record(ctx, newRevision(ctx, domain.RevisionCreate, domain.Movie{}, *result.Movie)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+// BulkDeleteMovies move vários filmes para a lixeira. Cada ID recebe o seu resultado, na ordem pedida:
+// o filme como ficou ou o erro da remoção dele.
+func (s *movieService) BulkDeleteMovies(ctx context.Context, ids []string) ([]domain.BatchResult, error) {
+	if len(ids) > domain.MaxBatchSize {
+		return nil, domain.ErrBatchTooLarge
+	}
+	results := make([]domain.BatchResult, len(ids))
+	for i, id := range ids {
+		results[i].Movie, results[i].Err = s.deleteMovie(ctx, id, 0)
+	}
+	return results, nil
+}
+
+// ExportMovies entrega a fn, um a um, todos os filmes que atendem aos filtros, na ordenação pedida.
+// A paginação da consulta é ignorada: a exportação percorre o catálogo inteiro.
+func (s *movieService) ExportMovies(ctx context.Context, query domain.MovieQuery, fn func(domain.Movie) error) error {
+	if err := query.Filter.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Stream(ctx, query, fn)
+}
+
+// ImportMovies lê as linhas de next até io.EOF e aplica cada uma conforme o modo, com as mesmas regras
+// de CreateMovie e UpdateMovie. Uma linha recusada não impede as demais; o relatório traz o resultado de cada uma.
+// Um erro de next diferente de io.EOF interrompe a importação, e as linhas já gravadas continuam gravadas.
+func (s *movieService) ImportMovies(ctx context.Context, mode domain.ImportMode, next func() (domain.ImportRow, error)) (*domain.ImportReport, error) {
+	report := &domain.ImportReport{Mode: mode, Rows: []domain.ImportRowResult{}}
+	// No dry-run nada é gravado, então as linhas repetidas dentro do arquivo são conferidas aqui.
+	seen := map[string]bool{}
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result := domain.ImportRowResult{Line: row.Line, Err: row.Err}
+		if result.Err == nil {
+			result.Action, result.Movie, result.Err = s.importRow(ctx, mode, row.Movie, seen)
+		}
+		report.Add(result)
+	}
+}
+
+// importRow aplica uma linha válida conforme o modo e diz o que foi feito.
+func (s *movieService) importRow(ctx context.Context, mode domain.ImportMode, movie domain.Movie, seen map[string]bool) (domain.ImportAction, *domain.Movie, error) {
+	prepared, err := prepareCreate(movie)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch mode {
+	case domain.ImportInsert:
+		created, err := s.CreateMovie(ctx, prepared)
+		return domain.ImportCreated, created, err
+
+	case domain.ImportDryRun:
+		key := domain.TitleKey(prepared.Title) + "\x00" + strconv.Itoa(prepared.Year)
+		if seen[key] {
+			return "", nil, domain.ErrMovieAlreadyExists
+		}
+		if _, err := s.repo.FindByTitle(ctx, prepared.Title, prepared.Year); err == nil {
+			return "", nil, domain.ErrMovieAlreadyExists
+		} else if !errors.Is(err, domain.ErrMovieNotFound) {
+			return "", nil, err
+		}
+		seen[key] = true
+		return domain.ImportValid, &prepared, nil
+	}
+
+	existing, err := s.repo.FindByTitle(ctx, prepared.Title, prepared.Year)
+	if errors.Is(err, domain.ErrMovieNotFound) {
+		created, err := s.CreateMovie(ctx, prepared)
+		return domain.ImportCreated, created, err
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	after := *existing
+	if err := after.ApplyUpdate(prepared, nil); err != nil {
+		return "", nil, err
+	}
+	if len(domain.Diff(*existing, after)) == 0 {
+		return domain.ImportUnchanged, existing, nil
+	}
+	prepared.ID, prepared.Version = existing.ID, existing.Version
+	updated, err := s.UpdateMovie(ctx, prepared, nil)
+	return domain.ImportUpdated, updated, err
+}
+
+// SeedMovies carrega os filmes do arquivo de seed, identificados pelo SourceID, para que o mesmo arquivo possa
+// ser carregado de novo depois de ganhar filmes. No modo ImportInsert só os SourceID que ainda não estão no catálogo
+// são criados; no ImportUpsert os que já estão também recebem os campos do arquivo. Um filme novo com o título e o
+// ano de um filme do catálogo sem SourceID é ligado a esse filme em vez de duplicá-lo (ver domain.SeedReport).
+// Os filmes são gravados em lotes de domain.MaxBatchSize; as criações e as ligações ao SourceID publicam o evento,
+// mas não geram revisão, e as demais escritas geram revisão e evento.
+func (s *movieService) SeedMovies(ctx context.Context, mode domain.ImportMode, movies []domain.Movie) (*domain.SeedReport, error) {
+	if mode != domain.ImportInsert && mode != domain.ImportUpsert {
+		return nil, fmt.Errorf("%w: o seed aceita insert ou upsert", domain.ErrInvalidImportMode)
+	}
+	report := &domain.SeedReport{Mode: mode}
+	seen := make(map[string]bool, len(movies))
+	batch := make([]seedItem, 0, domain.MaxBatchSize)
+	for i, movie := range movies {
+		line := int64(i + 1)
+		prepared, err := prepareSeed(movie)
+		if err == nil && seen[prepared.SourceID] {
+			err = &domain.ValidationError{Violations: []domain.FieldViolation{{Field: "id", Description: "O id de origem se repete no arquivo"}}}
+		}
+		if err != nil {
+			report.Reject(line, movie, err)
+			continue
+		}
+		seen[prepared.SourceID] = true
+
+		batch = append(batch, seedItem{line: line, movie: prepared})
+		if len(batch) == domain.MaxBatchSize {
+			if err := s.seedBatch(ctx, mode, batch, report); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := s.seedBatch(ctx, mode, batch, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// seedItem é um filme válido do seed e a posição dele no arquivo.
+type seedItem struct {
+	line  int64
+	movie domain.Movie
+}
+
+// prepareSeed normaliza e valida um filme do seed, que precisa do id de origem.
+func prepareSeed(movie domain.Movie) (domain.Movie, error) {
+	movie.SourceID = strings.TrimSpace(movie.SourceID)
+	if movie.SourceID == "" {
+		return domain.Movie{}, &domain.ValidationError{Violations: []domain.FieldViolation{{Field: "id", Description: "O id de origem é obrigatório"}}}
+	}
+	return prepareCreate(movie)
+}
+
+// seedBatch grava um lote do seed: busca de uma vez os SourceID que já estão no catálogo, atualiza esses
+// filmes (no upsert) e cria os demais com InsertMany. Um filme do seed que foi para a lixeira não é recriado.
+// Só falhas que impedem o lote inteiro são devolvidas.
+func (s *movieService) seedBatch(ctx context.Context, mode domain.ImportMode, batch []seedItem, report *domain.SeedReport) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	sourceIDs := make([]string, len(batch))
+	for i, item := range batch {
+		sourceIDs[i] = item.movie.SourceID
+	}
+	found, err := s.repo.FindBySourceIDs(ctx, sourceIDs)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]domain.Movie, len(found))
+	for _, movie := range found {
+		// Fora da lixeira o SourceID é de um filme só, mas um filme removido pode ter o mesmo de um que não foi.
+		if current, ok := existing[movie.SourceID]; !ok || current.DeletedAt != nil {
+			existing[movie.SourceID] = movie
+		}
+	}
 
+	fresh := make([]seedItem, 0, len(batch))
+	for _, item := range batch {
+		current, ok := existing[item.movie.SourceID]
+		switch {
+		case !ok:
+			fresh = append(fresh, item)
+		case current.DeletedAt != nil:
+			report.Skipped++
+		case mode == domain.ImportUpsert:
+			changed, err := s.seedUpdate(ctx, current, item.movie, true)
+			if err != nil {
+				report.Reject(item.line, item.movie, err)
+			} else if changed {
+				report.Updated++
+			} else {
+				report.Unchanged++
+			}
+		default:
+			report.Unchanged++
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	movies := make([]domain.Movie, len(fresh))
+	for i, item := range fresh {
+		movies[i] = item.movie
+	}
+	inserted, err := s.seedInsert(ctx, movies)
+	if err != nil {
+		return err
+	}
+	links := make([]seedItem, 0)
+	for i, result := range inserted {
+		switch {
+		case result.Err == nil:
+			report.Created++
+		case errors.Is(result.Err, domain.ErrMovieAlreadyExists):
+			if link, ok := s.seedLink(ctx, mode, fresh[i], report); ok {
+				links = append(links, link)
+			}
+		default:
+			report.Reject(fresh[i].line, fresh[i].movie, result.Err)
+		}
+	}
+	return s.seedLinkSources(ctx, links, report)
+}
+
+// seedInsert cria os filmes novos do seed com InsertMany e publica o evento de criação de cada um, sem revisão.
+// Numa transação, o lote e os eventos são gravados juntos; como um filme repetido desfaz o lote inteiro, nesse caso
+// cada filme é gravado de novo na sua própria transação, com o seu evento (ver seedCreate), como em BulkCreateMovies.
+func (s *movieService) seedInsert(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	if s.tx == nil {
+		inserted, err := s.repo.InsertMany(ctx, movies)
+		if err != nil {
+			return nil, err
+		}
+		// Sem transação, publish não falha: a falha ao publicar só vai para o log.
+		_ = s.publish(ctx, createdEvents(ctx, inserted)...)
+		return inserted, nil
+	}
+
+	var inserted []domain.BatchResult
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if inserted, err = s.repo.InsertMany(ctx, movies); err != nil {
+			return err
+		}
+		for _, result := range inserted {
+			if result.Err != nil {
+				return result.Err
+			}
+		}
+		return s.publish(ctx, createdEvents(ctx, inserted)...)
+	})
+	switch {
+	case err == nil:
+		return inserted, nil
+	case errors.Is(err, domain.ErrMovieAlreadyExists):
+		results := make([]domain.BatchResult, len(movies))
+		for i, movie := range movies {
+			results[i].Movie, results[i].Err = s.seedCreate(ctx, movie)
+		}
+		return results, nil
+	default:
+		return nil, err
+	}
+}
+
+// seedCreate cria um filme do seed e publica o evento de criação dele na mesma transação, sem revisão.
+func (s *movieService) seedCreate(ctx context.Context, movie domain.Movie) (*domain.Movie, error) {
+	var created *domain.Movie
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Save(ctx, movie); err != nil {
+			return err
+		}
+		return s.publish(ctx, createdEvents(ctx, []domain.BatchResult{{Movie: created}})...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// createdEvents são os eventos de criação dos filmes gravados no lote.
+func createdEvents(ctx context.Context, inserted []domain.BatchResult) []domain.MovieEvent {
+	events := make([]domain.MovieEvent, 0, len(inserted))
+	for _, result := range inserted {
+		if result.Movie != nil {
+			events = append(events, domain.NewRevisionEvent(newRevision(ctx, domain.RevisionCreate, domain.Movie{}, *result.Movie)))
+		}
+	}
+	return events
+}
+
+// seedLink trata o filme novo que não pôde ser criado porque o título e o ano, ou o SourceID, já estão em uso.
+// O filme do catálogo sem SourceID passa a ter o do arquivo: se só o SourceID e os ids externos mudam, o filme é
+// devolvido para ser ligado junto com os demais do lote (ver seedLinkSources); no upsert, se outros campos mudam,
+// ele é gravado na hora, com revisão. O que já tem o mesmo SourceID foi gravado por outra carga simultânea e é
+// tratado como já existente.
+func (s *movieService) seedLink(ctx context.Context, mode domain.ImportMode, item seedItem, report *domain.SeedReport) (seedItem, bool) {
+	current, err := s.repo.FindByTitle(ctx, item.movie.Title, item.movie.Year)
+	switch {
+	case errors.Is(err, domain.ErrMovieNotFound):
+		report.Skipped++
+		return seedItem{}, false
+	case err != nil:
+		report.Reject(item.line, item.movie, err)
+		return seedItem{}, false
+	case current.SourceID != "" && current.SourceID != item.movie.SourceID:
+		report.Skipped++
+		return seedItem{}, false
+	}
+
+	replace := mode == domain.ImportUpsert
+	if current.SourceID == "" {
+		after := seedTarget(*current, item.movie, replace)
+		if !changesBesidesExternalIDs(domain.Diff(*current, after)) {
+			return seedItem{line: item.line, movie: after}, true
+		}
+	}
+	linking := current.SourceID == ""
+	changed, err := s.seedUpdate(ctx, *current, item.movie, replace)
+	switch {
+	case err != nil:
+		report.Reject(item.line, item.movie, err)
+	case linking:
+		report.Linked++
+	case changed:
+		report.Updated++
+	default:
+		report.Unchanged++
+	}
+	return seedItem{}, false
+}
+
+// seedLinkSources grava de uma vez o SourceID e os ids externos dos filmes do catálogo ligados ao arquivo. A ligação
+// não gera revisão: como as migrações que preencheram external_ids, é manutenção do catálogo, e na primeira carga
+// depois de uma atualização ela alcança quase todos os filmes. A versão é incrementada mesmo assim, para que uma
+// escrita condicionada a uma leitura anterior não desfaça a ligação, e cada filme ligado publica movie.updated.v1,
+// para que quem acompanha a versão e os ids externos veja a mudança (ver linkSources).
+func (s *movieService) seedLinkSources(ctx context.Context, links []seedItem, report *domain.SeedReport) error {
+	if len(links) == 0 {
+		return nil
+	}
+	movies := make([]domain.Movie, len(links))
+	for i, link := range links {
+		movies[i] = link.movie
+	}
+	results, err := s.linkSources(ctx, movies)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			report.Reject(links[i].line, links[i].movie, result.Err)
+			continue
+		}
+		report.Linked++
+	}
+	return nil
+}
+
+// linkSources liga os filmes com LinkSources e publica o evento de alteração de cada um. Numa transação, as ligações
+// e os eventos são gravados juntos; como um filme recusado desfaz o lote inteiro, nesse caso cada filme é ligado de
+// novo na sua própria transação, com o seu evento, como em seedInsert.
+func (s *movieService) linkSources(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	if s.tx == nil {
+		results, err := s.repo.LinkSources(ctx, movies)
+		if err != nil {
+			return nil, err
+		}
+		// Sem transação, publish não falha: a falha ao publicar só vai para o log.
+		_ = s.publish(ctx, linkedEvents(ctx, movies, results)...)
+		return results, nil
+	}
+
+	results, err := s.linkSourcesTx(ctx, movies)
+	switch {
+	case err == nil:
+		return results, nil
+	case errors.Is(err, domain.ErrMovieAlreadyExists), errors.Is(err, domain.ErrVersionConflict):
+		results = make([]domain.BatchResult, len(movies))
+		for i, movie := range movies {
+			linked, err := s.linkSourcesTx(ctx, []domain.Movie{movie})
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i] = linked[0]
+		}
+		return results, nil
+	default:
+		return nil, err
+	}
+}
+
+// linkSourcesTx liga os filmes e publica os eventos na mesma transação. Se algum filme for recusado, devolve o erro
+// dele e a transação é desfeita.
+func (s *movieService) linkSourcesTx(ctx context.Context, movies []domain.Movie) ([]domain.BatchResult, error) {
+	var results []domain.BatchResult
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if results, err = s.repo.LinkSources(ctx, movies); err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				return result.Err
+			}
+		}
+		return s.publish(ctx, linkedEvents(ctx, movies, results)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// linkedEvents são os eventos de alteração dos filmes ligados no lote; links são os filmes como foram pedidos.
+func linkedEvents(ctx context.Context, links []domain.Movie, results []domain.BatchResult) []domain.MovieEvent {
+	events := make([]domain.MovieEvent, 0, len(results))
+	for i, result := range results {
+		if result.Movie != nil {
+			events = append(events, domain.NewRevisionEvent(newRevision(ctx, domain.RevisionUpdate, links[i], *result.Movie)))
+		}
+	}
+	return events
+}
+
+// changesBesidesExternalIDs diz se a alteração muda algum campo além dos ids externos.
+func changesBesidesExternalIDs(changes []domain.FieldChange) bool {
+	for _, change := range changes {
+		if change.Field != "external_ids" {
+			return true
+		}
+	}
+	return false
+}
+
+// seedTarget devolve o filme do catálogo com o SourceID e os ids externos do filme do arquivo e, com replace, também
+// os demais campos editáveis.
+func seedTarget(current, movie domain.Movie, replace bool) domain.Movie {
+	after := current
+	after.SourceID = movie.SourceID
+	if replace {
+		// Sem máscara, ApplyUpdate copia todos os campos editáveis e não falha.
+		_ = after.ApplyUpdate(movie, nil)
+	}
+	// O arquivo só conhece alguns provedores: os ids externos dele prevalecem, e os dos demais provedores ficam.
+	if len(movie.ExternalIDs) > 0 {
+		after.ExternalIDs = maps.Clone(current.ExternalIDs)
+		if after.ExternalIDs == nil {
+			after.ExternalIDs = make(map[string]string, len(movie.ExternalIDs))
+		}
+		maps.Copy(after.ExternalIDs, movie.ExternalIDs)
+	}
+	return after
+}
+
+// seedUpdate grava no filme do catálogo o SourceID e os ids externos do filme do arquivo e, com replace, também
+// os demais campos editáveis (ver seedTarget).
+// Não grava nada se o filme já está igual; diz se houve escrita.
+func (s *movieService) seedUpdate(ctx context.Context, current, movie domain.Movie, replace bool) (bool, error) {
+	after := seedTarget(current, movie, replace)
+	if after.SourceID == current.SourceID && len(domain.Diff(current, after)) == 0 {
+		return false, nil
+	}
+	if _, err := s.save(ctx, domain.RevisionUpdate, current, after); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListDeletedMovies lista a lixeira, com os mesmos filtros, ordenação e paginação de ListMovies.
+func (s *movieService) ListDeletedMovies(ctx context.Context, query domain.MovieQuery) (*domain.MoviePage, error) {
+	query.Deleted = true
+	return s.ListMovies(ctx, query)
+}
+
+func (s *movieService) RestoreMovie(ctx context.Context, id string) (*domain.Movie, error) {
+	var restored *domain.Movie
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if restored, err = s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		// Só importa que o filme estava na lixeira; o momento da remoção já está na revisão de delete.
+		before := *restored
+		before.DeletedAt = &time.Time{}
+		return s.record(ctx, newRevision(ctx, domain.RevisionRestore, before, *restored))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// PurgeDeletedMovies remove definitivamente os filmes que estão na lixeira há mais tempo que retention e publica
+// o evento de expurgo de cada um. O expurgo não gera revisão: o histórico do filme sai com ele. Os filmes saem em
+// lotes de domain.MaxBatchSize, cada um com os seus eventos na mesma transação, para que uma lixeira grande não
+// estoure os limites de uma transação; se um lote falhar, os anteriores continuam removidos e o total deles é
+// devolvido com o erro.
+func (s *movieService) PurgeDeletedMovies(ctx context.Context, retention time.Duration) (int64, error) {
+	deletedBefore := time.Now().Add(-retention)
+	var total int64
+	for {
+		purged, err := s.purgeBatch(ctx, deletedBefore)
+		total += int64(purged)
+		if err != nil {
+			return total, err
+		}
+		if purged < domain.MaxBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// purgeBatch remove um lote da lixeira e publica os eventos dele na mesma transação; diz quantos filmes saíram.
+func (s *movieService) purgeBatch(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged []domain.Movie
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if purged, err = s.repo.Purge(ctx, deletedBefore, domain.MaxBatchSize); err != nil {
+			return err
+		}
+		actor, now := domain.ActorFromContext(ctx), time.Now().UTC()
+		events := make([]domain.MovieEvent, len(purged))
+		for i, movie := range purged {
+			events[i] = domain.NewPurgeEvent(movie, actor, now)
+		}
+		return s.publish(ctx, events...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(purged), nil
+}
+
+// ListMovieRevisions lista o histórico do filme, das revisões mais recentes para as mais antigas.
+// Para a próxima página, beforeVersion é a versão da última revisão recebida.
+func (s *movieService) ListMovieRevisions(ctx context.Context, movieID string, beforeVersion, limit int64) ([]domain.MovieRevision, error) {
+	if s.revisions == nil {
+		return nil, domain.ErrHistoryUnavailable
+	}
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	return s.revisions.List(ctx, movieID, beforeVersion, min(limit, maxHistoryLimit))
+}
+
+// RevertMovie volta os campos editáveis do filme aos valores que tinham na revisão informada.
+// A reversão é uma escrita como as outras: incrementa a versão e gera a própria revisão.
+// Se o filme já está igual à revisão, nada é gravado.
+func (s *movieService) RevertMovie(ctx context.Context, id string, version int64) (*domain.Movie, error) {
+	if s.revisions == nil {
+		return nil, domain.ErrHistoryUnavailable
+	}
+	current, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.revisions.Get(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *current
+	if err := current.ApplyUpdate(revision.Snapshot, nil); err != nil {
+		return nil, err
+	}
+	current.Normalize()
+	if err := current.Validate(); err != nil {
+		return nil, err
+	}
+	if len(domain.Diff(before, *current)) == 0 {
+		return current, nil
+	}
+	var reverted *domain.Movie
+	err = s.transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if reverted, err = s.repo.Save(ctx, *current); err != nil {
+			return err
+		}
+		rev := newRevision(ctx, domain.RevisionRevert, before, *reverted)
+		rev.RevertedTo = version
+		return s.record(ctx, rev)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// newRevision descreve a escrita que levou o filme de before para after, feita por quem está anotado no contexto.
+func newRevision(ctx context.Context, action domain.RevisionAction, before, after domain.Movie) domain.MovieRevision {
+	return domain.MovieRevision{
+		MovieID:   after.ID,
+		Version:   after.Version,
+		Action:    action,
+		Actor:     domain.ActorFromContext(ctx),
+		Timestamp: time.Now().UTC(),
+		Changes:   domain.Diff(before, after),
+		Snapshot:  after,
+	}
+}
+
+// transaction executa fn na transação do WithTransactor ou, sem ele, direto.
+func (s *movieService) transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithinTransaction(ctx, fn)
+}
+
+// record grava a revisão de uma escrita e publica o evento dela (ver publish). Como o evento, a revisão faz parte
+// da escrita numa transação, e a falha ao gravá-la é devolvida; sem transação, a escrita já vale e a falha só vai
+// para o log.
+func (s *movieService) record(ctx context.Context, revision domain.MovieRevision) error {
+	if s.revisions != nil {
+		if err := s.revisions.Append(ctx, revision); err != nil {
+			if s.tx != nil {
+				return err
+			}
+			log.Printf("Erro ao gravar a revisão %d do filme %s: %v", revision.Version, revision.MovieID, err)
+		}
+	}
+	return s.publish(ctx, domain.NewRevisionEvent(revision))
+}
+
+// publish publica os eventos de uma escrita. Sem transação, a falha ao publicar só vai para o log; numa transação,
+// o evento faz parte da escrita e a falha é devolvida, para que a escrita seja desfeita.
+func (s *movieService) publish(ctx context.Context, events ...domain.MovieEvent) error {
+	if s.events == nil {
+		return nil
+	}
+	for _, event := range events {
+		if s.tx != nil {
+			if err := s.events.Publish(ctx, event); err != nil {
+				return err
+			}
+			continue
+		}
+		// A escrita já foi aplicada: o evento sai mesmo que quem a pediu tenha desistido de esperar.
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			log.Printf("Erro ao publicar o evento %s %s: %v", event.Type, event.ID, err)
+		}
+	}
+	return nil
+}
+
+// SearchMovies faz a busca textual e destaca, em cada resultado, os termos encontrados.
+// O destaque é feito aqui para que todo adaptador de busca devolva o mesmo formato.
+func (s *movieService) SearchMovies(ctx context.Context, query string, limit int64) ([]domain.SearchResult, error) {
+	if s.searcher == nil {
+		return nil, domain.ErrSearchUnavailable
+	}
+
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, domain.ErrEmptySearchQuery
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	results, err := s.searcher.Search(ctx, strings.Join(terms, " "), limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Highlights = results[i].Movie.Highlight(terms)
+	}
+	return results, nil
+}
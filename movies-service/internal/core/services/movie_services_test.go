@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 	"errors"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
 	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports/mocks"
@@ -35,7 +38,7 @@ func TestCreateMovie_Success(t *testing.T) {
 	movieService := NewMovieService(mockRepo)
 
 	
-	result, err := movieService.CreateMovie(context.Background(), inputTitle, inputYear)
+	result, err := movieService.CreateMovie(context.Background(), domain.Movie{Title: inputTitle, Year: inputYear})
 
 	
 	assert.NoError(t, err)
@@ -59,7 +62,7 @@ func TestCreateMovie_RepositoryError(t *testing.T) {
 
 	movieService := NewMovieService(mockRepo)
 	
-	result, err := movieService.CreateMovie(context.Background(), "O Auto da Compadecida", 2000)
+	result, err := movieService.CreateMovie(context.Background(), domain.Movie{Title: "O Auto da Compadecida", Year: 2000})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -69,6 +72,41 @@ func TestCreateMovie_RepositoryError(t *testing.T) {
 }
 
 
+func TestCreateMovie_WithDetails(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+
+	releaseDate := time.Date(2019, time.August, 29, 0, 0, 0, 0, time.UTC)
+	input := domain.Movie{
+		ID:               "id_informado_pelo_cliente",
+		Title:            "Bacurau",
+		Year:             2019,
+		Genres:           []string{"Drama", "Faroeste"},
+		Directors:        []string{"Kleber Mendonça Filho", "Juliano Dornelles"},
+		Cast:             []string{"Sônia Braga", "Udo Kier"},
+		RuntimeMinutes:   131,
+		Synopsis:         "Os moradores de um pequeno povoado do sertão percebem que a comunidade sumiu do mapa.",
+		OriginalLanguage: "pt",
+		ReleaseDate:      &releaseDate,
+	}
+
+	movieToSave := input
+	movieToSave.ID = ""
+
+	expectedMovie := movieToSave
+	expectedMovie.ID = "some_generated_id"
+
+	mockRepo.On("Save", mock.Anything, movieToSave).Return(&expectedMovie, nil)
+
+	movieService := NewMovieService(mockRepo)
+
+	result, err := movieService.CreateMovie(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &expectedMovie, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestListMovies_Success(t *testing.T) {
 	mockRepo := new(mocks.MovieRepositoryMock)
 
@@ -77,22 +115,69 @@ func TestListMovies_Success(t *testing.T) {
 		{ID: "id2", Title: "Filme 2", Year: 2002},
 	}
 	
-	var limit, offset int64 = 10, 0
+	query := domain.MovieQuery{Limit: 10, Offset: 0}
 
-	mockRepo.On("GetAll", mock.Anything, limit, offset).Return(expectedMovies, nil)
+	mockRepo.On("GetAll", mock.Anything, query).Return(&domain.MoviePage{Movies: expectedMovies, NextPageToken: "token"}, nil)
 
 	movieService := NewMovieService(mockRepo)
 
-	result, err := movieService.ListMovies(context.Background(), limit, offset)
+	result, err := movieService.ListMovies(context.Background(), query)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 2) 
-	assert.Equal(t, expectedMovies, result) 
+	assert.Len(t, result.Movies, 2) 
+	assert.Equal(t, expectedMovies, result.Movies) 
+	assert.Equal(t, "token", result.NextPageToken)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListMovies_IncludeTotal(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+
+	query := domain.MovieQuery{Filter: domain.MovieFilter{Decade: 1890}, Limit: 2, IncludeTotal: true}
+	total := int64(3)
+	expectedPage := &domain.MoviePage{
+		Movies:        []domain.Movie{{ID: "id1", Title: "Filme 1", Year: 1895}, {ID: "id2", Title: "Filme 2", Year: 1896}},
+		NextPageToken: "token",
+		TotalCount:    &total,
+	}
+	mockRepo.On("GetAll", mock.Anything, query).Return(expectedPage, nil)
+
+	result, err := NewMovieService(mockRepo).ListMovies(context.Background(), query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPage, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListMovies_Filter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filter        domain.MovieFilter
+		expectedError error
+	}{
+		{name: "Sucesso - Título e década", filter: domain.MovieFilter{Title: "lumiere", Decade: 1890}},
+		{name: "Sucesso - Intervalo de anos", filter: domain.MovieFilter{YearFrom: 1990, YearTo: 1999}},
+		{name: "Falha - Década fora do formato", filter: domain.MovieFilter{Decade: 1995}, expectedError: domain.ErrInvalidFilter},
+		{name: "Falha - Intervalo invertido", filter: domain.MovieFilter{YearFrom: 2000, YearTo: 1990}, expectedError: domain.ErrInvalidFilter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.MovieRepositoryMock)
+			query := domain.MovieQuery{Filter: tc.filter, Limit: 20}
+			if tc.expectedError == nil {
+				mockRepo.On("GetAll", mock.Anything, query).Return(&domain.MoviePage{Movies: []domain.Movie{}}, nil)
+			}
+
+			_, err := NewMovieService(mockRepo).ListMovies(context.Background(), query)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
 
 func TestGetMovie(t *testing.T) {
 	mockRepo := new(mocks.MovieRepositoryMock)
@@ -138,6 +223,29 @@ func TestGetMovie(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetMovieByExternalID(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	expectedMovie := domain.Movie{ID: "a", Title: "La sortie des usines Lumière", ExternalIDs: map[string]string{"imdb": "tt0000010"}}
+	mockRepo.On("FindByExternalID", mock.Anything, "imdb", "tt0000010").Return(&expectedMovie, nil).Twice()
+	movieService := NewMovieService(mockRepo)
+
+	movie, err := movieService.GetMovieByExternalID(context.Background(), "IMDb", " tt0000010 ")
+	assert.NoError(t, err)
+	assert.Equal(t, &expectedMovie, movie)
+
+	// O id do IMDb só com dígitos é completado antes da busca.
+	movie, err = movieService.GetMovieByExternalID(context.Background(), "imdb", "10")
+	assert.NoError(t, err)
+	assert.Equal(t, &expectedMovie, movie)
+
+	_, err = movieService.GetMovieByExternalID(context.Background(), "letterboxd", "lumiere")
+	assert.ErrorIs(t, err, domain.ErrInvalidExternalID)
+	_, err = movieService.GetMovieByExternalID(context.Background(), "tmdb", "abc")
+	assert.ErrorIs(t, err, domain.ErrInvalidExternalID)
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestDeleteMovie(t *testing.T) {
 	mockRepo := new(mocks.MovieRepositoryMock)
 	movieService := NewMovieService(mockRepo)
@@ -147,14 +255,18 @@ func TestDeleteMovie(t *testing.T) {
 	expectedNotFoundError := errors.New("filme não encontrado")
 
 
-	mockRepo.On("Delete", mock.Anything, validID).Return(nil)
+	deletedAt := time.Now()
+	mockRepo.On("Delete", mock.Anything, validID, int64(0)).Return(&domain.Movie{ID: validID, DeletedAt: &deletedAt, Version: 2}, nil)
+
+	mockRepo.On("Delete", mock.Anything, notFoundID, int64(0)).Return(nil, expectedNotFoundError)
 
-	mockRepo.On("Delete", mock.Anything, notFoundID).Return(expectedNotFoundError)
+	mockRepo.On("Delete", mock.Anything, validID, int64(1)).Return(nil, domain.ErrVersionMismatch)
 
 	testCases := []struct {
-		name          string
-		inputID       string
-		expectedError error
+		name            string
+		inputID         string
+		expectedVersion int64
+		expectedError   error
 	}{
 		{
 			name:          "Sucesso - Filme Deletado",
@@ -166,16 +278,749 @@ func TestDeleteMovie(t *testing.T) {
 			inputID:       notFoundID,
 			expectedError: expectedNotFoundError,
 		},
+		{
+			name:            "Falha - Versão Alterada Por Outra Escrita",
+			inputID:         validID,
+			expectedVersion: 1,
+			expectedError:   domain.ErrVersionMismatch,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
-			err := movieService.DeleteMovie(context.Background(), tc.inputID)
+			err := movieService.DeleteMovie(context.Background(), tc.inputID, tc.expectedVersion)
 
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
 
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestTrash(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	movieService := NewMovieService(mockRepo)
+	ctx := context.Background()
+
+	trashPage := &domain.MoviePage{Movies: []domain.Movie{{ID: "1", Title: "Bacurau", Year: 2019}}}
+	mockRepo.On("GetAll", mock.Anything, domain.MovieQuery{Limit: 10, Deleted: true}).Return(trashPage, nil).Once()
+	page, err := movieService.ListDeletedMovies(ctx, domain.MovieQuery{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, trashPage, page)
+
+	_, err = movieService.ListDeletedMovies(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Decade: 1995}})
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+
+	mockRepo.On("Restore", mock.Anything, "1").Return(&domain.Movie{ID: "1", Title: "Bacurau", Year: 2019}, nil).Once()
+	restored, err := movieService.RestoreMovie(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bacurau", restored.Title)
+
+	before := time.Now()
+	mockRepo.On("Purge", mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		cutoff := before.Add(-48 * time.Hour)
+		return !deletedBefore.Before(cutoff) && deletedBefore.Before(cutoff.Add(time.Minute))
+	}), domain.MaxBatchSize).Return([]domain.Movie{{ID: "1"}, {ID: "2"}, {ID: "3"}}, nil).Once()
+	purged, err := movieService.PurgeDeletedMovies(ctx, 48*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateMovie(t *testing.T) {
+	stored := domain.Movie{ID: "id_existente", Title: "Titulo Antigo", Year: 1999, Genres: []string{"Drama"}, Version: 3}
+
+	testCases := []struct {
+		name          string
+		input         domain.Movie
+		fields        []string
+		expectedSaved *domain.Movie
+		expectedError error
+	}{
+		{
+			name:          "Sucesso - Apenas campos da máscara",
+			input:         domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 2024},
+			fields:        []string{"title"},
+			expectedSaved: &domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 1999, Genres: []string{"Drama"}, Version: 3},
+		},
+		{
+			name:          "Sucesso - Máscara vazia substitui tudo",
+			input:         domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 2024},
+			expectedSaved: &domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 2024, Version: 3},
+		},
+		{
+			name:          "Sucesso - Versão esperada confere",
+			input:         domain.Movie{ID: "id_existente", Title: "Titulo Novo", Version: 3},
+			fields:        []string{"title"},
+			expectedSaved: &domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 1999, Genres: []string{"Drama"}, Version: 3},
+		},
+		{
+			name:          "Falha - Versão esperada diferente da persistida",
+			input:         domain.Movie{ID: "id_existente", Title: "Titulo Novo", Version: 2},
+			fields:        []string{"title"},
+			expectedError: domain.ErrVersionMismatch,
+		},
+		{
+			name:          "Sucesso - Título é aparado",
+			input:         domain.Movie{ID: "id_existente", Title: "  Titulo Novo  "},
+			fields:        []string{"title"},
+			expectedSaved: &domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 1999, Genres: []string{"Drama"}, Version: 3},
+		},
+		{
+			name:          "Falha - Título em branco",
+			input:         domain.Movie{ID: "id_existente", Title: "   "},
+			fields:        []string{"title"},
+			expectedError: domain.ErrInvalidMovie,
+		},
+		{
+			name:          "Falha - Máscara vazia valida todos os campos",
+			input:         domain.Movie{ID: "id_existente", Title: "Titulo Novo"},
+			expectedError: domain.ErrInvalidMovie,
+		},
+		{
+			name:          "Falha - Campo desconhecido na máscara",
+			input:         domain.Movie{ID: "id_existente", Title: "Titulo Novo"},
+			fields:        []string{"id"},
+			expectedError: domain.ErrInvalidUpdateMask,
+		},
+		{
+			name:          "Falha - Filme Não Encontrado",
+			input:         domain.Movie{ID: "id_inexistente", Title: "Titulo Novo"},
+			fields:        []string{"title"},
+			expectedError: domain.ErrMovieNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.MovieRepositoryMock)
+			current := stored
+			mockRepo.On("Get", mock.Anything, "id_existente").Return(&current, nil).Maybe()
+			mockRepo.On("Get", mock.Anything, "id_inexistente").Return(nil, domain.ErrMovieNotFound).Maybe()
+			if tc.expectedSaved != nil {
+				mockRepo.On("Save", mock.Anything, *tc.expectedSaved).Return(tc.expectedSaved, nil)
+			}
+
+			result, err := NewMovieService(mockRepo).UpdateMovie(context.Background(), tc.input, tc.fields)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedSaved, result)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchMovies_Highlights(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockSearcher := new(mocks.MovieSearcherMock)
+
+	found := domain.Movie{
+		ID:       "id1",
+		Title:    "La sortie des usines Lumière",
+		Year:     1895,
+		Synopsis: "Operários deixam a fábrica dos irmãos Lumière em Lyon.",
+	}
+	mockSearcher.On("Search", mock.Anything, "lumiere fabrica", int64(20)).
+		Return([]domain.SearchResult{{Movie: found, Score: 7.5}}, nil)
+
+	movieService := NewMovieService(mockRepo, WithSearcher(mockSearcher))
+
+	results, err := movieService.SearchMovies(context.Background(), "lumiere, fabrica!", 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, 7.5, results[0].Score)
+	assert.Equal(t, []domain.SearchHighlight{
+		{Field: "title", Snippet: "La sortie des usines <em>Lumière</em>"},
+		{Field: "synopsis", Snippet: "Operários deixam a <em>fábrica</em> dos irmãos <em>Lumière</em> em Lyon."},
+	}, results[0].Highlights)
+
+	mockSearcher.AssertExpectations(t)
+}
+
+func TestSearchMovies_Errors(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+
+	_, err := NewMovieService(mockRepo).SearchMovies(context.Background(), "lumiere", 10)
+	assert.ErrorIs(t, err, domain.ErrSearchUnavailable)
+
+	_, err = NewMovieService(mockRepo, WithSearcher(new(mocks.MovieSearcherMock))).SearchMovies(context.Background(), " ?! ", 10)
+	assert.ErrorIs(t, err, domain.ErrEmptySearchQuery)
+}
+
+func TestMovieRevisions_RecordedOnWrites(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockRevisions := new(mocks.MovieRevisionStoreMock)
+	movieService := NewMovieService(mockRepo, WithRevisionStore(mockRevisions))
+	ctx := domain.WithActor(context.Background(), "maria")
+
+	created := domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, Version: 1}
+	mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Bacurau", Year: 2019}).Return(&created, nil).Once()
+	mockRevisions.On("Append", mock.Anything, mock.MatchedBy(func(rev domain.MovieRevision) bool {
+		return rev.Action == domain.RevisionCreate && rev.MovieID == "id_novo" && rev.Version == 1 &&
+			rev.Actor == "maria" && len(rev.Changes) == 2 && rev.Snapshot.Title == "Bacurau"
+	})).Return(nil).Once()
+	_, err := movieService.CreateMovie(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	assert.NoError(t, err)
+
+	updated := domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, Version: 2}
+	mockRepo.On("Get", mock.Anything, "id_novo").Return(&domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, Version: 1}, nil).Once()
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(&updated, nil).Once()
+	mockRevisions.On("Append", mock.Anything, mock.MatchedBy(func(rev domain.MovieRevision) bool {
+		return rev.Action == domain.RevisionUpdate && rev.Version == 2 &&
+			assert.ObjectsAreEqual([]domain.FieldChange{{Field: "runtime_minutes", Old: nil, New: 131}}, rev.Changes)
+	})).Return(nil).Once()
+	_, err = movieService.UpdateMovie(ctx, domain.Movie{ID: "id_novo", RuntimeMinutes: 131}, []string{"runtime_minutes"})
+	assert.NoError(t, err)
+
+	// Uma falha ao gravar o histórico não desfaz nem invalida a escrita.
+	deletedAt := time.Now()
+	mockRepo.On("Delete", mock.Anything, "id_novo", int64(0)).Return(&domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, DeletedAt: &deletedAt, Version: 3}, nil).Once()
+	mockRevisions.On("Append", mock.Anything, mock.MatchedBy(func(rev domain.MovieRevision) bool {
+		return rev.Action == domain.RevisionDelete &&
+			assert.ObjectsAreEqual([]domain.FieldChange{{Field: domain.DeletedField, Old: false, New: true}}, rev.Changes)
+	})).Return(errors.New("database error")).Once()
+	assert.NoError(t, movieService.DeleteMovie(ctx, "id_novo", 0))
+
+	mockRepo.AssertExpectations(t)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestMovieEvents_PublishedOnWrites(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockEvents := new(mocks.EventPublisherMock)
+	movieService := NewMovieService(mockRepo, WithEventPublisher(mockEvents))
+	ctx := domain.WithActor(context.Background(), "maria")
+
+	created := domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, Version: 1}
+	mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Bacurau", Year: 2019}).Return(&created, nil).Once()
+	mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.MovieEvent) bool {
+		return event.ID == "id_novo:1" && event.Type == domain.EventMovieCreated && event.Actor == "maria" &&
+			assert.ObjectsAreEqual(created, event.Movie) && !event.OccurredAt.IsZero()
+	})).Return(nil).Once()
+	_, err := movieService.CreateMovie(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	assert.NoError(t, err)
+
+	// Uma falha ao publicar não desfaz nem invalida a escrita.
+	deletedAt := time.Now()
+	deleted := domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, DeletedAt: &deletedAt, Version: 2}
+	mockRepo.On("Delete", mock.Anything, "id_novo", int64(0)).Return(&deleted, nil).Once()
+	mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.MovieEvent) bool {
+		return event.ID == "id_novo:2" && event.Type == domain.EventMovieDeleted && event.Movie.DeletedAt != nil
+	})).Return(errors.New("broker fora do ar")).Once()
+	assert.NoError(t, movieService.DeleteMovie(ctx, "id_novo", 0))
+
+	// Escritas recusadas não publicam nada.
+	mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Aquarius", Year: 2016}).Return(nil, domain.ErrMovieAlreadyExists).Once()
+	_, err = movieService.CreateMovie(ctx, domain.Movie{Title: "Aquarius", Year: 2016})
+	assert.ErrorIs(t, err, domain.ErrMovieAlreadyExists)
+
+	mockRepo.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestRevertMovie(t *testing.T) {
+	ctx := context.Background()
+	current := domain.Movie{ID: "id_existente", Title: "Titulo Novo", Year: 2001, Version: 5}
+	revision := domain.MovieRevision{
+		MovieID:  "id_existente",
+		Version:  2,
+		Snapshot: domain.Movie{ID: "id_existente", Title: "Titulo Antigo", Year: 2001, Version: 2},
+	}
+
+	t.Run("Sucesso - Volta os campos e grava uma revisão", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRevisions := new(mocks.MovieRevisionStoreMock)
+		movieService := NewMovieService(mockRepo, WithRevisionStore(mockRevisions))
+
+		stored := current
+		mockRepo.On("Get", mock.Anything, "id_existente").Return(&stored, nil)
+		mockRevisions.On("Get", mock.Anything, "id_existente", int64(2)).Return(&revision, nil)
+		saved := domain.Movie{ID: "id_existente", Title: "Titulo Antigo", Year: 2001, Version: 6}
+		mockRepo.On("Save", mock.Anything, domain.Movie{ID: "id_existente", Title: "Titulo Antigo", Year: 2001, Version: 5}).Return(&saved, nil)
+		mockRevisions.On("Append", mock.Anything, mock.MatchedBy(func(rev domain.MovieRevision) bool {
+			return rev.Action == domain.RevisionRevert && rev.Version == 6 && rev.RevertedTo == 2 &&
+				assert.ObjectsAreEqual([]domain.FieldChange{{Field: "title", Old: "Titulo Novo", New: "Titulo Antigo"}}, rev.Changes)
+		})).Return(nil)
+
+		result, err := movieService.RevertMovie(ctx, "id_existente", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, &saved, result)
+		mockRepo.AssertExpectations(t)
+		mockRevisions.AssertExpectations(t)
+	})
+
+	t.Run("Filme já igual à revisão não é gravado", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRevisions := new(mocks.MovieRevisionStoreMock)
+		movieService := NewMovieService(mockRepo, WithRevisionStore(mockRevisions))
+
+		stored := revision.Snapshot
+		mockRepo.On("Get", mock.Anything, "id_existente").Return(&stored, nil)
+		mockRevisions.On("Get", mock.Anything, "id_existente", int64(2)).Return(&revision, nil)
+
+		result, err := movieService.RevertMovie(ctx, "id_existente", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "Titulo Antigo", result.Title)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Falha - Revisão inexistente", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRevisions := new(mocks.MovieRevisionStoreMock)
+		movieService := NewMovieService(mockRepo, WithRevisionStore(mockRevisions))
+
+		stored := current
+		mockRepo.On("Get", mock.Anything, "id_existente").Return(&stored, nil)
+		mockRevisions.On("Get", mock.Anything, "id_existente", int64(9)).Return(nil, domain.ErrRevisionNotFound)
+
+		_, err := movieService.RevertMovie(ctx, "id_existente", 9)
+		assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	})
+
+	t.Run("Falha - Sem histórico configurado", func(t *testing.T) {
+		movieService := NewMovieService(new(mocks.MovieRepositoryMock))
+
+		_, err := movieService.RevertMovie(ctx, "id_existente", 2)
+		assert.ErrorIs(t, err, domain.ErrHistoryUnavailable)
+		_, err = movieService.ListMovieRevisions(ctx, "id_existente", 0, 0)
+		assert.ErrorIs(t, err, domain.ErrHistoryUnavailable)
+	})
+}
+
+func TestListMovieRevisions_Limits(t *testing.T) {
+	mockRevisions := new(mocks.MovieRevisionStoreMock)
+	movieService := NewMovieService(new(mocks.MovieRepositoryMock), WithRevisionStore(mockRevisions))
+	ctx := context.Background()
+
+	mockRevisions.On("List", mock.Anything, "id", int64(0), defaultHistoryLimit).Return([]domain.MovieRevision{}, nil).Once()
+	mockRevisions.On("List", mock.Anything, "id", int64(7), maxHistoryLimit).Return([]domain.MovieRevision{}, nil).Once()
+
+	_, err := movieService.ListMovieRevisions(ctx, "id", 0, 0)
+	assert.NoError(t, err)
+	_, err = movieService.ListMovieRevisions(ctx, "id", 7, 1000)
+	assert.NoError(t, err)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestCreateMovie_Validation(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	testCases := []struct {
+		name               string
+		input              domain.Movie
+		expectedViolations []string
+	}{
+		{name: "Título vazio e sem ano", input: domain.Movie{Title: "  "}, expectedViolations: []string{"title", "year"}},
+		{name: "Título longo demais", input: domain.Movie{Title: strings.Repeat("a", domain.MaxTitleLength+1), Year: 2000}, expectedViolations: []string{"title"}},
+		{name: "Ano anterior ao cinema", input: domain.Movie{Title: "Bacurau", Year: domain.MinMovieYear - 1}, expectedViolations: []string{"year"}},
+		{name: "Ano depois do próximo", input: domain.Movie{Title: "Bacurau", Year: nextYear + 1}, expectedViolations: []string{"year"}},
+		{name: "Duração negativa", input: domain.Movie{Title: "Bacurau", Year: 2019, RuntimeMinutes: -1}, expectedViolations: []string{"runtime_minutes"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.MovieRepositoryMock)
+
+			_, err := NewMovieService(mockRepo).CreateMovie(context.Background(), tc.input)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidMovie)
+			var validationErr *domain.ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				fields := make([]string, len(validationErr.Violations))
+				for i, violation := range validationErr.Violations {
+					fields[i] = violation.Field
+				}
+				assert.Equal(t, tc.expectedViolations, fields)
+			}
+			mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Próximo ano é aceito e os textos são aparados", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		expected := domain.Movie{Title: "Bacurau", Year: nextYear, Genres: []string{"Drama"}}
+		mockRepo.On("Save", mock.Anything, expected).Return(&expected, nil)
+
+		_, err := NewMovieService(mockRepo).CreateMovie(context.Background(), domain.Movie{Title: " Bacurau\t", Year: nextYear, Genres: []string{" Drama ", ""}})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestValidateMovie(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.MovieRepositoryMock)
+	movieService := NewMovieService(mockRepo)
+
+	// Filme antigo com ano fora das regras atuais: continua editável nos demais campos.
+	legacy := domain.Movie{ID: "id_antigo", Title: "Filme Antigo", Year: 0, Version: 4}
+	mockRepo.On("Get", mock.Anything, "id_antigo").Return(func() *domain.Movie { movie := legacy; return &movie }(), nil).Once()
+	validated, err := movieService.ValidateMovie(ctx, domain.Movie{ID: "id_antigo", Title: " Novo Título "}, []string{"title"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Novo Título", validated.Title)
+	assert.Equal(t, int64(4), validated.Version)
+
+	mockRepo.On("Get", mock.Anything, "id_antigo").Return(func() *domain.Movie { movie := legacy; return &movie }(), nil).Once()
+	_, err = movieService.ValidateMovie(ctx, domain.Movie{ID: "id_antigo", Year: 1500}, []string{"year"})
+	assert.ErrorIs(t, err, domain.ErrInvalidMovie)
+
+	validated, err = movieService.ValidateMovie(ctx, domain.Movie{Title: "Bacurau ", Year: 2019}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bacurau", validated.Title)
+
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchGetMovies(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	movieService := NewMovieService(mockRepo)
+	ctx := context.Background()
+
+	ids := []string{"2", "", "1", "404"}
+	mockRepo.On("GetMany", mock.Anything, ids).Return([]domain.Movie{
+		{ID: "1", Title: "Bacurau", Year: 2019},
+		{ID: "2", Title: "Aquarius", Year: 2016},
+	}, nil).Once()
+
+	results, err := movieService.BatchGetMovies(ctx, ids)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 4) {
+		assert.Equal(t, "Aquarius", results[0].Movie.Title)
+		assert.ErrorIs(t, results[1].Err, domain.ErrMissingID)
+		assert.Equal(t, "Bacurau", results[2].Movie.Title)
+		assert.ErrorIs(t, results[3].Err, domain.ErrMovieNotFound)
+	}
+
+	_, err = movieService.BatchGetMovies(ctx, make([]string, domain.MaxBatchSize+1))
+	assert.ErrorIs(t, err, domain.ErrBatchTooLarge)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBulkCreateMovies(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockRevisions := new(mocks.MovieRevisionStoreMock)
+	movieService := NewMovieService(mockRepo, WithRevisionStore(mockRevisions))
+	ctx := context.Background()
+
+	// Só os filmes válidos chegam ao repositório, já normalizados; os resultados voltam às posições pedidas.
+	valid := []domain.Movie{{Title: "Bacurau", Year: 2019}, {Title: "Aquarius", Year: 2016}}
+	created := domain.Movie{ID: "1", Title: "Bacurau", Year: 2019, Version: 1}
+	mockRepo.On("InsertMany", mock.Anything, valid).Return([]domain.BatchResult{
+		{Movie: &created},
+		{Err: domain.ErrMovieAlreadyExists},
+	}, nil).Once()
+	mockRevisions.On("Append", mock.Anything, mock.MatchedBy(func(rev domain.MovieRevision) bool {
+		return rev.Action == domain.RevisionCreate && rev.MovieID == "1"
+	})).Return(nil).Once()
+
+	results, err := movieService.BulkCreateMovies(ctx, []domain.Movie{
+		{Title: " Bacurau ", Year: 2019},
+		{Title: "", Year: 2019},
+		{Title: "Aquarius", Year: 2016},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, &created, results[0].Movie)
+		assert.ErrorIs(t, results[1].Err, domain.ErrInvalidMovie)
+		assert.ErrorIs(t, results[2].Err, domain.ErrMovieAlreadyExists)
+	}
+
+	// Uma falha do lote inteiro é devolvida como erro da chamada.
+	mockRepo.On("InsertMany", mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+	_, err = movieService.BulkCreateMovies(ctx, []domain.Movie{{Title: "Bacurau", Year: 2019}})
+	assert.Error(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestBulkDeleteMovies(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	movieService := NewMovieService(mockRepo)
+	ctx := context.Background()
+
+	deletedAt := time.Now()
+	mockRepo.On("Delete", mock.Anything, "1", int64(0)).Return(&domain.Movie{ID: "1", DeletedAt: &deletedAt, Version: 2}, nil).Once()
+	mockRepo.On("Delete", mock.Anything, "404", int64(0)).Return(nil, domain.ErrMovieNotFound).Once()
+
+	results, err := movieService.BulkDeleteMovies(ctx, []string{"1", "404"})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, int64(2), results[0].Movie.Version)
+		assert.ErrorIs(t, results[1].Err, domain.ErrMovieNotFound)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportMovies(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	movieService := NewMovieService(mockRepo)
+	ctx := context.Background()
+
+	query := domain.MovieQuery{Filter: domain.MovieFilter{Decade: 2010}, Limit: 10}
+	mockRepo.On("Stream", mock.Anything, query).Return([]domain.Movie{
+		{ID: "1", Title: "Aquarius", Year: 2016},
+		{ID: "2", Title: "Bacurau", Year: 2019},
+	}, nil).Once()
+
+	var exported []string
+	err := movieService.ExportMovies(ctx, query, func(movie domain.Movie) error {
+		exported = append(exported, movie.Title)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Aquarius", "Bacurau"}, exported)
+
+	err = movieService.ExportMovies(ctx, domain.MovieQuery{Filter: domain.MovieFilter{Decade: 1995}}, func(domain.Movie) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// importRows entrega as linhas informadas, uma por chamada, e depois io.EOF.
+func importRows(rows ...domain.ImportRow) func() (domain.ImportRow, error) {
+	return func() (domain.ImportRow, error) {
+		if len(rows) == 0 {
+			return domain.ImportRow{}, io.EOF
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+}
+
+func TestImportMovies(t *testing.T) {
+	ctx := context.Background()
+	bacurau := domain.Movie{ID: "1", Title: "Bacurau", Year: 2019, Genres: []string{"Drama"}, Version: 2}
+
+	t.Run("Insert", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Aquarius", Year: 2016}).
+			Return(&domain.Movie{ID: "2", Title: "Aquarius", Year: 2016, Version: 1}, nil).Once()
+		mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Bacurau", Year: 2019}).
+			Return(nil, domain.ErrMovieAlreadyExists).Once()
+
+		report, err := NewMovieService(mockRepo).ImportMovies(ctx, domain.ImportInsert, importRows(
+			domain.ImportRow{Line: 2, Movie: domain.Movie{Title: " Aquarius ", Year: 2016}},
+			domain.ImportRow{Line: 3, Movie: domain.Movie{Title: "Bacurau", Year: 2019}},
+			domain.ImportRow{Line: 4, Movie: domain.Movie{Title: "", Year: 2019}},
+			domain.ImportRow{Line: 5, Err: domain.ErrInvalidReleaseDate},
+		))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), report.Accepted)
+		assert.Equal(t, int64(3), report.Rejected)
+		if assert.Len(t, report.Rows, 4) {
+			assert.Equal(t, domain.ImportCreated, report.Rows[0].Action)
+			assert.Equal(t, "2", report.Rows[0].Movie.ID)
+			assert.ErrorIs(t, report.Rows[1].Err, domain.ErrMovieAlreadyExists)
+			assert.ErrorIs(t, report.Rows[2].Err, domain.ErrInvalidMovie)
+			assert.Equal(t, int64(5), report.Rows[3].Line)
+			assert.ErrorIs(t, report.Rows[3].Err, domain.ErrInvalidReleaseDate)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		current := bacurau
+		mockRepo.On("FindByTitle", mock.Anything, "Bacurau", 2019).Return(&current, nil)
+		mockRepo.On("FindByTitle", mock.Anything, "Aquarius", 2016).Return(nil, domain.ErrMovieNotFound).Once()
+		mockRepo.On("Get", mock.Anything, "1").Return(&current, nil).Once()
+		mockRepo.On("Save", mock.Anything, domain.Movie{ID: "1", Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, Version: 2}).
+			Return(&domain.Movie{ID: "1", Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, Version: 3}, nil).Once()
+		mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Aquarius", Year: 2016}).
+			Return(&domain.Movie{ID: "2", Title: "Aquarius", Year: 2016, Version: 1}, nil).Once()
+
+		report, err := NewMovieService(mockRepo).ImportMovies(ctx, domain.ImportUpsert, importRows(
+			domain.ImportRow{Line: 1, Movie: domain.Movie{Title: "Bacurau", Year: 2019, Genres: []string{"Drama"}}},
+			domain.ImportRow{Line: 2, Movie: domain.Movie{Title: "Bacurau", Year: 2019, RuntimeMinutes: 131}},
+			domain.ImportRow{Line: 3, Movie: domain.Movie{Title: "Aquarius", Year: 2016}},
+		))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), report.Accepted)
+		actions := []domain.ImportAction{}
+		for _, row := range report.Rows {
+			actions = append(actions, row.Action)
+		}
+		assert.Equal(t, []domain.ImportAction{domain.ImportUnchanged, domain.ImportUpdated, domain.ImportCreated}, actions)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Dry-run não grava", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRepo.On("FindByTitle", mock.Anything, "Bacurau", 2019).Return(&bacurau, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "Aquarius", 2016).Return(nil, domain.ErrMovieNotFound).Once()
+
+		report, err := NewMovieService(mockRepo).ImportMovies(ctx, domain.ImportDryRun, importRows(
+			domain.ImportRow{Line: 1, Movie: domain.Movie{Title: "Bacurau", Year: 2019}},
+			domain.ImportRow{Line: 2, Movie: domain.Movie{Title: "Aquarius", Year: 2016}},
+			domain.ImportRow{Line: 3, Movie: domain.Movie{Title: "aquárius", Year: 2016}},
+		))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), report.Accepted)
+		assert.ErrorIs(t, report.Rows[0].Err, domain.ErrMovieAlreadyExists)
+		assert.Equal(t, domain.ImportValid, report.Rows[1].Action)
+		assert.ErrorIs(t, report.Rows[2].Err, domain.ErrMovieAlreadyExists)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Erro de leitura interrompe", func(t *testing.T) {
+		broken := errors.New("conexão encerrada")
+		_, err := NewMovieService(new(mocks.MovieRepositoryMock)).ImportMovies(ctx, domain.ImportInsert, func() (domain.ImportRow, error) {
+			return domain.ImportRow{}, broken
+		})
+		assert.ErrorIs(t, err, broken)
+	})
+}
+
+func TestSeedMovies(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Insert", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRepo.On("FindBySourceIDs", mock.Anything, []string{"1", "2", "3", "4"}).
+			Return([]domain.Movie{{ID: "a", Title: "Bacurau", Year: 2019, SourceID: "1", Version: 1}}, nil).Once()
+		mockRepo.On("InsertMany", mock.Anything, []domain.Movie{
+			{Title: "Aquarius", Year: 2016, SourceID: "2"},
+			{Title: "Tatuagem", Year: 2013, SourceID: "3"},
+			{Title: "O Som ao Redor", Year: 2012, SourceID: "4"},
+		}).Return([]domain.BatchResult{
+			{Movie: &domain.Movie{ID: "b", Title: "Aquarius", Year: 2016, SourceID: "2", Version: 1}},
+			{Err: domain.ErrMovieAlreadyExists},
+			{Err: domain.ErrMovieAlreadyExists},
+		}, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "Tatuagem", 2013).
+			Return(&domain.Movie{ID: "c", Title: "Tatuagem", Year: 2013, Genres: []string{"Drama"}, Version: 4}, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "O Som ao Redor", 2012).
+			Return(&domain.Movie{ID: "d", Title: "O Som ao Redor", Year: 2012, SourceID: "9", Version: 1}, nil).Once()
+		// Só o SourceID é gravado no filme que já existia, de uma vez para o lote e sem revisão; os demais campos
+		// ficam como estão.
+		mockRepo.On("LinkSources", mock.Anything, []domain.Movie{{ID: "c", Title: "Tatuagem", Year: 2013, Genres: []string{"Drama"}, SourceID: "3", Version: 4}}).
+			Return([]domain.BatchResult{{Movie: &domain.Movie{ID: "c", Title: "Tatuagem", Year: 2013, Genres: []string{"Drama"}, SourceID: "3", Version: 5}}}, nil).Once()
+		mockRevisions := new(mocks.MovieRevisionStoreMock)
+
+		report, err := NewMovieService(mockRepo, WithRevisionStore(mockRevisions)).SeedMovies(ctx, domain.ImportInsert, []domain.Movie{
+			{Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, SourceID: "1"},
+			{Title: "Aquarius", Year: 2016, SourceID: " 2 "},
+			{Title: "Tatuagem", Year: 2013, SourceID: "3"},
+			{Title: "O Som ao Redor", Year: 2012, SourceID: "4"},
+			{Title: "Sem id", Year: 2012},
+			{Title: "Repetido", Year: 2012, SourceID: "2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 1, report.Linked)
+		assert.Equal(t, 1, report.Unchanged)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Zero(t, report.Updated)
+		if assert.Len(t, report.Rejected, 2) {
+			assert.Equal(t, int64(5), report.Rejected[0].Line)
+			assert.ErrorIs(t, report.Rejected[0].Err, domain.ErrInvalidMovie)
+			assert.Equal(t, int64(6), report.Rejected[1].Line)
+		}
+		mockRepo.AssertExpectations(t)
+		mockRevisions.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Filmes na lixeira", func(t *testing.T) {
+		deletedAt := time.Now()
+		mockRepo := new(mocks.MovieRepositoryMock)
+		// O filme removido com o SourceID "2" foi substituído por outro, que é o usado no upsert.
+		mockRepo.On("FindBySourceIDs", mock.Anything, []string{"1", "2"}).Return([]domain.Movie{
+			{ID: "a", Title: "Bacurau", Year: 2019, SourceID: "1", Version: 2, DeletedAt: &deletedAt},
+			{ID: "b", Title: "Aquarius", Year: 2016, SourceID: "2", Version: 1},
+			{ID: "c", Title: "Aquarius", Year: 2016, SourceID: "2", Version: 3, DeletedAt: &deletedAt},
+		}, nil).Once()
+
+		report, err := NewMovieService(mockRepo).SeedMovies(ctx, domain.ImportUpsert, []domain.Movie{
+			{Title: "Bacurau", Year: 2019, SourceID: "1"},
+			{Title: "Aquarius", Year: 2016, SourceID: "2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Skipped, "o filme do seed que foi para a lixeira não é recriado")
+		assert.Equal(t, 1, report.Unchanged)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "InsertMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRepo.On("FindBySourceIDs", mock.Anything, []string{"1", "2"}).Return([]domain.Movie{
+			{ID: "a", Title: "Bacurau", Year: 2019, SourceID: "1", Version: 1},
+			{ID: "b", Title: "Aquarius", Year: 2016, SourceID: "2", Version: 2},
+		}, nil).Once()
+		mockRepo.On("Save", mock.Anything, domain.Movie{ID: "b", Title: "Aquarius", Year: 2016, RuntimeMinutes: 145, SourceID: "2", Version: 2}).
+			Return(&domain.Movie{ID: "b", Title: "Aquarius", Year: 2016, RuntimeMinutes: 145, SourceID: "2", Version: 3}, nil).Once()
+
+		report, err := NewMovieService(mockRepo).SeedMovies(ctx, domain.ImportUpsert, []domain.Movie{
+			{Title: "Bacurau", Year: 2019, SourceID: "1"},
+			{Title: "Aquarius", Year: 2016, RuntimeMinutes: 145, SourceID: "2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Updated)
+		assert.Equal(t, 1, report.Unchanged)
+		assert.Empty(t, report.Rejected)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "InsertMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Upsert ligando filme com campos diferentes", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRepo.On("FindBySourceIDs", mock.Anything, []string{"1", "2"}).Return([]domain.Movie{}, nil).Once()
+		mockRepo.On("InsertMany", mock.Anything, mock.Anything).Return([]domain.BatchResult{
+			{Err: domain.ErrMovieAlreadyExists}, {Err: domain.ErrMovieAlreadyExists},
+		}, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "Bacurau", 2019).Return(&domain.Movie{ID: "a", Title: "Bacurau", Year: 2019, Version: 1}, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "Aquarius", 2016).Return(&domain.Movie{ID: "b", Title: "Aquarius", Year: 2016, Version: 3}, nil).Once()
+		// O filme que recebe outros campos do arquivo é gravado com revisão; o que só ganha o SourceID vai no lote.
+		mockRepo.On("Save", mock.Anything, domain.Movie{ID: "a", Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, SourceID: "1", Version: 1}).
+			Return(&domain.Movie{ID: "a", Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, SourceID: "1", Version: 2}, nil).Once()
+		mockRepo.On("LinkSources", mock.Anything, []domain.Movie{{ID: "b", Title: "Aquarius", Year: 2016, SourceID: "2", Version: 3}}).
+			Return([]domain.BatchResult{{Err: domain.ErrVersionConflict}}, nil).Once()
+
+		report, err := NewMovieService(mockRepo).SeedMovies(ctx, domain.ImportUpsert, []domain.Movie{
+			{Title: "Bacurau", Year: 2019, RuntimeMinutes: 131, SourceID: "1"},
+			{Title: "Aquarius", Year: 2016, SourceID: "2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Linked)
+		if assert.Len(t, report.Rejected, 1) {
+			assert.Equal(t, int64(2), report.Rejected[0].Line)
+			assert.ErrorIs(t, report.Rejected[0].Err, domain.ErrVersionConflict)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Ids externos", func(t *testing.T) {
+		mockRepo := new(mocks.MovieRepositoryMock)
+		mockRepo.On("FindBySourceIDs", mock.Anything, []string{"10"}).Return([]domain.Movie{}, nil).Once()
+		mockRepo.On("InsertMany", mock.Anything, mock.Anything).Return([]domain.BatchResult{{Err: domain.ErrMovieAlreadyExists}}, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "La sortie des usines Lumière", 1895).Return(&domain.Movie{
+			ID: "a", Title: "La sortie des usines Lumière", Year: 1895, ExternalIDs: map[string]string{"tmdb": "775"}, Version: 1,
+		}, nil).Once()
+		// O id do IMDb do arquivo se junta aos ids de outros provedores que o filme já tinha.
+		mockRepo.On("LinkSources", mock.Anything, []domain.Movie{{
+			ID: "a", Title: "La sortie des usines Lumière", Year: 1895, SourceID: "10",
+			ExternalIDs: map[string]string{"tmdb": "775", "imdb": "tt0000010"}, Version: 1,
+		}}).Return([]domain.BatchResult{{Movie: &domain.Movie{ID: "a", Version: 2}}}, nil).Once()
+
+		report, err := NewMovieService(mockRepo).SeedMovies(ctx, domain.ImportInsert, []domain.Movie{
+			{Title: "La sortie des usines Lumière (1895)", Year: 1895, SourceID: "10", ExternalIDs: map[string]string{"imdb": "10"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Linked)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Dry-run não é aceito", func(t *testing.T) {
+		_, err := NewMovieService(new(mocks.MovieRepositoryMock)).SeedMovies(ctx, domain.ImportDryRun, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidImportMode)
+	})
+}
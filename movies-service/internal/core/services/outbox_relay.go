@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports"
+)
+
+// Padrões do relay: eventos publicados por rodada, por quanto tempo um evento fica reservado para a instância que
+// o publica e espera depois da primeira falha de um evento, que dobra a cada nova falha até o máximo. A reserva
+// cobre com folga a conexão e a confirmação do broker.
+const (
+	defaultRelayBatchSize  = 100
+	defaultRelayLease      = time.Minute
+	defaultRelayMinBackoff = time.Second
+	defaultRelayMaxBackoff = 5 * time.Minute
+)
+
+// OutboxRelay publica no broker os eventos pendentes da caixa de saída. Cada evento é reservado antes de ser
+// publicado, então as réplicas do serviço podem rodar o relay ao mesmo tempo sem publicar o mesmo evento duas vezes.
+// Um evento só sai dos pendentes depois que o broker o confirmou; se o serviço cair entre a confirmação e MarkSent,
+// ou antes de terminar a publicação, o evento é publicado de novo quando a reserva vencer. A entrega é, portanto,
+// pelo menos uma vez: quem consome descarta repetidos pelo ID do evento.
+type OutboxRelay struct {
+	outbox     ports.EventOutbox
+	publisher  ports.EventPublisher
+	batchSize  int
+	lease      time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	now        func() time.Time
+}
+
+// NewOutboxRelay cria o relay que lê de outbox e publica em publisher.
+func NewOutboxRelay(outbox ports.EventOutbox, publisher ports.EventPublisher) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:     outbox,
+		publisher:  publisher,
+		batchSize:  defaultRelayBatchSize,
+		lease:      defaultRelayLease,
+		minBackoff: defaultRelayMinBackoff,
+		maxBackoff: defaultRelayMaxBackoff,
+		now:        time.Now,
+	}
+}
+
+// RelayPending publica até um lote de eventos pendentes, na ordem em que foram gravados, e diz quantos foram
+// enviados. Os eventos são reservados um a um, para que nenhum fique preso na reserva quando a rodada para.
+// Na primeira falha do broker o evento é reagendado com backoff exponencial e a rodada para, já que os seguintes
+// provavelmente falhariam pelo mesmo motivo.
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	sent := 0
+	for sent < r.batchSize {
+		now := r.now()
+		entry, err := r.outbox.Claim(ctx, now, now.Add(r.lease))
+		if err != nil {
+			return sent, err
+		}
+		if entry == nil {
+			break
+		}
+		if err := r.publisher.Publish(ctx, entry.Event); err != nil {
+			attempts := entry.Attempts + 1
+			if rerr := r.outbox.Reschedule(ctx, entry.Event.ID, attempts, r.now().Add(r.backoff(attempts)), err.Error()); rerr != nil {
+				return sent, rerr
+			}
+			return sent, fmt.Errorf("evento %s, tentativa %d: %w", entry.Event.ID, attempts, err)
+		}
+		if err := r.outbox.MarkSent(ctx, entry.Event.ID, r.now()); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// backoff é a espera antes da próxima tentativa de um evento que já falhou attempts vezes.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	wait := r.minBackoff
+	for i := 1; i < attempts && wait < r.maxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, r.maxBackoff)
+}
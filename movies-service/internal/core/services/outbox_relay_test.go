@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/domain"
+	"github.com/jamescookdev/projeto-sipub-tech/movies-service/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxRelay_RelayPending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	created := domain.MovieEvent{ID: "a:1", Type: domain.EventMovieCreated}
+	updated := domain.MovieEvent{ID: "a:2", Type: domain.EventMovieUpdated}
+
+	mockOutbox := new(mocks.EventOutboxMock)
+	mockPublisher := new(mocks.EventPublisherMock)
+	relay := NewOutboxRelay(mockOutbox, mockPublisher)
+	relay.now = func() time.Time { return now }
+
+	// Cada evento é reservado pela duração da reserva antes de ser publicado; o seguinte fica para a próxima rodada.
+	lease := now.Add(defaultRelayLease)
+	mockOutbox.On("Claim", mock.Anything, now, lease).Return(&domain.OutboxEntry{Event: created}, nil).Once()
+	mockOutbox.On("Claim", mock.Anything, now, lease).Return(&domain.OutboxEntry{Event: updated, Attempts: 2}, nil).Once()
+	mockPublisher.On("Publish", mock.Anything, created).Return(nil).Once()
+	mockOutbox.On("MarkSent", mock.Anything, "a:1", now).Return(nil).Once()
+	// Na terceira falha, a espera é o dobro do dobro da inicial.
+	mockPublisher.On("Publish", mock.Anything, updated).Return(errors.New("broker fora do ar")).Once()
+	mockOutbox.On("Reschedule", mock.Anything, "a:2", 3, now.Add(4*time.Second), "broker fora do ar").Return(nil).Once()
+
+	sent, err := relay.RelayPending(ctx)
+	assert.Equal(t, 1, sent)
+	assert.ErrorContains(t, err, "evento a:2, tentativa 3: broker fora do ar")
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+
+	// Sem pendentes, a rodada termina sem erro.
+	mockOutbox.On("Claim", mock.Anything, now, lease).Return(nil, nil).Once()
+	sent, err = relay.RelayPending(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+	mockOutbox.AssertExpectations(t)
+}
+
+// fakeOutbox é uma caixa de saída em memória cuja reserva, como a do adaptador, é atômica.
+type fakeOutbox struct {
+	mu      sync.Mutex
+	pending []*domain.OutboxEntry
+	next    map[string]time.Time
+	sent    map[string]int
+}
+
+func newFakeOutbox(events ...domain.MovieEvent) *fakeOutbox {
+	o := &fakeOutbox{next: map[string]time.Time{}, sent: map[string]int{}}
+	for _, event := range events {
+		o.pending = append(o.pending, &domain.OutboxEntry{Event: event})
+	}
+	return o
+}
+
+func (o *fakeOutbox) Publish(ctx context.Context, event domain.MovieEvent) error {
+	return errors.New("não usado")
+}
+
+func (o *fakeOutbox) Claim(ctx context.Context, now, leaseUntil time.Time) (*domain.OutboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range o.pending {
+		id := entry.Event.ID
+		if o.sent[id] == 0 && !o.next[id].After(now) {
+			o.next[id] = leaseUntil
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (o *fakeOutbox) MarkSent(ctx context.Context, eventID string, sentAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent[eventID]++
+	return nil
+}
+
+func (o *fakeOutbox) Reschedule(ctx context.Context, eventID string, attempts int, next time.Time, lastErr string) error {
+	return errors.New("não usado")
+}
+
+// countingPublisher conta quantas vezes cada evento chegou ao broker.
+type countingPublisher struct {
+	mu        sync.Mutex
+	published map[string]int
+}
+
+func (p *countingPublisher) Publish(ctx context.Context, event domain.MovieEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published[event.ID]++
+	return nil
+}
+
+func TestOutboxRelay_ConcurrentRelays(t *testing.T) {
+	var events []domain.MovieEvent
+	for i := range 200 {
+		events = append(events, domain.MovieEvent{ID: fmt.Sprintf("filme_%d:1", i), Type: domain.EventMovieCreated})
+	}
+	outbox := newFakeOutbox(events...)
+	publisher := &countingPublisher{published: map[string]int{}}
+
+	// Duas réplicas rodando o relay sobre a mesma caixa de saída, cada uma com lotes menores que o total.
+	var wg sync.WaitGroup
+	for range 2 {
+		relay := NewOutboxRelay(outbox, publisher)
+		relay.batchSize = 7
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				sent, err := relay.RelayPending(context.Background())
+				if !assert.NoError(t, err) || sent == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, publisher.published, len(events))
+	for _, event := range events {
+		assert.Equal(t, 1, publisher.published[event.ID], event.ID)
+		assert.Equal(t, 1, outbox.sent[event.ID], event.ID)
+	}
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	relay := NewOutboxRelay(nil, nil)
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 256*time.Second, relay.backoff(9))
+	assert.Equal(t, 5*time.Minute, relay.backoff(10))
+	assert.Equal(t, 5*time.Minute, relay.backoff(1000))
+}
+
+func TestMovieEvents_Transaction(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockOutbox := new(mocks.EventOutboxMock)
+	mockTx := new(mocks.TransactorMock)
+	movieService := NewMovieService(mockRepo, WithEventPublisher(mockOutbox), WithTransactor(mockTx))
+	ctx := context.Background()
+
+	created := domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, Version: 1}
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Twice()
+	mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Bacurau", Year: 2019}).Return(&created, nil).Twice()
+	mockOutbox.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.MovieEvent) bool {
+		return event.ID == "id_novo:1"
+	})).Return(nil).Once()
+	_, err := movieService.CreateMovie(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	assert.NoError(t, err)
+
+	// Na transação, o evento faz parte da escrita: se não for gravado, a escrita falha e é desfeita.
+	mockOutbox.On("Publish", mock.Anything, mock.Anything).Return(errors.New("outbox indisponível")).Once()
+	_, err = movieService.CreateMovie(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	assert.EqualError(t, err, "outbox indisponível")
+
+	// O lote é gravado com um único InsertMany, na mesma transação dos eventos.
+	batch := []domain.Movie{{Title: "Aquarius", Year: 2016}, {Title: "Tatuagem", Year: 2013}}
+	aquarius := &domain.Movie{ID: "id_1", Title: "Aquarius", Year: 2016, Version: 1}
+	tatuagem := &domain.Movie{ID: "id_2", Title: "Tatuagem", Year: 2013, Version: 1}
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+	mockRepo.On("InsertMany", mock.Anything, batch).Return([]domain.BatchResult{{Movie: aquarius}, {Movie: tatuagem}}, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, mock.Anything).Return(nil).Twice()
+	results, err := movieService.BulkCreateMovies(ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, "id_1", results[0].Movie.ID)
+	assert.Equal(t, "id_2", results[1].Movie.ID)
+
+	// Um repetido desfaz a transação do lote; cada filme é gravado de novo na sua, e o repetido não desfaz os demais.
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Times(3)
+	mockRepo.On("InsertMany", mock.Anything, batch).Return([]domain.BatchResult{{Err: domain.ErrMovieAlreadyExists}, {Movie: tatuagem}}, nil).Once()
+	mockRepo.On("Save", mock.Anything, batch[0]).Return(nil, domain.ErrMovieAlreadyExists).Once()
+	mockRepo.On("Save", mock.Anything, batch[1]).Return(tatuagem, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, mock.Anything).Return(nil).Once()
+	results, err = movieService.BulkCreateMovies(ctx, batch)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, domain.ErrMovieAlreadyExists)
+	assert.Equal(t, "id_2", results[1].Movie.ID)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestMovieEvents_TransactionRevision(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockOutbox := new(mocks.EventOutboxMock)
+	mockTx := new(mocks.TransactorMock)
+	mockRevisions := new(mocks.MovieRevisionStoreMock)
+	movieService := NewMovieService(mockRepo, WithEventPublisher(mockOutbox), WithTransactor(mockTx), WithRevisionStore(mockRevisions))
+	ctx := context.Background()
+
+	// Na transação, a revisão também faz parte da escrita: se não for gravada, a escrita falha e o evento não sai.
+	created := domain.Movie{ID: "id_novo", Title: "Bacurau", Year: 2019, Version: 1}
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+	mockRepo.On("Save", mock.Anything, domain.Movie{Title: "Bacurau", Year: 2019}).Return(&created, nil).Once()
+	mockRevisions.On("Append", mock.Anything, mock.MatchedBy(func(revision domain.MovieRevision) bool {
+		return revision.MovieID == "id_novo" && revision.Version == 1
+	})).Return(errors.New("revisões indisponíveis")).Once()
+	_, err := movieService.CreateMovie(ctx, domain.Movie{Title: "Bacurau", Year: 2019})
+	assert.EqualError(t, err, "revisões indisponíveis")
+
+	mockRepo.AssertExpectations(t)
+	mockRevisions.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestMovieEvents_SeedAndPurge(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockOutbox := new(mocks.EventOutboxMock)
+	mockTx := new(mocks.TransactorMock)
+	movieService := NewMovieService(mockRepo, WithEventPublisher(mockOutbox), WithTransactor(mockTx))
+	ctx := domain.WithActor(context.Background(), "seed")
+	createdBy := func(id string) any {
+		return mock.MatchedBy(func(event domain.MovieEvent) bool {
+			return event.ID == id+":1" && event.Type == domain.EventMovieCreated && event.Actor == "seed"
+		})
+	}
+
+	// Os filmes criados pelo seed têm evento, na mesma transação do lote, mas não revisão.
+	aquarius := &domain.Movie{ID: "id_1", Title: "Aquarius", Year: 2016, SourceID: "1", Version: 1}
+	tatuagem := &domain.Movie{ID: "id_2", Title: "Tatuagem", Year: 2013, SourceID: "2", Version: 1}
+	seed := []domain.Movie{{Title: "Aquarius", Year: 2016, SourceID: "1"}, {Title: "Tatuagem", Year: 2013, SourceID: "2"}}
+	mockRepo.On("FindBySourceIDs", mock.Anything, []string{"1", "2"}).Return([]domain.Movie{}, nil).Twice()
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+	mockRepo.On("InsertMany", mock.Anything, seed).Return([]domain.BatchResult{{Movie: aquarius}, {Movie: tatuagem}}, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, createdBy("id_1")).Return(nil).Once()
+	mockOutbox.On("Publish", mock.Anything, createdBy("id_2")).Return(nil).Once()
+	report, err := movieService.SeedMovies(ctx, domain.ImportInsert, seed)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+
+	// Um repetido desfaz a transação do lote; cada filme é gravado de novo na sua, com o seu evento.
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Times(3)
+	mockRepo.On("InsertMany", mock.Anything, seed).Return([]domain.BatchResult{{Err: domain.ErrMovieAlreadyExists}, {Movie: tatuagem}}, nil).Once()
+	mockRepo.On("Save", mock.Anything, seed[0]).Return(nil, domain.ErrMovieAlreadyExists).Once()
+	mockRepo.On("Save", mock.Anything, seed[1]).Return(tatuagem, nil).Once()
+	mockRepo.On("FindByTitle", mock.Anything, "Aquarius", 2016).Return(nil, domain.ErrMovieNotFound).Once()
+	mockOutbox.On("Publish", mock.Anything, createdBy("id_2")).Return(nil).Once()
+	report, err = movieService.SeedMovies(ctx, domain.ImportInsert, seed)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+
+	// O expurgo publica um evento por filme removido, na mesma transação.
+	deletedAt := time.Now()
+	purgedMovie := domain.Movie{ID: "id_3", Title: "Bacurau", Year: 2019, DeletedAt: &deletedAt, Version: 4}
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+	mockRepo.On("Purge", mock.Anything, mock.Anything, domain.MaxBatchSize).Return([]domain.Movie{purgedMovie}, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.MovieEvent) bool {
+		return event.ID == "id_3:purged" && event.Type == domain.EventMoviePurged && event.Actor == "purge" &&
+			assert.ObjectsAreEqual(purgedMovie, event.Movie)
+	})).Return(nil).Once()
+	purged, err := movieService.PurgeDeletedMovies(domain.WithActor(context.Background(), "purge"), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	// Uma lixeira grande sai em lotes, cada um na sua transação; se os eventos de um lote não forem gravados, esse
+	// lote é desfeito, e os anteriores continuam removidos.
+	fullBatch := make([]domain.Movie, domain.MaxBatchSize)
+	for i := range fullBatch {
+		fullBatch[i] = domain.Movie{ID: fmt.Sprintf("lixo_%d", i), DeletedAt: &deletedAt}
+	}
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Twice()
+	mockRepo.On("Purge", mock.Anything, mock.Anything, domain.MaxBatchSize).Return(fullBatch, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, mock.Anything).Return(nil).Times(domain.MaxBatchSize)
+	mockRepo.On("Purge", mock.Anything, mock.Anything, domain.MaxBatchSize).Return([]domain.Movie{purgedMovie}, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, mock.Anything).Return(errors.New("outbox indisponível")).Once()
+	purged, err = movieService.PurgeDeletedMovies(ctx, time.Hour)
+	assert.EqualError(t, err, "outbox indisponível")
+	assert.Equal(t, int64(domain.MaxBatchSize), purged)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestMovieEvents_SeedLinks(t *testing.T) {
+	mockRepo := new(mocks.MovieRepositoryMock)
+	mockOutbox := new(mocks.EventOutboxMock)
+	mockTx := new(mocks.TransactorMock)
+	movieService := NewMovieService(mockRepo, WithEventPublisher(mockOutbox), WithTransactor(mockTx))
+	ctx := domain.WithActor(context.Background(), "seed")
+
+	// Os dois filmes do arquivo já estão no catálogo sem SourceID: a criação falha e eles são ligados.
+	seed := []domain.Movie{{Title: "Aquarius", Year: 2016, SourceID: "1"}, {Title: "Tatuagem", Year: 2013, SourceID: "2"}}
+	aquarius := domain.Movie{ID: "id_1", Title: "Aquarius", Year: 2016, Version: 2}
+	tatuagem := domain.Movie{ID: "id_2", Title: "Tatuagem", Year: 2013, Version: 5}
+	linkedAquarius, linkedTatuagem := aquarius, tatuagem
+	linkedAquarius.SourceID, linkedAquarius.Version = "1", 3
+	linkedTatuagem.SourceID, linkedTatuagem.Version = "2", 6
+	links := []domain.Movie{aquarius, tatuagem}
+	links[0].SourceID, links[1].SourceID = "1", "2"
+	updatedTo := func(id string, version int64) any {
+		return mock.MatchedBy(func(event domain.MovieEvent) bool {
+			return event.ID == fmt.Sprintf("%s:%d", id, version) && event.Type == domain.EventMovieUpdated && event.Actor == "seed"
+		})
+	}
+	prepare := func() {
+		mockRepo.On("FindBySourceIDs", mock.Anything, []string{"1", "2"}).Return([]domain.Movie{}, nil).Once()
+		mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+		mockRepo.On("InsertMany", mock.Anything, seed).Return([]domain.BatchResult{{Err: domain.ErrMovieAlreadyExists}, {Err: domain.ErrMovieAlreadyExists}}, nil).Once()
+		mockTx.On("WithinTransaction", mock.Anything).Return(nil).Twice()
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil, domain.ErrMovieAlreadyExists).Twice()
+		mockRepo.On("FindByTitle", mock.Anything, "Aquarius", 2016).Return(&aquarius, nil).Once()
+		mockRepo.On("FindByTitle", mock.Anything, "Tatuagem", 2013).Return(&tatuagem, nil).Once()
+	}
+
+	// As ligações saem num só LinkSources, com um evento de alteração por filme, na mesma transação.
+	prepare()
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+	mockRepo.On("LinkSources", mock.Anything, links).Return([]domain.BatchResult{{Movie: &linkedAquarius}, {Movie: &linkedTatuagem}}, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, updatedTo("id_1", 3)).Return(nil).Once()
+	mockOutbox.On("Publish", mock.Anything, updatedTo("id_2", 6)).Return(nil).Once()
+	report, err := movieService.SeedMovies(ctx, domain.ImportInsert, seed)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Linked)
+
+	// Um filme recusado desfaz a transação das ligações; cada um é ligado de novo na sua, com o seu evento.
+	prepare()
+	mockTx.On("WithinTransaction", mock.Anything).Return(nil).Times(3)
+	mockRepo.On("LinkSources", mock.Anything, links).Return([]domain.BatchResult{{Err: domain.ErrVersionConflict}, {Movie: &linkedTatuagem}}, nil).Once()
+	mockRepo.On("LinkSources", mock.Anything, links[:1]).Return([]domain.BatchResult{{Err: domain.ErrVersionConflict}}, nil).Once()
+	mockRepo.On("LinkSources", mock.Anything, links[1:]).Return([]domain.BatchResult{{Movie: &linkedTatuagem}}, nil).Once()
+	mockOutbox.On("Publish", mock.Anything, updatedTo("id_2", 6)).Return(nil).Once()
+	report, err = movieService.SeedMovies(ctx, domain.ImportInsert, seed)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Linked)
+	if assert.Len(t, report.Rejected, 1) {
+		assert.Equal(t, int64(1), report.Rejected[0].Line)
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}